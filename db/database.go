@@ -35,7 +35,7 @@ func ConnectDB() error {
 	DB = db
 
 	// Auto-migrate the schema
-	err = DB.AutoMigrate(&models.Team{}, &models.Match{})
+	err = DB.AutoMigrate(&models.Team{}, &models.Match{}, &models.TeamRating{}, &models.MatchEvent{}, &models.WebhookSubscription{}, &models.WebhookDelivery{}, &models.Player{}, &models.Manager{}, &models.FantasySquad{}, &models.FantasySquadPlayer{}, &models.PlayerGameweek{}, &models.FantasyPick{}, &models.ManagerGameweek{}, &models.Transfer{}, &models.Lineup{}, &models.ChipActivation{}, &models.MiniLeague{}, &models.MiniLeagueMember{}, &models.HeadToHeadFixture{}, &models.PriceChange{}, &models.User{}, &models.RefreshToken{}, &models.APIKey{}, &models.ScorePrediction{}, &models.Wallet{}, &models.Bet{}, &models.WalletTransaction{})
 	if err != nil {
 		return fmt.Errorf("failed to migrate database schema: %w", err)
 	}
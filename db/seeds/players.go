@@ -0,0 +1,120 @@
+package seeds
+
+import (
+	"insider-league/models"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// squads holds the seeded first-team squad of each club, keyed by team name
+var squads = map[string][]models.Player{
+	"Chelsea": {
+		{Name: "Robert Sánchez", Position: models.PositionGoalkeeper, ShirtNumber: 1, Rating: 78, Price: 4.5},
+		{Name: "Filip Jørgensen", Position: models.PositionGoalkeeper, ShirtNumber: 12, Rating: 74, Price: 4.0},
+		{Name: "Marc Cucurella", Position: models.PositionDefender, ShirtNumber: 3, Rating: 80, Price: 5.0},
+		{Name: "Tosin Adarabioyo", Position: models.PositionDefender, ShirtNumber: 4, Rating: 77, Price: 4.5},
+		{Name: "Levi Colwill", Position: models.PositionDefender, ShirtNumber: 6, Rating: 80, Price: 4.5},
+		{Name: "Reece James", Position: models.PositionDefender, ShirtNumber: 24, Rating: 82, Price: 5.5},
+		{Name: "Malo Gusto", Position: models.PositionDefender, ShirtNumber: 27, Rating: 78, Price: 4.5},
+		{Name: "Wesley Fofana", Position: models.PositionDefender, ShirtNumber: 29, Rating: 79, Price: 4.5},
+		{Name: "Pedro Neto", Position: models.PositionMidfielder, ShirtNumber: 7, Rating: 81, Price: 6.5},
+		{Name: "Enzo Fernández", Position: models.PositionMidfielder, ShirtNumber: 8, Rating: 83, Price: 5.5},
+		{Name: "Noni Madueke", Position: models.PositionMidfielder, ShirtNumber: 11, Rating: 79, Price: 6.0},
+		{Name: "Cole Palmer", Position: models.PositionMidfielder, ShirtNumber: 20, Rating: 88, Price: 10.5},
+		{Name: "Moisés Caicedo", Position: models.PositionMidfielder, ShirtNumber: 25, Rating: 84, Price: 5.0},
+		{Name: "Roméo Lavia", Position: models.PositionMidfielder, ShirtNumber: 45, Rating: 77, Price: 4.5},
+		{Name: "Nicolas Jackson", Position: models.PositionForward, ShirtNumber: 15, Rating: 81, Price: 7.5},
+		{Name: "Christopher Nkunku", Position: models.PositionForward, ShirtNumber: 18, Rating: 80, Price: 6.5},
+	},
+	"Arsenal": {
+		{Name: "David Raya", Position: models.PositionGoalkeeper, ShirtNumber: 22, Rating: 85, Price: 5.5},
+		{Name: "Neto", Position: models.PositionGoalkeeper, ShirtNumber: 32, Rating: 75, Price: 4.0},
+		{Name: "William Saliba", Position: models.PositionDefender, ShirtNumber: 2, Rating: 87, Price: 6.0},
+		{Name: "Ben White", Position: models.PositionDefender, ShirtNumber: 4, Rating: 82, Price: 6.0},
+		{Name: "Gabriel Magalhães", Position: models.PositionDefender, ShirtNumber: 6, Rating: 86, Price: 6.0},
+		{Name: "Jurriën Timber", Position: models.PositionDefender, ShirtNumber: 12, Rating: 81, Price: 5.5},
+		{Name: "Jakub Kiwior", Position: models.PositionDefender, ShirtNumber: 15, Rating: 77, Price: 4.5},
+		{Name: "Riccardo Calafiori", Position: models.PositionDefender, ShirtNumber: 33, Rating: 80, Price: 5.5},
+		{Name: "Bukayo Saka", Position: models.PositionMidfielder, ShirtNumber: 7, Rating: 88, Price: 10.0},
+		{Name: "Martin Ødegaard", Position: models.PositionMidfielder, ShirtNumber: 8, Rating: 87, Price: 8.5},
+		{Name: "Gabriel Martinelli", Position: models.PositionMidfielder, ShirtNumber: 11, Rating: 82, Price: 7.0},
+		{Name: "Leandro Trossard", Position: models.PositionMidfielder, ShirtNumber: 19, Rating: 81, Price: 7.0},
+		{Name: "Mikel Merino", Position: models.PositionMidfielder, ShirtNumber: 23, Rating: 81, Price: 5.5},
+		{Name: "Declan Rice", Position: models.PositionMidfielder, ShirtNumber: 41, Rating: 87, Price: 6.5},
+		{Name: "Gabriel Jesus", Position: models.PositionForward, ShirtNumber: 9, Rating: 80, Price: 7.0},
+		{Name: "Kai Havertz", Position: models.PositionForward, ShirtNumber: 29, Rating: 83, Price: 8.0},
+	},
+	"Manchester City": {
+		{Name: "Stefan Ortega", Position: models.PositionGoalkeeper, ShirtNumber: 18, Rating: 79, Price: 4.0},
+		{Name: "Ederson", Position: models.PositionGoalkeeper, ShirtNumber: 31, Rating: 86, Price: 5.5},
+		{Name: "Kyle Walker", Position: models.PositionDefender, ShirtNumber: 2, Rating: 82, Price: 5.0},
+		{Name: "Rúben Dias", Position: models.PositionDefender, ShirtNumber: 3, Rating: 88, Price: 5.5},
+		{Name: "John Stones", Position: models.PositionDefender, ShirtNumber: 5, Rating: 84, Price: 5.5},
+		{Name: "Nathan Aké", Position: models.PositionDefender, ShirtNumber: 6, Rating: 81, Price: 5.0},
+		{Name: "Joško Gvardiol", Position: models.PositionDefender, ShirtNumber: 24, Rating: 85, Price: 6.0},
+		{Name: "Manuel Akanji", Position: models.PositionDefender, ShirtNumber: 25, Rating: 83, Price: 5.0},
+		{Name: "Mateo Kovačić", Position: models.PositionMidfielder, ShirtNumber: 8, Rating: 82, Price: 5.0},
+		{Name: "Jack Grealish", Position: models.PositionMidfielder, ShirtNumber: 10, Rating: 80, Price: 6.5},
+		{Name: "Rodri", Position: models.PositionMidfielder, ShirtNumber: 16, Rating: 91, Price: 6.5},
+		{Name: "Kevin De Bruyne", Position: models.PositionMidfielder, ShirtNumber: 17, Rating: 89, Price: 9.5},
+		{Name: "Bernardo Silva", Position: models.PositionMidfielder, ShirtNumber: 20, Rating: 86, Price: 6.5},
+		{Name: "Phil Foden", Position: models.PositionMidfielder, ShirtNumber: 47, Rating: 87, Price: 9.0},
+		{Name: "Omar Marmoush", Position: models.PositionForward, ShirtNumber: 7, Rating: 84, Price: 8.0},
+		{Name: "Erling Haaland", Position: models.PositionForward, ShirtNumber: 9, Rating: 91, Price: 15.0},
+	},
+	"Liverpool": {
+		{Name: "Alisson", Position: models.PositionGoalkeeper, ShirtNumber: 1, Rating: 89, Price: 5.5},
+		{Name: "Caoimhín Kelleher", Position: models.PositionGoalkeeper, ShirtNumber: 62, Rating: 78, Price: 4.5},
+		{Name: "Joe Gomez", Position: models.PositionDefender, ShirtNumber: 2, Rating: 78, Price: 4.5},
+		{Name: "Virgil van Dijk", Position: models.PositionDefender, ShirtNumber: 4, Rating: 89, Price: 6.5},
+		{Name: "Ibrahima Konaté", Position: models.PositionDefender, ShirtNumber: 5, Rating: 84, Price: 5.5},
+		{Name: "Kostas Tsimikas", Position: models.PositionDefender, ShirtNumber: 21, Rating: 77, Price: 4.5},
+		{Name: "Andrew Robertson", Position: models.PositionDefender, ShirtNumber: 26, Rating: 82, Price: 6.0},
+		{Name: "Trent Alexander-Arnold", Position: models.PositionDefender, ShirtNumber: 66, Rating: 86, Price: 7.0},
+		{Name: "Luis Díaz", Position: models.PositionMidfielder, ShirtNumber: 7, Rating: 84, Price: 8.0},
+		{Name: "Dominik Szoboszlai", Position: models.PositionMidfielder, ShirtNumber: 8, Rating: 82, Price: 6.5},
+		{Name: "Alexis Mac Allister", Position: models.PositionMidfielder, ShirtNumber: 10, Rating: 85, Price: 6.5},
+		{Name: "Mohamed Salah", Position: models.PositionMidfielder, ShirtNumber: 11, Rating: 90, Price: 13.5},
+		{Name: "Curtis Jones", Position: models.PositionMidfielder, ShirtNumber: 17, Rating: 79, Price: 5.5},
+		{Name: "Ryan Gravenberch", Position: models.PositionMidfielder, ShirtNumber: 38, Rating: 83, Price: 5.5},
+		{Name: "Darwin Núñez", Position: models.PositionForward, ShirtNumber: 9, Rating: 81, Price: 7.0},
+		{Name: "Cody Gakpo", Position: models.PositionForward, ShirtNumber: 18, Rating: 83, Price: 7.5},
+	},
+}
+
+// loadPlayers seeds the squads of the seeded clubs when no players exist yet
+func loadPlayers(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&models.Player{}).Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	var teams []models.Team
+	if err := db.Find(&teams).Error; err != nil {
+		return err
+	}
+
+	var players []models.Player
+	for _, team := range teams {
+		for _, player := range squads[team.Name] {
+			player.TeamID = team.ID
+			players = append(players, player)
+		}
+	}
+
+	if len(players) == 0 {
+		return nil
+	}
+
+	if err := db.Create(&players).Error; err != nil {
+		return err
+	}
+
+	log.Printf("Seeded %d players.", len(players))
+	return nil
+}
@@ -1,6 +1,7 @@
 package seeds
 
 import (
+	"insider-league/helpers"
 	"insider-league/models"
 	"log"
 
@@ -17,15 +18,17 @@ func Load(db *gorm.DB) error {
 
 	if count > 0 {
 		log.Println("Database already seeded.")
-		return nil
+
+		// Databases seeded before squads existed still get their players
+		return loadPlayers(db)
 	}
 
 	// Create teams
 	teams := []models.Team{
-		{Name: "Chelsea", Strength: 85, Stats: models.Stats{}},
-		{Name: "Arsenal", Strength: 87, Stats: models.Stats{}},
-		{Name: "Manchester City", Strength: 94, Stats: models.Stats{}},
-		{Name: "Liverpool", Strength: 92, Stats: models.Stats{}},
+		{Name: "Chelsea", Attack: 84, Defence: 86, Strength: 85, BaseStrength: 85, Stats: models.Stats{}},
+		{Name: "Arsenal", Attack: 86, Defence: 88, Strength: 87, BaseStrength: 87, Stats: models.Stats{}},
+		{Name: "Manchester City", Attack: 96, Defence: 92, Strength: 94, BaseStrength: 94, Stats: models.Stats{}},
+		{Name: "Liverpool", Attack: 93, Defence: 91, Strength: 92, BaseStrength: 92, Stats: models.Stats{}},
 	}
 
 	// Save teams to database
@@ -42,42 +45,26 @@ func Load(db *gorm.DB) error {
 	}
 
 	log.Println("Database seeded successfully with 4 teams and 12 matches.")
-	return nil
+	return loadPlayers(db)
 }
 
 // generateFixtures creates all matches for the season
 func generateFixtures(teams []models.Team) []models.Match {
 	var matches []models.Match
-	numTeams := len(teams)
-
-	// For odd number of teams, we'll need to add a "bye" team
-	// This ensures we always have an even number of teams to schedule
-	if numTeams%2 != 0 {
-		log.Printf("Warning: Odd number of teams (%d). Adding a bye team.", numTeams)
-		// Add a dummy team for bye weeks
-		teams = append(teams, models.Team{Name: "BYE", Strength: 0})
-		numTeams = len(teams)
+
+	if len(teams)%2 != 0 {
+		log.Printf("Warning: Odd number of teams (%d). One team rests each week.", len(teams))
 	}
 
-	weeksPerHalf := numTeams - 1
+	// Every pairing is played at home in the first half of the season and away in the second
+	rounds := helpers.RoundRobin(len(teams))
+	weeksPerHalf := len(rounds)
 	totalWeeks := weeksPerHalf * 2
 
-	// Create a copy of teams slice to rotate
-	teamRotation := make([]models.Team, len(teams))
-	copy(teamRotation, teams)
-
-	// First half of the season
-	for week := 1; week <= weeksPerHalf; week++ {
-		// For each week, create matches between teams at opposite ends of the rotation
-		// For n teams, we create n/2 matches per week
-		for i := 0; i < numTeams/2; i++ {
-			homeTeam := teamRotation[i]
-			awayTeam := teamRotation[numTeams-1-i]
-
-			// Skip matches involving the BYE team
-			if homeTeam.Name == "BYE" || awayTeam.Name == "BYE" {
-				continue
-			}
+	for i, round := range rounds {
+		week := i + 1
+		for _, pair := range round {
+			homeTeam, awayTeam := teams[pair[0]], teams[pair[1]]
 
 			// Home match
 			matches = append(matches, models.Match{
@@ -99,12 +86,6 @@ func generateFixtures(teams []models.Team) []models.Match {
 				IsPlayed:      false,
 			})
 		}
-
-		// Rotate teams for next week (keep first team fixed, rotate others)
-		// Move the second team to the end, shift others up
-		secondTeam := teamRotation[1]
-		copy(teamRotation[1:], teamRotation[2:])
-		teamRotation[len(teamRotation)-1] = secondTeam
 	}
 
 	log.Printf("Generated %d matches over %d weeks", len(matches), totalWeeks)
@@ -0,0 +1,88 @@
+package eventbus
+
+import (
+	"insider-league/models"
+	"sync"
+	"time"
+)
+
+// Event types published when the league changes
+const (
+	WeekPlayed        = "week_played"
+	MatchResultEdited = "match_result_edited"
+	LeagueReset       = "league_reset"
+	SeasonFinished    = "season_finished"
+	TeamChanged       = "team_changed"
+)
+
+// Event represents a change to the league that subscribers are notified about
+type Event struct {
+	Type        string         `json:"type"`
+	Week        int            `json:"week,omitempty"`
+	Matches     []models.Match `json:"matches,omitempty"`
+	Team        *models.Team   `json:"team,omitempty"`
+	LeagueTable []models.Team  `json:"league_table,omitempty"`
+	OccurredAt  time.Time      `json:"occurredAt"`
+}
+
+// Handler receives published events
+type Handler func(event Event)
+
+// Bus is an in-process publish/subscribe hub for league events.
+// Handlers run synchronously in subscription order, so slow subscribers should hand events off.
+type Bus struct {
+	mu       sync.RWMutex
+	nextID   int
+	handlers map[int]Handler
+	order    []int
+}
+
+// NewBus creates a new instance of Bus
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[int]Handler),
+	}
+}
+
+// Subscribe registers a handler and returns a function that removes it
+func (b *Bus) Subscribe(handler Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+	b.order = append(b.order, id)
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		delete(b.handlers, id)
+		for i, existing := range b.order {
+			if existing == id {
+				b.order = append(b.order[:i], b.order[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Publish delivers an event to every subscribed handler
+func (b *Bus) Publish(event Event) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	// Copy the handlers so they can subscribe or unsubscribe while being notified
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.order))
+	for _, id := range b.order {
+		handlers = append(handlers, b.handlers[id])
+	}
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"insider-league/models"
+	"insider-league/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// APIKeyHandler handles HTTP requests for the signed-in user's API keys
+type APIKeyHandler struct {
+	service services.APIKeyService
+}
+
+// NewAPIKeyHandler creates and returns a new APIKeyHandler instance
+func NewAPIKeyHandler(service services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: service,
+	}
+}
+
+// CreateAPIKey handles issuing an API key; the response is the only time the full key is shown
+func (h *APIKeyHandler) CreateAPIKey(c *fiber.Ctx) error {
+	var req models.APIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	key, err := h.service.Create(currentUser(c), req)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(key)
+}
+
+// GetAPIKeys handles retrieving the signed-in user's API keys
+func (h *APIKeyHandler) GetAPIKeys(c *fiber.Ctx) error {
+	keys, err := h.service.GetByUser(int(currentUser(c).ID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(keys)
+}
+
+// RevokeAPIKey handles revoking one of the signed-in user's API keys
+func (h *APIKeyHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid API key ID",
+		})
+	}
+
+	if err := h.service.Revoke(int(currentUser(c).ID), id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "API key not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
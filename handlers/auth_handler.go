@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"insider-league/models"
+	"insider-league/services"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Locals keys where Authenticate stores the signed-in user and, for machine clients, the API key they used
+const (
+	userLocalsKey   = "user"
+	apiKeyLocalsKey = "apiKey"
+)
+
+// apiKeyHeader carries an API key instead of a Bearer token
+const apiKeyHeader = "X-API-Key"
+
+// AuthHandler handles user account HTTP requests and authenticates the rest of the API
+type AuthHandler struct {
+	service services.AuthService
+	keys    services.APIKeyService
+}
+
+// NewAuthHandler creates and returns a new AuthHandler instance
+func NewAuthHandler(service services.AuthService, keys services.APIKeyService) *AuthHandler {
+	return &AuthHandler{
+		service: service,
+		keys:    keys,
+	}
+}
+
+// refreshRequest is the body accepted when refreshing or revoking a refresh token
+type refreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// Register handles creating an account, which is signed in straight away
+func (h *AuthHandler) Register(c *fiber.Ctx) error {
+	var credentials models.Credentials
+	if err := c.BodyParser(&credentials); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	tokens, err := h.service.Register(credentials)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(tokens)
+}
+
+// Login handles signing in with an email address and password
+func (h *AuthHandler) Login(c *fiber.Ctx) error {
+	var credentials models.Credentials
+	if err := c.BodyParser(&credentials); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	tokens, err := h.service.Login(credentials)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(tokens)
+}
+
+// Refresh handles swapping a refresh token for a new pair of tokens
+func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
+	var req refreshRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	tokens, err := h.service.Refresh(req.RefreshToken)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(tokens)
+}
+
+// Logout handles revoking a refresh token
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	var req refreshRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	if err := h.service.Logout(req.RefreshToken); err != nil {
+		return authError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetCurrentUser handles retrieving the signed-in user; it sits behind RequireUser
+func (h *AuthHandler) GetCurrentUser(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(currentUser(c))
+}
+
+// GetAllUsers handles retrieving every account with its role
+func (h *AuthHandler) GetAllUsers(c *fiber.Ctx) error {
+	users, err := h.service.GetUsers()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(users)
+}
+
+// SetUserRole handles changing the role of an account
+func (h *AuthHandler) SetUserRole(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	var update models.RoleUpdate
+	if err := c.BodyParser(&update); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	user, err := h.service.SetRole(currentUser(c), id, update.Role)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// Authenticate is a middleware that signs in the user of a Bearer access token or of an X-API-Key. A missing or
+// invalid credential is rejected on every request that changes data; reads stay open to anonymous callers but
+// still reject a bad one.
+func (h *AuthHandler) Authenticate(c *fiber.Ctx) error {
+	if key := c.Get(apiKeyHeader); key != "" {
+		if c.Get(fiber.HeaderAuthorization) != "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Send either a Bearer token or an X-API-Key, not both",
+			})
+		}
+
+		user, apiKey, err := h.keys.Authenticate(strings.TrimSpace(key))
+		if err != nil {
+			return authError(c, err)
+		}
+
+		c.Locals(userLocalsKey, user)
+		c.Locals(apiKeyLocalsKey, apiKey)
+		return c.Next()
+	}
+
+	header := c.Get(fiber.HeaderAuthorization)
+	if header == "" {
+		if c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead || c.Method() == fiber.MethodOptions {
+			return c.Next()
+		}
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Authentication required",
+		})
+	}
+
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Authorization header must be a Bearer token",
+		})
+	}
+
+	user, err := h.service.Authenticate(strings.TrimSpace(token))
+	if err != nil {
+		return authError(c, err)
+	}
+
+	c.Locals(userLocalsKey, user)
+	return c.Next()
+}
+
+// RequireUser is a middleware that rejects anonymous requests to routes open to every role, such as /auth/me
+func (h *AuthHandler) RequireUser(c *fiber.Ctx) error {
+	if currentUser(c) == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Authentication required",
+		})
+	}
+	return c.Next()
+}
+
+// RequireSession is a middleware that only lets through users signed in with a token, so an API key cannot be
+// used to issue or revoke other keys
+func (h *AuthHandler) RequireSession(c *fiber.Ctx) error {
+	if currentUser(c) == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Authentication required",
+		})
+	}
+	if currentAPIKey(c) != nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "API keys cannot manage API keys; sign in with a Bearer token",
+		})
+	}
+	return c.Next()
+}
+
+// Require is a middleware that lets a request through only when the signed-in user's role has the permission
+func (h *AuthHandler) Require(permission string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return authorize(c, permission)
+	}
+}
+
+// Authorize is a middleware for route groups that checks the permission mapped to the request method; methods
+// without one, such as reads, are let through
+func (h *AuthHandler) Authorize(permissions map[string]string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		permission, ok := permissions[c.Method()]
+		if !ok {
+			return c.Next()
+		}
+		return authorize(c, permission)
+	}
+}
+
+// authorize rejects an anonymous request with 401 and a signed-in user lacking the permission with 403, naming
+// the permission and the roles that have it. Requests made with an API key must also have it in the key's scope.
+func authorize(c *fiber.Ctx, permission string) error {
+	user := currentUser(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Authentication required",
+		})
+	}
+
+	if !services.HasPermission(user.Role, permission) {
+		allowed := services.RolesWithPermission(permission)
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":        fmt.Sprintf("The %s role lacks the %s permission; this requires one of: %s", user.Role, permission, strings.Join(allowed, ", ")),
+			"permission":   permission,
+			"role":         user.Role,
+			"allowedRoles": allowed,
+		})
+	}
+
+	if key := currentAPIKey(c); key != nil && !services.ScopeAllows(key.Scope, permission) {
+		allowed := services.ScopesWithPermission(permission)
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":         fmt.Sprintf("The %s API key scope lacks the %s permission; this requires one of: %s", key.Scope, permission, strings.Join(allowed, ", ")),
+			"permission":    permission,
+			"scope":         key.Scope,
+			"allowedScopes": allowed,
+		})
+	}
+	return c.Next()
+}
+
+// currentUser returns the user signed in on the request, or nil for an anonymous one
+func currentUser(c *fiber.Ctx) *models.User {
+	user, _ := c.Locals(userLocalsKey).(*models.User)
+	return user
+}
+
+// currentAPIKey returns the API key the request was made with, or nil when it was not made with one
+func currentAPIKey(c *fiber.Ctx) *models.APIKey {
+	key, _ := c.Locals(apiKeyLocalsKey).(*models.APIKey)
+	return key
+}
+
+// authError maps account and token errors to HTTP responses
+func authError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidUser), errors.Is(err, services.ErrInvalidRole), errors.Is(err, services.ErrInvalidAPIKeyRequest):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidCredentials), errors.Is(err, services.ErrInvalidToken), errors.Is(err, services.ErrInvalidAPIKey):
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrEmailTaken), errors.Is(err, services.ErrOwnRole):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case err == gorm.ErrRecordNotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"insider-league/models"
+	"insider-league/services"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// BettingHandler handles virtual betting HTTP requests for the signed-in user
+type BettingHandler struct {
+	service services.BettingService
+}
+
+// NewBettingHandler creates and returns a new BettingHandler instance
+func NewBettingHandler(service services.BettingService) *BettingHandler {
+	return &BettingHandler{
+		service: service,
+	}
+}
+
+// GetWallet handles retrieving the signed-in user's wallet
+func (h *BettingHandler) GetWallet(c *fiber.Ctx) error {
+	wallet, err := h.service.GetWallet(int(currentUser(c).ID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(wallet)
+}
+
+// GetTransactions handles retrieving the signed-in user's ledger
+func (h *BettingHandler) GetTransactions(c *fiber.Ctx) error {
+	entries, err := h.service.GetTransactions(int(currentUser(c).ID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(entries)
+}
+
+// GetBets handles retrieving the signed-in user's bets
+func (h *BettingHandler) GetBets(c *fiber.Ctx) error {
+	bets, err := h.service.GetBets(int(currentUser(c).ID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(bets)
+}
+
+// PlaceBet handles placing a bet on an unplayed match
+func (h *BettingHandler) PlaceBet(c *fiber.Ctx) error {
+	var req models.BetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	bet, err := h.service.PlaceBet(currentUser(c), req)
+	if err != nil {
+		return betError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(bet)
+}
+
+// betError maps bet placement errors to HTTP responses
+func betError(c *fiber.Ctx, err error) error {
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Match not found",
+		})
+	case errors.Is(err, services.ErrInvalidBet):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrMatchPlayed), errors.Is(err, services.ErrWeekInProgress), errors.Is(err, services.ErrOddsChanged), errors.Is(err, services.ErrInsufficientFunds):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
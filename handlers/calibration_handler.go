@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"insider-league/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CalibrationHandler handles strength calibration HTTP requests
+type CalibrationHandler struct {
+	service services.CalibrationService
+}
+
+// NewCalibrationHandler creates and returns a new CalibrationHandler instance
+func NewCalibrationHandler(service services.CalibrationService) *CalibrationHandler {
+	return &CalibrationHandler{
+		service: service,
+	}
+}
+
+// Calibrate handles fitting team strengths to the played matches in the database.
+// Pass ?apply=true to store the fitted strengths.
+func (h *CalibrationHandler) Calibrate(c *fiber.Ctx) error {
+	result, err := h.service.CalibrateFromMatches(c.QueryBool("apply"))
+	if err != nil {
+		return calibrationError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// CalibrateUpload handles fitting team strengths to results uploaded as a CSV file.
+// Pass ?apply=true to store the fitted strengths.
+func (h *CalibrationHandler) CalibrateUpload(c *fiber.Ctx) error {
+	// Get the uploaded results file
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "A results CSV must be uploaded in the file field",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to read uploaded file",
+		})
+	}
+	defer file.Close()
+
+	result, err := h.service.CalibrateFromCSV(file, c.QueryBool("apply"))
+	if err != nil {
+		return calibrationError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// calibrationError maps calibration errors to HTTP responses
+func calibrationError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrInsufficientResults) || errors.Is(err, services.ErrInvalidResultsFile) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if errors.Is(err, services.ErrLeagueBusy) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"insider-league/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ChipHandler handles fantasy chip HTTP requests
+type ChipHandler struct {
+	service services.ChipService
+}
+
+// NewChipHandler creates and returns a new ChipHandler instance
+func NewChipHandler(service services.ChipService) *ChipHandler {
+	return &ChipHandler{
+		service: service,
+	}
+}
+
+// chipRequest is the body accepted when playing a chip
+type chipRequest struct {
+	Chip string `json:"chip"`
+
+	// Week is the gameweek the chip is meant for; 0 means the open one
+	Week int `json:"week"`
+}
+
+// GetChips handles retrieving a manager's chip inventory
+func (h *ChipHandler) GetChips(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid manager ID",
+		})
+	}
+
+	inventory, err := h.service.GetInventory(id)
+	if err != nil {
+		return chipError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(inventory)
+}
+
+// ActivateChip handles playing a chip for the open gameweek
+func (h *ChipHandler) ActivateChip(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid manager ID",
+		})
+	}
+
+	var req chipRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	activation, err := h.service.Activate(id, req.Week, req.Chip)
+	if err != nil {
+		return chipError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(activation)
+}
+
+// chipError maps chip errors to HTTP responses, leaving deadline, manager and squad errors to transferError
+func chipError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidChip):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrChipUnavailable), errors.Is(err, services.ErrChipAlreadyActive):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return transferError(c, err)
+}
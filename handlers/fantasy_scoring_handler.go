@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"insider-league/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// FantasyScoringHandler handles fantasy points HTTP requests
+type FantasyScoringHandler struct {
+	service services.FantasyScoringService
+}
+
+// NewFantasyScoringHandler creates and returns a new FantasyScoringHandler instance
+func NewFantasyScoringHandler(service services.FantasyScoringService) *FantasyScoringHandler {
+	return &FantasyScoringHandler{
+		service: service,
+	}
+}
+
+// GetScoringRules handles retrieving the scoring table in use
+func (h *FantasyScoringHandler) GetScoringRules(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(h.service.GetRules())
+}
+
+// GetPlayerGameweeks handles retrieving every player's points for a week
+func (h *FantasyScoringHandler) GetPlayerGameweeks(c *fiber.Ctx) error {
+	// Get and parse the week parameter
+	week, err := strconv.Atoi(c.Params("week"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid week",
+		})
+	}
+
+	players, err := h.service.GetPlayerGameweeks(week)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"week":    week,
+		"players": players,
+	})
+}
+
+// GetManagerGameweeks handles retrieving a manager's points for every scored week
+func (h *FantasyScoringHandler) GetManagerGameweeks(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid manager ID",
+		})
+	}
+
+	gameweeks, err := h.service.GetManagerGameweeks(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Manager not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"gameweeks": gameweeks,
+	})
+}
+
+// GetManagerGameweek handles retrieving a manager's points for one week, pick by pick
+func (h *FantasyScoringHandler) GetManagerGameweek(c *fiber.Ctx) error {
+	// Get and parse the ID and week parameters
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid manager ID",
+		})
+	}
+	week, err := strconv.Atoi(c.Params("week"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid week",
+		})
+	}
+
+	gameweek, err := h.service.GetManagerGameweek(id, week)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Gameweek not scored for this manager",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(gameweek)
+}
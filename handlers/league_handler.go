@@ -1,10 +1,16 @@
 package handlers
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"insider-league/models"
 	"insider-league/services"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
 )
 
 // LeagueHandler handles league-related HTTP requests
@@ -33,16 +39,33 @@ func (h *LeagueHandler) GetLeagueTable(c *fiber.Ctx) error {
 	})
 }
 
-// PlayNextWeek handles simulating the next unplayed week
-func (h *LeagueHandler) PlayNextWeek(c *fiber.Ctx) error {
-	// Play only the next week
-	teams, matches, predictions, err := h.service.PlayWeeks(false)
+// GetProjections retrieves each team's chance of finishing in every position and zone of the table
+func (h *LeagueHandler) GetProjections(c *fiber.Ctx) error {
+	predictions, err := h.service.GetProjections()
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
+	return c.JSON(fiber.Map{
+		"predictions": predictions,
+	})
+}
+
+// PlayNextWeek handles simulating the next unplayed week.
+// Pass ?live=true to stream the week over Server-Sent Events instead.
+func (h *LeagueHandler) PlayNextWeek(c *fiber.Ctx) error {
+	if c.QueryBool("live") {
+		return h.playNextWeekLive(c)
+	}
+
+	// Play only the next week
+	teams, matches, predictions, err := h.service.PlayWeeks(false)
+	if err != nil {
+		return leagueError(c, err)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"league_table": teams,
 		"matches":      matches,
@@ -55,9 +78,7 @@ func (h *LeagueHandler) PlayAll(c *fiber.Ctx) error {
 	// Play all remaining weeks
 	teams, matches, predictions, err := h.service.PlayWeeks(true)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return leagueError(c, err)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -124,9 +145,7 @@ func (h *LeagueHandler) EditMatchResult(c *fiber.Ctx) error {
 	// Update match result
 	match, leagueTable, err := h.service.EditMatchResult(matchID, req.HomeGoals, req.AwayGoals)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return leagueError(c, err)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -138,12 +157,60 @@ func (h *LeagueHandler) EditMatchResult(c *fiber.Ctx) error {
 // ResetLeague resets all match results and team statistics
 func (h *LeagueHandler) ResetLeague(c *fiber.Ctx) error {
 	if err := h.service.ResetLeague(); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return leagueError(c, err)
 	}
 
 	return c.JSON(fiber.Map{
 		"message": "League has been reset successfully",
 	})
 }
+
+// playNextWeekLive streams the next week as Server-Sent Events at an accelerated speed.
+// The speed query parameter is a multiple of real time and defaults to one match minute per second.
+func (h *LeagueHandler) playNextWeekLive(c *fiber.Ctx) error {
+	speed := c.QueryFloat("speed", services.DefaultLiveSpeed)
+	if speed <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Speed must be greater than zero",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		err := h.service.PlayNextWeekLive(speed, func(update models.LiveUpdate) error {
+			return writeServerSentEvent(w, update.Type, update)
+		})
+		if err != nil {
+			_ = writeServerSentEvent(w, "error", fiber.Map{"error": err.Error()})
+		}
+	}))
+
+	return nil
+}
+
+// writeServerSentEvent writes a single named event with a JSON payload and flushes it to the client
+func writeServerSentEvent(w *bufio.Writer, event string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// leagueError maps league operation errors to HTTP responses
+func leagueError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrLeagueBusy) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+	"insider-league/eventbus"
+	"insider-league/services"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+// snapshotEvent is sent to a client right after it connects
+const snapshotEvent = "snapshot"
+
+// socketBufferSize is the number of pending messages kept per client before updates are dropped
+const socketBufferSize = 16
+
+// LeagueSocketHandler pushes league events with table snapshots to WebSocket clients
+type LeagueSocketHandler struct {
+	service services.LeagueService
+
+	mu      sync.Mutex
+	clients map[chan []byte]struct{}
+}
+
+// NewLeagueSocketHandler creates and returns a new LeagueSocketHandler subscribed to the event bus
+func NewLeagueSocketHandler(service services.LeagueService, bus *eventbus.Bus) *LeagueSocketHandler {
+	h := &LeagueSocketHandler{
+		service: service,
+		clients: make(map[chan []byte]struct{}),
+	}
+	bus.Subscribe(h.broadcast)
+	return h
+}
+
+// Upgrade rejects requests that are not WebSocket upgrades
+func (h *LeagueSocketHandler) Upgrade(c *fiber.Ctx) error {
+	if websocket.IsWebSocketUpgrade(c) {
+		return c.Next()
+	}
+	return fiber.ErrUpgradeRequired
+}
+
+// Stream sends the current table and then every league event to a connected client
+func (h *LeagueSocketHandler) Stream(conn *websocket.Conn) {
+	send := make(chan []byte, socketBufferSize)
+	h.register(send)
+	defer h.unregister(send)
+
+	// Start the client off with the current standings
+	leagueTable, err := h.service.GetLeagueTable()
+	if err == nil {
+		if message, err := json.Marshal(eventbus.Event{Type: snapshotEvent, LeagueTable: leagueTable, OccurredAt: time.Now()}); err == nil {
+			if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		}
+	}
+
+	// Clients only listen, so reading is just used to notice when they leave
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case message := <-send:
+			if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// broadcast delivers an event to every connected client, attaching the table when it is missing
+func (h *LeagueSocketHandler) broadcast(event eventbus.Event) {
+	h.mu.Lock()
+	listeners := len(h.clients)
+	h.mu.Unlock()
+	if listeners == 0 {
+		return
+	}
+
+	if event.LeagueTable == nil {
+		leagueTable, err := h.service.GetLeagueTable()
+		if err != nil {
+			log.Printf("Failed to load league table for %s event: %v", event.Type, err)
+			return
+		}
+		event.LeagueTable = leagueTable
+	}
+
+	message, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to encode %s event: %v", event.Type, err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for client := range h.clients {
+		// Slow clients miss updates instead of blocking the league
+		select {
+		case client <- message:
+		default:
+		}
+	}
+}
+
+// register adds a client's send channel
+func (h *LeagueSocketHandler) register(client chan []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.clients[client] = struct{}{}
+}
+
+// unregister removes a client's send channel
+func (h *LeagueSocketHandler) unregister(client chan []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.clients, client)
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"insider-league/models"
+	"insider-league/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LineupHandler handles fantasy lineup HTTP requests
+type LineupHandler struct {
+	service services.LineupService
+}
+
+// NewLineupHandler creates and returns a new LineupHandler instance
+func NewLineupHandler(service services.LineupService) *LineupHandler {
+	return &LineupHandler{
+		service: service,
+	}
+}
+
+// GetLineup handles retrieving the lineup a manager's squad lines up with in a week
+func (h *LineupHandler) GetLineup(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid manager ID",
+		})
+	}
+
+	// An omitted week means the open gameweek
+	week := c.QueryInt("week", 0)
+	if week < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid week",
+		})
+	}
+
+	lineup, err := h.service.GetLineup(id, week)
+	if err != nil {
+		return lineupError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(lineup)
+}
+
+// SetLineup handles picking a manager's starters, bench order, captain and vice-captain for the open gameweek
+func (h *LineupHandler) SetLineup(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid manager ID",
+		})
+	}
+
+	lineup := new(models.Lineup)
+	if err := c.BodyParser(lineup); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	lineup, err = h.service.SetLineup(id, lineup)
+	if err != nil {
+		return lineupError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(lineup)
+}
+
+// lineupError maps lineup errors to HTTP responses, leaving deadline, manager and squad errors to transferError
+func lineupError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrInvalidLineup) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return transferError(c, err)
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"errors"
+	"insider-league/models"
+	"insider-league/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// ManagerHandler handles fantasy manager and squad HTTP requests
+type ManagerHandler struct {
+	service services.ManagerService
+}
+
+// NewManagerHandler creates and returns a new ManagerHandler instance
+func NewManagerHandler(service services.ManagerService) *ManagerHandler {
+	return &ManagerHandler{
+		service: service,
+	}
+}
+
+// squadRequest is the body accepted when picking or validating a squad
+type squadRequest struct {
+	PlayerIDs []uint `json:"playerIds"`
+}
+
+// CreateManager handles registering a new fantasy manager
+func (h *ManagerHandler) CreateManager(c *fiber.Ctx) error {
+	manager := new(models.Manager)
+
+	// Parse the request body into the manager struct
+	if err := c.BodyParser(manager); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	// Tie the manager to the signed-in account rather than whatever the body claims
+	manager.UserID = nil
+	if user := currentUser(c); user != nil {
+		manager.UserID = &user.ID
+	}
+
+	// Create the manager using the service
+	if err := h.service.Create(manager); err != nil {
+		return managerError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(manager)
+}
+
+// GetAllManagers handles retrieving all managers
+func (h *ManagerHandler) GetAllManagers(c *fiber.Ctx) error {
+	managers, err := h.service.GetAll()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(managers)
+}
+
+// GetManagerByID handles retrieving a manager by its ID
+func (h *ManagerHandler) GetManagerByID(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid manager ID",
+		})
+	}
+
+	manager, err := h.service.GetByID(id)
+	if err != nil {
+		return managerError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(manager)
+}
+
+// DeleteManager handles deleting a manager
+func (h *ManagerHandler) DeleteManager(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid manager ID",
+		})
+	}
+
+	if err := h.service.Delete(id); err != nil {
+		return managerError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// RequireOwner is a middleware that only lets the account that registered the manager in the :id parameter, or an
+// admin, change it
+func (h *ManagerHandler) RequireOwner(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid manager ID",
+		})
+	}
+
+	if err := h.service.Authorize(currentUser(c), id); err != nil {
+		return managerError(c, err)
+	}
+	return c.Next()
+}
+
+// GetSquad handles retrieving a manager's squad
+func (h *ManagerHandler) GetSquad(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid manager ID",
+		})
+	}
+
+	squad, err := h.service.GetSquad(id)
+	if err != nil {
+		return managerError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(squad)
+}
+
+// CreateSquad handles picking a manager's initial squad
+func (h *ManagerHandler) CreateSquad(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid manager ID",
+		})
+	}
+
+	var req squadRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	squad, err := h.service.CreateSquad(id, req.PlayerIDs)
+	if err != nil {
+		return managerError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(squad)
+}
+
+// ValidateSquad handles checking a manager's saved squad against the rules and current prices
+func (h *ManagerHandler) ValidateSquad(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid manager ID",
+		})
+	}
+
+	validation, err := h.service.ValidateSquad(id)
+	if err != nil {
+		return managerError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(validation)
+}
+
+// ValidatePlayers handles checking a prospective squad without saving it
+func (h *ManagerHandler) ValidatePlayers(c *fiber.Ctx) error {
+	var req squadRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	validation, err := h.service.ValidatePlayers(req.PlayerIDs)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(validation)
+}
+
+// managerError maps manager and squad errors to HTTP responses
+func managerError(c *fiber.Ctx, err error) error {
+	var validationErr *services.SquadValidationError
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Manager or squad not found",
+		})
+	case errors.As(err, &validationErr):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":      "Squad breaks the fantasy rules",
+			"validation": validationErr.Validation,
+		})
+	case errors.Is(err, services.ErrInvalidManager):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrNotManagerOwner):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrSquadExists):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
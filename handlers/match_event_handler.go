@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"insider-league/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// MatchEventHandler handles match timeline HTTP requests
+type MatchEventHandler struct {
+	service services.MatchEventService
+}
+
+// NewMatchEventHandler creates and returns a new MatchEventHandler instance
+func NewMatchEventHandler(service services.MatchEventService) *MatchEventHandler {
+	return &MatchEventHandler{
+		service: service,
+	}
+}
+
+// GetMatchEvents handles retrieving the timeline of a match
+func (h *MatchEventHandler) GetMatchEvents(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid match ID",
+		})
+	}
+
+	// Get the events using the service
+	events, err := h.service.GetByMatch(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Match not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"events": events,
+	})
+}
+
+// GetTopScorers handles retrieving the season's top scorers, limited by ?limit=
+func (h *MatchEventHandler) GetTopScorers(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", services.DefaultLeaderboardSize)
+	if limit <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Limit must be a positive number",
+		})
+	}
+
+	leaders, err := h.service.GetTopScorers(limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"top_scorers": leaders,
+	})
+}
+
+// GetTopAssisters handles retrieving the season's top assisters, limited by ?limit=
+func (h *MatchEventHandler) GetTopAssisters(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", services.DefaultLeaderboardSize)
+	if limit <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Limit must be a positive number",
+		})
+	}
+
+	leaders, err := h.service.GetTopAssisters(limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"top_assists": leaders,
+	})
+}
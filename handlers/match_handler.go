@@ -82,6 +82,44 @@ func (h *MatchHandler) GetMatchByID(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(match)
 }
 
+// GetHeadToHead handles retrieving the head-to-head record between two teams
+func (h *MatchHandler) GetHeadToHead(c *fiber.Ctx) error {
+	// Get and parse both team ID parameters
+	teamID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid team ID",
+		})
+	}
+	otherTeamID, err := strconv.Atoi(c.Params("otherId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid opponent team ID",
+		})
+	}
+
+	if teamID == otherTeamID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "A team cannot be compared against itself",
+		})
+	}
+
+	// Get the head-to-head record using the service
+	record, err := h.service.GetHeadToHead(teamID, otherTeamID)
+	if err == gorm.ErrRecordNotFound {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Team not found",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(record)
+}
+
 // UpdateMatch handles updating an existing match
 func (h *MatchHandler) UpdateMatch(c *fiber.Ctx) error {
 	// Get and parse the ID parameter
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"insider-league/models"
+	"insider-league/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// MiniLeagueHandler handles fantasy mini-league HTTP requests
+type MiniLeagueHandler struct {
+	service services.MiniLeagueService
+}
+
+// NewMiniLeagueHandler creates and returns a new MiniLeagueHandler instance
+func NewMiniLeagueHandler(service services.MiniLeagueService) *MiniLeagueHandler {
+	return &MiniLeagueHandler{
+		service: service,
+	}
+}
+
+// joinRequest is the body accepted when joining a mini-league
+type joinRequest struct {
+	Code      string `json:"code"`
+	ManagerID int    `json:"managerId"`
+}
+
+// CreateMiniLeague handles setting up a new mini-league
+func (h *MiniLeagueHandler) CreateMiniLeague(c *fiber.Ctx) error {
+	league := new(models.MiniLeague)
+
+	// Parse the request body into the mini-league struct
+	if err := c.BodyParser(league); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	if err := h.service.Create(currentUser(c), league); err != nil {
+		return miniLeagueError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(league)
+}
+
+// JoinMiniLeague handles a manager joining a mini-league with its code
+func (h *MiniLeagueHandler) JoinMiniLeague(c *fiber.Ctx) error {
+	var req joinRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	league, err := h.service.Join(currentUser(c), req.Code, req.ManagerID)
+	if err != nil {
+		return miniLeagueError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(league)
+}
+
+// GetMiniLeagueByID handles retrieving a mini-league with its members
+func (h *MiniLeagueHandler) GetMiniLeagueByID(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid mini-league ID",
+		})
+	}
+
+	league, err := h.service.GetByID(id)
+	if err != nil {
+		return miniLeagueError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(league)
+}
+
+// GetStandings handles retrieving a mini-league's table
+func (h *MiniLeagueHandler) GetStandings(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid mini-league ID",
+		})
+	}
+
+	standings, err := h.service.GetStandings(id)
+	if err != nil {
+		return miniLeagueError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(standings)
+}
+
+// GetFixtures handles retrieving a head-to-head mini-league's fixtures
+func (h *MiniLeagueHandler) GetFixtures(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid mini-league ID",
+		})
+	}
+
+	fixtures, err := h.service.GetFixtures(id)
+	if err != nil {
+		return miniLeagueError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fixtures)
+}
+
+// GetManagerMiniLeagues handles retrieving the mini-leagues a manager belongs to
+func (h *MiniLeagueHandler) GetManagerMiniLeagues(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid manager ID",
+		})
+	}
+
+	leagues, err := h.service.GetByManager(id)
+	if err != nil {
+		return miniLeagueError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(leagues)
+}
+
+// miniLeagueError maps mini-league errors to HTTP responses, leaving deadline errors to transferError
+func miniLeagueError(c *fiber.Ctx, err error) error {
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Mini-league or manager not found",
+		})
+	case errors.Is(err, services.ErrInvalidMiniLeague):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrNotManagerOwner):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrAlreadyMember), errors.Is(err, services.ErrMiniLeagueClosed):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return transferError(c, err)
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"insider-league/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// OddsHandler handles match odds HTTP requests
+type OddsHandler struct {
+	service services.OddsService
+}
+
+// NewOddsHandler creates and returns a new OddsHandler instance
+func NewOddsHandler(service services.OddsService) *OddsHandler {
+	return &OddsHandler{
+		service: service,
+	}
+}
+
+// GetMatchOdds handles retrieving the pre-match odds of an unplayed match, optionally with a margin override
+func (h *OddsHandler) GetMatchOdds(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid match ID",
+		})
+	}
+
+	var margin *float64
+	if raw := c.Query("margin"); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid margin",
+			})
+		}
+		margin = &value
+	}
+
+	odds, err := h.service.GetMatchOdds(id, margin)
+	if err != nil {
+		switch {
+		case err == gorm.ErrRecordNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Match not found",
+			})
+		case errors.Is(err, services.ErrInvalidMargin):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case errors.Is(err, services.ErrMatchPlayed), errors.Is(err, services.ErrWeekInProgress):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(odds)
+}
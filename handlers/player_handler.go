@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+	"insider-league/models"
+	"insider-league/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// PlayerHandler handles player-related HTTP requests
+type PlayerHandler struct {
+	service services.PlayerService
+}
+
+// NewPlayerHandler creates and returns a new PlayerHandler instance
+func NewPlayerHandler(service services.PlayerService) *PlayerHandler {
+	return &PlayerHandler{
+		service: service,
+	}
+}
+
+// CreatePlayer handles adding a player to a squad
+func (h *PlayerHandler) CreatePlayer(c *fiber.Ctx) error {
+	player := new(models.Player)
+
+	// Parse the request body into the player struct
+	if err := c.BodyParser(player); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	// Create the player using the service
+	if err := h.service.Create(player); err != nil {
+		return playerError(c, err)
+	}
+
+	// Return the created player with a 201 status code
+	return c.Status(fiber.StatusCreated).JSON(player)
+}
+
+// GetAllPlayers handles retrieving all players
+func (h *PlayerHandler) GetAllPlayers(c *fiber.Ctx) error {
+	players, err := h.service.GetAll()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(players)
+}
+
+// GetPlayerByID handles retrieving a player by its ID
+func (h *PlayerHandler) GetPlayerByID(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid player ID",
+		})
+	}
+
+	// Get the player using the service
+	player, err := h.service.GetByID(id)
+	if err != nil {
+		return playerError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(player)
+}
+
+// GetTeamPlayers handles retrieving the squad of a team
+func (h *PlayerHandler) GetTeamPlayers(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid team ID",
+		})
+	}
+
+	// Get the squad using the service
+	players, err := h.service.GetByTeam(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Team not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"players": players,
+	})
+}
+
+// UpdatePlayer handles updating an existing player
+func (h *PlayerHandler) UpdatePlayer(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid player ID",
+		})
+	}
+
+	// Create a new player instance and parse the request body
+	player := new(models.Player)
+	if err := c.BodyParser(player); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	// Set the ID from the URL parameter
+	player.ID = uint(id)
+
+	// Update the player using the service
+	if err := h.service.Update(player); err != nil {
+		return playerError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(player)
+}
+
+// DeletePlayer handles deleting a player
+func (h *PlayerHandler) DeletePlayer(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid player ID",
+		})
+	}
+
+	// Delete the player using the service
+	if err := h.service.Delete(id); err != nil {
+		return playerError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// playerError maps player errors to HTTP responses
+func playerError(c *fiber.Ctx, err error) error {
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Player not found",
+		})
+	case errors.Is(err, services.ErrInvalidPlayer), errors.Is(err, services.ErrPlayerTeamNotFound):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrShirtNumberTaken):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
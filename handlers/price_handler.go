@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"insider-league/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PriceHandler handles fantasy player price HTTP requests
+type PriceHandler struct {
+	service services.PriceService
+}
+
+// NewPriceHandler creates and returns a new PriceHandler instance
+func NewPriceHandler(service services.PriceService) *PriceHandler {
+	return &PriceHandler{
+		service: service,
+	}
+}
+
+// GetPlayerPrices handles retrieving a player's price history
+func (h *PriceHandler) GetPlayerPrices(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid player ID",
+		})
+	}
+
+	history, err := h.service.GetHistory(id)
+	if err != nil {
+		return playerError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(history)
+}
+
+// GetPriceChanges handles retrieving the price changes made after a gameweek
+func (h *PriceHandler) GetPriceChanges(c *fiber.Ctx) error {
+	// Get and parse the week parameter
+	week, err := strconv.Atoi(c.Params("week"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid week",
+		})
+	}
+
+	changes, err := h.service.GetChanges(week)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"week":    week,
+		"changes": changes,
+	})
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"insider-league/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// RatingHandler handles Elo rating-related HTTP requests
+type RatingHandler struct {
+	service services.RatingService
+}
+
+// NewRatingHandler creates and returns a new RatingHandler instance
+func NewRatingHandler(service services.RatingService) *RatingHandler {
+	return &RatingHandler{
+		service: service,
+	}
+}
+
+// GetTeamRatings handles retrieving the rating history of a team
+func (h *RatingHandler) GetTeamRatings(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid team ID",
+		})
+	}
+
+	// Get the rating history using the service
+	ratings, err := h.service.GetHistory(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Team not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"ratings": ratings,
+	})
+}
+
+// GetPowerRankings handles retrieving all teams ordered by Elo rating
+func (h *RatingHandler) GetPowerRankings(c *fiber.Ctx) error {
+	rankings, err := h.service.GetPowerRankings()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"rankings": rankings,
+	})
+}
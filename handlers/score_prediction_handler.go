@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+	"insider-league/models"
+	"insider-league/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// ScorePredictionHandler handles score prediction game HTTP requests
+type ScorePredictionHandler struct {
+	service services.ScorePredictionService
+}
+
+// NewScorePredictionHandler creates and returns a new ScorePredictionHandler instance
+func NewScorePredictionHandler(service services.ScorePredictionService) *ScorePredictionHandler {
+	return &ScorePredictionHandler{
+		service: service,
+	}
+}
+
+// Predict handles predicting the score of a match, or changing a prediction before its week is played
+func (h *ScorePredictionHandler) Predict(c *fiber.Ctx) error {
+	var req models.ScorePredictionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	prediction, err := h.service.Predict(currentUser(c), req)
+	if err != nil {
+		return predictionError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(prediction)
+}
+
+// GetMyPredictions handles retrieving the signed-in user's predictions
+func (h *ScorePredictionHandler) GetMyPredictions(c *fiber.Ctx) error {
+	predictions, err := h.service.GetByUser(int(currentUser(c).ID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(predictions)
+}
+
+// GetWeekPredictions handles retrieving everyone's predictions for the played matches of a week
+func (h *ScorePredictionHandler) GetWeekPredictions(c *fiber.Ctx) error {
+	// Get and parse the week parameter
+	week, err := strconv.Atoi(c.Params("week"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid week",
+		})
+	}
+
+	predictions, err := h.service.GetByWeek(week)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"week":        week,
+		"predictions": predictions,
+	})
+}
+
+// GetLeaderboard handles retrieving the season prediction leaderboard
+func (h *ScorePredictionHandler) GetLeaderboard(c *fiber.Ctx) error {
+	leaderboard, err := h.service.GetLeaderboard()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(leaderboard)
+}
+
+// predictionError maps score prediction errors to HTTP responses
+func predictionError(c *fiber.Ctx, err error) error {
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Match not found",
+		})
+	case errors.Is(err, services.ErrInvalidPrediction):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrPredictionLocked), errors.Is(err, services.ErrWeekInProgress):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
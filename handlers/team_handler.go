@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"insider-league/models"
 	"insider-league/services"
 	"strconv"
@@ -34,6 +35,11 @@ func (h *TeamHandler) CreateTeam(c *fiber.Ctx) error {
 
 	// Create the team using the service
 	if err := h.service.Create(team); err != nil {
+		if errors.Is(err, services.ErrInvalidRatings) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -93,8 +99,18 @@ func (h *TeamHandler) UpdateTeam(c *fiber.Ctx) error {
 		})
 	}
 
-	// Create a new team instance and parse the request body
-	team := new(models.Team)
+	// Load the stored team and apply the request body over it, so fields left out keep their values
+	team, err := h.service.GetByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Team not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 	if err := c.BodyParser(team); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Failed to parse request body",
@@ -106,6 +122,16 @@ func (h *TeamHandler) UpdateTeam(c *fiber.Ctx) error {
 
 	// Update the team using the service
 	if err := h.service.Update(team); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Team not found",
+			})
+		}
+		if errors.Is(err, services.ErrInvalidRatings) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"insider-league/models"
+	"insider-league/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TransferHandler handles fantasy transfer HTTP requests
+type TransferHandler struct {
+	service services.TransferService
+}
+
+// NewTransferHandler creates and returns a new TransferHandler instance
+func NewTransferHandler(service services.TransferService) *TransferHandler {
+	return &TransferHandler{
+		service: service,
+	}
+}
+
+// transfersRequest is the body accepted when making transfers
+type transfersRequest struct {
+	// Week is the gameweek the transfers are meant for; 0 means the open one
+	Week      int                      `json:"week"`
+	Transfers []models.TransferRequest `json:"transfers"`
+}
+
+// GetDeadline handles retrieving the gameweek transfers currently count towards
+func (h *TransferHandler) GetDeadline(c *fiber.Ctx) error {
+	deadline, err := h.service.GetDeadline()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(deadline)
+}
+
+// MakeTransfers handles swapping players in a manager's squad ahead of the next gameweek
+func (h *TransferHandler) MakeTransfers(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid manager ID",
+		})
+	}
+
+	var req transfersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	result, err := h.service.MakeTransfers(id, req.Week, req.Transfers)
+	if err != nil {
+		return transferError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(result)
+}
+
+// GetTransfers handles retrieving a manager's transfer history
+func (h *TransferHandler) GetTransfers(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid manager ID",
+		})
+	}
+
+	transfers, err := h.service.GetHistory(id)
+	if err != nil {
+		return transferError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(transfers)
+}
+
+// transferError maps transfer errors to HTTP responses, leaving manager and squad errors to managerError
+func transferError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidTransfer), errors.Is(err, services.ErrGameweekNotOpen):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrDeadlinePassed), errors.Is(err, services.ErrSeasonOver), errors.Is(err, services.ErrWeekInProgress):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return managerError(c, err)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"insider-league/models"
+	"insider-league/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// WebhookHandler handles webhook subscription HTTP requests
+type WebhookHandler struct {
+	service services.WebhookService
+}
+
+// NewWebhookHandler creates and returns a new WebhookHandler instance
+func NewWebhookHandler(service services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+	}
+}
+
+// createWebhookRequest is the body accepted when subscribing; the secret is optional
+type createWebhookRequest struct {
+	TargetURL string   `json:"targetUrl"`
+	Events    []string `json:"events"`
+	Secret    string   `json:"secret"`
+}
+
+// CreateWebhook handles registering a new subscription.
+// The signing secret is only returned in this response.
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	var req createWebhookRequest
+
+	// Parse the request body
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	subscription := &models.WebhookSubscription{
+		TargetURL: req.TargetURL,
+		Events:    req.Events,
+		Secret:    req.Secret,
+	}
+
+	// Create the subscription using the service
+	if err := h.service.Create(subscription); err != nil {
+		if errors.Is(err, services.ErrInvalidWebhook) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"subscription": subscription,
+		"secret":       subscription.Secret,
+	})
+}
+
+// GetAllWebhooks handles retrieving all subscriptions
+func (h *WebhookHandler) GetAllWebhooks(c *fiber.Ctx) error {
+	subscriptions, err := h.service.GetAll()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(subscriptions)
+}
+
+// GetWebhookByID handles retrieving a subscription by its ID
+func (h *WebhookHandler) GetWebhookByID(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook ID",
+		})
+	}
+
+	// Get the subscription using the service
+	subscription, err := h.service.GetByID(id)
+	if err != nil {
+		return webhookError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(subscription)
+}
+
+// DeleteWebhook handles removing a subscription
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook ID",
+		})
+	}
+
+	// Delete the subscription using the service
+	if err := h.service.Delete(id); err != nil {
+		return webhookError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetWebhookDeliveries handles retrieving the delivery log of a subscription
+func (h *WebhookHandler) GetWebhookDeliveries(c *fiber.Ctx) error {
+	// Get and parse the ID parameter
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook ID",
+		})
+	}
+
+	// Get the deliveries using the service
+	deliveries, err := h.service.GetDeliveries(id)
+	if err != nil {
+		return webhookError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"deliveries": deliveries,
+	})
+}
+
+// webhookError maps webhook lookup errors to HTTP responses
+func webhookError(c *fiber.Ctx, err error) error {
+	if err == gorm.ErrRecordNotFound {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Webhook not found",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,154 @@
+package helpers
+
+import (
+	"insider-league/models"
+	"math"
+)
+
+// PoissonFit holds maximum-likelihood parameters of the attack/defence Poisson model.
+// Expected home goals are exp(BaseRate + HomeAdvantage + Attack[home] - Defence[away])
+// and expected away goals are exp(BaseRate + Attack[away] - Defence[home]).
+type PoissonFit struct {
+	BaseRate      float64
+	HomeAdvantage float64
+	Attack        map[uint]float64
+	Defence       map[uint]float64
+	Iterations    int
+	Converged     bool
+}
+
+// ExpectedGoals returns the fitted expected goals for both sides of a fixture
+func (f *PoissonFit) ExpectedGoals(homeTeamID, awayTeamID uint) (home, away float64) {
+	home = math.Exp(f.BaseRate + f.HomeAdvantage + f.Attack[homeTeamID] - f.Defence[awayTeamID])
+	away = math.Exp(f.BaseRate + f.Attack[awayTeamID] - f.Defence[homeTeamID])
+	return home, away
+}
+
+// FitPoissonRatings fits attack and defence ratings to results by maximum likelihood.
+// Each parameter is updated in turn with its closed-form optimum given the others,
+// which increases the likelihood every step and converges for connected fixture lists.
+func FitPoissonRatings(results []models.MatchResult, maxIterations int, tolerance float64) *PoissonFit {
+	fit := &PoissonFit{
+		Attack:  make(map[uint]float64),
+		Defence: make(map[uint]float64),
+	}
+
+	// Observed goal totals per team and overall
+	scored := make(map[uint]float64)
+	conceded := make(map[uint]float64)
+	totalGoals, homeGoals := 0.0, 0.0
+	for _, result := range results {
+		fit.Attack[result.HomeTeamID] = 0
+		fit.Attack[result.AwayTeamID] = 0
+		scored[result.HomeTeamID] += float64(result.HomeGoals)
+		scored[result.AwayTeamID] += float64(result.AwayGoals)
+		conceded[result.HomeTeamID] += float64(result.AwayGoals)
+		conceded[result.AwayTeamID] += float64(result.HomeGoals)
+		totalGoals += float64(result.HomeGoals + result.AwayGoals)
+		homeGoals += float64(result.HomeGoals)
+	}
+	for id := range fit.Attack {
+		fit.Defence[id] = 0
+	}
+
+	if len(results) == 0 {
+		return fit
+	}
+
+	// A small pseudo-count keeps teams that never scored or conceded finite
+	const smoothing = 0.5
+
+	fit.BaseRate = math.Log((totalGoals + smoothing) / float64(2*len(results)))
+
+	for fit.Iterations = 1; fit.Iterations <= maxIterations; fit.Iterations++ {
+		previous := fit.snapshot()
+
+		// Attack: goals scored divided by the exposure implied by the other parameters
+		exposure := make(map[uint]float64)
+		for _, result := range results {
+			exposure[result.HomeTeamID] += math.Exp(fit.BaseRate + fit.HomeAdvantage - fit.Defence[result.AwayTeamID])
+			exposure[result.AwayTeamID] += math.Exp(fit.BaseRate - fit.Defence[result.HomeTeamID])
+		}
+		for id := range fit.Attack {
+			fit.Attack[id] = math.Log((scored[id] + smoothing) / (exposure[id] + smoothing))
+		}
+
+		// Defence: goals conceded against the opponents' attacking exposure
+		exposure = make(map[uint]float64)
+		for _, result := range results {
+			exposure[result.AwayTeamID] += math.Exp(fit.BaseRate + fit.HomeAdvantage + fit.Attack[result.HomeTeamID])
+			exposure[result.HomeTeamID] += math.Exp(fit.BaseRate + fit.Attack[result.AwayTeamID])
+		}
+		for id := range fit.Defence {
+			fit.Defence[id] = -math.Log((conceded[id] + smoothing) / (exposure[id] + smoothing))
+		}
+
+		// Home advantage: home goals against the expected home goals without the advantage
+		homeExposure := 0.0
+		for _, result := range results {
+			homeExposure += math.Exp(fit.BaseRate + fit.Attack[result.HomeTeamID] - fit.Defence[result.AwayTeamID])
+		}
+		fit.HomeAdvantage = math.Log((homeGoals + smoothing) / (homeExposure + smoothing))
+
+		// Centre attack and defence on zero and fold the offsets into the base rate
+		attackMean, defenceMean := mean(fit.Attack), mean(fit.Defence)
+		for id := range fit.Attack {
+			fit.Attack[id] -= attackMean
+			fit.Defence[id] -= defenceMean
+		}
+		fit.BaseRate += attackMean - defenceMean
+
+		if fit.maxChange(previous) < tolerance {
+			fit.Converged = true
+			break
+		}
+	}
+	if fit.Iterations > maxIterations {
+		fit.Iterations = maxIterations
+	}
+
+	return fit
+}
+
+// snapshot copies the current parameters so convergence can be measured after centring
+func (f *PoissonFit) snapshot() *PoissonFit {
+	copied := &PoissonFit{
+		BaseRate:      f.BaseRate,
+		HomeAdvantage: f.HomeAdvantage,
+		Attack:        make(map[uint]float64, len(f.Attack)),
+		Defence:       make(map[uint]float64, len(f.Defence)),
+	}
+	for id := range f.Attack {
+		copied.Attack[id] = f.Attack[id]
+		copied.Defence[id] = f.Defence[id]
+	}
+	return copied
+}
+
+// maxChange returns the largest absolute parameter difference from a previous snapshot
+func (f *PoissonFit) maxChange(previous *PoissonFit) float64 {
+	change := math.Max(math.Abs(f.BaseRate-previous.BaseRate), math.Abs(f.HomeAdvantage-previous.HomeAdvantage))
+	for id := range f.Attack {
+		change = math.Max(change, math.Abs(f.Attack[id]-previous.Attack[id]))
+		change = math.Max(change, math.Abs(f.Defence[id]-previous.Defence[id]))
+	}
+	return change
+}
+
+// PoissonLogLikelihood returns the log probability of observing k goals when lambda are expected
+func PoissonLogLikelihood(k int, lambda float64) float64 {
+	logFactorial, _ := math.Lgamma(float64(k) + 1)
+	return float64(k)*math.Log(lambda) - lambda - logFactorial
+}
+
+// mean returns the average of the map values
+func mean(values map[uint]float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	return total / float64(len(values))
+}
@@ -0,0 +1,35 @@
+package helpers
+
+import "math"
+
+// ExpectedScore returns the expected result (0 to 1) for a team against an opponent based on Elo ratings
+func ExpectedScore(rating, opponentRating float64) float64 {
+	return 1.0 / (1.0 + math.Pow(10, (opponentRating-rating)/400.0))
+}
+
+// EloChange calculates the rating change for the home team after a match.
+// The away team's change is the negation of the returned value.
+func EloChange(homeRating, awayRating float64, homeGoals, awayGoals int, kFactor, homeAdvantage float64) float64 {
+	// Actual result from the home team's perspective
+	actual := 0.5
+	if homeGoals > awayGoals {
+		actual = 1.0
+	} else if homeGoals < awayGoals {
+		actual = 0.0
+	}
+
+	// Home advantage is applied to the home rating for the expectation only
+	expected := ExpectedScore(homeRating+homeAdvantage, awayRating)
+
+	// Scale the change by the margin of victory so that heavy wins move ratings further
+	margin := math.Abs(float64(homeGoals - awayGoals))
+	multiplier := 1.0
+	switch {
+	case margin == 2:
+		multiplier = 1.5
+	case margin >= 3:
+		multiplier = (11.0 + margin) / 8.0
+	}
+
+	return kFactor * multiplier * (actual - expected)
+}
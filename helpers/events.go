@@ -0,0 +1,146 @@
+package helpers
+
+import (
+	"insider-league/models"
+	"math/rand"
+	"sort"
+)
+
+const (
+	// shotConversionRate is the share of shots that are expected to become goals
+	shotConversionRate = 0.11
+
+	// averageYellowCards is the mean number of yellow cards shown to a side per match
+	averageYellowCards = 1.8
+
+	// redCardChance is the probability of a side receiving a red card in a match
+	redCardChance = 0.05
+)
+
+// eventOrder breaks ties between events in the same minute
+var eventOrder = map[string]int{
+	models.EventShot:         0,
+	models.EventGoal:         1,
+	models.EventYellowCard:   2,
+	models.EventRedCard:      3,
+	models.EventSubstitution: 4,
+	models.EventHalfTime:     5,
+	models.EventFullTime:     6,
+}
+
+// SimulateMatchEvents builds a minute-by-minute timeline for a match that ends with the given score.
+// Shots are drawn around the expected goals, so the timeline stays consistent with edited results too.
+func SimulateMatchEvents(match *models.Match) []models.MatchEvent {
+	events := append(goalEvents(match), supportingEvents(match)...)
+	OrderTimeline(match, events)
+	return events
+}
+
+// SimulateGoalEvents builds a timeline holding only the goals of a match, at random minutes
+func SimulateGoalEvents(match *models.Match) []models.MatchEvent {
+	events := goalEvents(match)
+	OrderTimeline(match, events)
+	return events
+}
+
+// SimulateSupportingEvents builds everything in a timeline except the goals:
+// missed shots, cards, substitutions, half-time and full-time
+func SimulateSupportingEvents(match *models.Match) []models.MatchEvent {
+	events := supportingEvents(match)
+	OrderTimeline(match, events)
+	return events
+}
+
+// goalEvents creates one goal event per goal of each side at random minutes
+func goalEvents(match *models.Match) []models.MatchEvent {
+	var events []models.MatchEvent
+	for range match.HomeTeamScore {
+		events = append(events, newEvent(match.ID, match.HomeTeamID, models.EventGoal, randomMinute(1, 90)))
+	}
+	for range match.AwayTeamScore {
+		events = append(events, newEvent(match.ID, match.AwayTeamID, models.EventGoal, randomMinute(1, 90)))
+	}
+	return events
+}
+
+// supportingEvents creates the missed shots, cards and substitutions of both sides plus half-time and full-time
+func supportingEvents(match *models.Match) []models.MatchEvent {
+	var events []models.MatchEvent
+
+	sides := []struct {
+		teamID   uint
+		expected float64
+	}{
+		{match.HomeTeamID, match.HomeExpectedGoals},
+		{match.AwayTeamID, match.AwayExpectedGoals},
+	}
+
+	for _, side := range sides {
+		// Matches without recorded expected goals fall back to an average attacking display
+		expected := side.expected
+		if expected <= 0 {
+			expected = LeagueAverageGoals
+		}
+
+		// Shots that did not go in, on top of the goals themselves
+		missedShots := SamplePoisson(expected/shotConversionRate - expected)
+		for range missedShots {
+			events = append(events, newEvent(match.ID, side.teamID, models.EventShot, randomMinute(1, 90)))
+		}
+
+		for range SamplePoisson(averageYellowCards) {
+			events = append(events, newEvent(match.ID, side.teamID, models.EventYellowCard, randomMinute(1, 90)))
+		}
+		if rand.Float64() < redCardChance {
+			events = append(events, newEvent(match.ID, side.teamID, models.EventRedCard, randomMinute(20, 90)))
+		}
+
+		// Three to five substitutions, all in the second half
+		for range 3 + rand.Intn(3) {
+			events = append(events, newEvent(match.ID, side.teamID, models.EventSubstitution, randomMinute(46, 89)))
+		}
+	}
+
+	return append(events,
+		newEvent(match.ID, 0, models.EventHalfTime, 45),
+		newEvent(match.ID, 0, models.EventFullTime, 90),
+	)
+}
+
+// OrderTimeline sorts a timeline and stamps the running score on every event
+func OrderTimeline(match *models.Match, events []models.MatchEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Minute != events[j].Minute {
+			return events[i].Minute < events[j].Minute
+		}
+		return eventOrder[events[i].Type] < eventOrder[events[j].Type]
+	})
+
+	homeScore, awayScore := 0, 0
+	for i := range events {
+		if events[i].Type == models.EventGoal {
+			if events[i].TeamID == match.HomeTeamID {
+				homeScore++
+			} else {
+				awayScore++
+			}
+		}
+		events[i].HomeScore = homeScore
+		events[i].AwayScore = awayScore
+	}
+}
+
+// newEvent creates an event for a team at the given minute
+func newEvent(matchID, teamID uint, eventType string, minute int) models.MatchEvent {
+	return models.MatchEvent{
+		MatchID: matchID,
+		Minute:  minute,
+		Type:    eventType,
+		TeamID:  teamID,
+	}
+}
+
+// randomMinute returns a uniformly random minute in the inclusive range
+func randomMinute(from, to int) int {
+	return from + rand.Intn(to-from+1)
+}
@@ -0,0 +1,35 @@
+package helpers
+
+// RoundRobin pairs n entrants so each meets every other once, returning one round per slice of index pairs.
+// The first entrant stays fixed while the others rotate; with an odd count one entrant sits out each round.
+func RoundRobin(n int) [][][2]int {
+	// An odd number of entrants gets a bye slot at index n, left out of the pairings
+	slots := n
+	if slots%2 != 0 {
+		slots++
+	}
+
+	rotation := make([]int, slots)
+	for i := range rotation {
+		rotation[i] = i
+	}
+
+	var rounds [][][2]int
+	for range slots - 1 {
+		// Pair entrants at opposite ends of the rotation
+		var round [][2]int
+		for i := range slots / 2 {
+			home, away := rotation[i], rotation[slots-1-i]
+			if home < n && away < n {
+				round = append(round, [2]int{home, away})
+			}
+		}
+		rounds = append(rounds, round)
+
+		// Keep the first entrant fixed, move the second to the end and shift the others up
+		second := rotation[1]
+		copy(rotation[1:], rotation[2:])
+		rotation[slots-1] = second
+	}
+	return rounds
+}
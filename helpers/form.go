@@ -0,0 +1,47 @@
+package helpers
+
+import (
+	"insider-league/models"
+	"sort"
+)
+
+// RecentForm returns the points per game a team collected over its last n played matches
+func RecentForm(matches []models.Match, teamID uint, n int) float64 {
+	var played []models.Match
+	for _, match := range matches {
+		if match.IsPlayed && (match.HomeTeamID == teamID || match.AwayTeamID == teamID) {
+			played = append(played, match)
+		}
+	}
+
+	if len(played) == 0 || n <= 0 {
+		return 0
+	}
+
+	// Most recent matches first
+	sort.SliceStable(played, func(i, j int) bool {
+		if played[i].Week != played[j].Week {
+			return played[i].Week > played[j].Week
+		}
+		return played[i].ID > played[j].ID
+	})
+	if len(played) > n {
+		played = played[:n]
+	}
+
+	points := 0
+	for _, match := range played {
+		goalsFor, goalsAgainst := match.HomeTeamScore, match.AwayTeamScore
+		if match.AwayTeamID == teamID {
+			goalsFor, goalsAgainst = goalsAgainst, goalsFor
+		}
+		switch {
+		case goalsFor > goalsAgainst:
+			points += 3
+		case goalsFor == goalsAgainst:
+			points++
+		}
+	}
+
+	return float64(points) / float64(len(played))
+}
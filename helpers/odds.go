@@ -0,0 +1,76 @@
+package helpers
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaxFractionalDenominator bounds the denominator of fractional odds, keeping them readable
+const MaxFractionalDenominator = 20
+
+// PoissonProbability returns the chance of exactly k goals when the expected number is lambda
+func PoissonProbability(k int, lambda float64) float64 {
+	return math.Exp(PoissonLogLikelihood(k, lambda))
+}
+
+// ScoreProbabilities returns the chance of every scoreline up to maxGoals for each side, indexed
+// [homeGoals][awayGoals]. Goals are drawn independently, as SimulateMatchScore does.
+func ScoreProbabilities(homeExpectedGoals, awayExpectedGoals float64, maxGoals int) [][]float64 {
+	home := make([]float64, maxGoals+1)
+	away := make([]float64, maxGoals+1)
+	for goals := 0; goals <= maxGoals; goals++ {
+		home[goals] = PoissonProbability(goals, homeExpectedGoals)
+		away[goals] = PoissonProbability(goals, awayExpectedGoals)
+	}
+
+	scores := make([][]float64, maxGoals+1)
+	for h := range scores {
+		scores[h] = make([]float64, maxGoals+1)
+		for a := range scores[h] {
+			scores[h][a] = home[h] * away[a]
+		}
+	}
+	return scores
+}
+
+// TotalGoalsAtMost returns the chance that a match with the given expected goals has at most n goals.
+// The total of two independent Poisson counts is Poisson with the summed mean.
+func TotalGoalsAtMost(n int, homeExpectedGoals, awayExpectedGoals float64) float64 {
+	total := 0.0
+	for goals := 0; goals <= n; goals++ {
+		total += PoissonProbability(goals, homeExpectedGoals+awayExpectedGoals)
+	}
+	return total
+}
+
+// DecimalOdds returns the decimal price of a probability once the bookmaker margin is added
+func DecimalOdds(probability, margin float64) float64 {
+	return 1 / (probability * (1 + margin))
+}
+
+// FractionalOdds formats decimal odds as the closest fraction with a denominator up to
+// MaxFractionalDenominator, preferring the smaller denominator, e.g. 2.5 as "3/2"
+func FractionalOdds(decimal float64) string {
+	profit := decimal - 1
+	bestNumerator, bestDenominator := 0, 1
+	bestError := math.Inf(1)
+	for denominator := 1; denominator <= MaxFractionalDenominator; denominator++ {
+		numerator := int(math.Round(profit * float64(denominator)))
+		if numerator < 1 {
+			numerator = 1
+		}
+		if err := math.Abs(float64(numerator)/float64(denominator) - profit); err < bestError-1e-9 {
+			bestNumerator, bestDenominator, bestError = numerator, denominator, err
+		}
+	}
+	return fmt.Sprintf("%d/%d", bestNumerator, bestDenominator)
+}
+
+// AmericanOdds formats decimal odds as a moneyline: the profit on a 100 stake for underdogs, e.g. "+150",
+// or the stake needed to win 100 for favourites, e.g. "-200"
+func AmericanOdds(decimal float64) string {
+	if decimal >= 2 {
+		return fmt.Sprintf("+%d", int(math.Round((decimal-1)*100)))
+	}
+	return fmt.Sprintf("-%d", int(math.Round(100/(decimal-1))))
+}
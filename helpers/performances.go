@@ -0,0 +1,268 @@
+package helpers
+
+import (
+	"insider-league/models"
+	"math/rand"
+	"slices"
+	"sort"
+)
+
+// saveShare is the share of missed shots that were on target and so count as goalkeeper saves
+const saveShare = 0.35
+
+// matchdayFormation is the shape of the starting XI picked from a club's squad
+var matchdayFormation = map[string]int{
+	models.PositionGoalkeeper: 1,
+	models.PositionDefender:   4,
+	models.PositionMidfielder: 4,
+	models.PositionForward:    2,
+}
+
+// cardWeights is how likely each position is to be booked, relative to a defender
+var cardWeights = map[string]float64{
+	models.PositionDefender:   1.0,
+	models.PositionMidfielder: 0.9,
+	models.PositionForward:    0.6,
+	models.PositionGoalkeeper: 0.1,
+}
+
+// appearance tracks a player's time on the pitch while a timeline is replayed
+type appearance struct {
+	player  models.Player
+	on, off int
+	record  *models.PlayerGameweek
+
+	// lastInvolvement is the last minute the player scored or assisted, so he must still be on the pitch then
+	lastInvolvement int
+}
+
+// onPitch reports whether the player was on the pitch at the given minute.
+// A substitute coming on in a minute is not yet counted for that minute.
+func (a *appearance) onPitch(minute int) bool {
+	return a.on < minute && minute <= a.off
+}
+
+// side holds one club's matchday squad while a timeline is replayed
+type side struct {
+	teamID  uint
+	players map[uint]*appearance
+	bench   []models.Player
+}
+
+// BuildPlayerPerformances replays a match timeline against both squads and returns what every player who appeared did.
+// Starters are the players credited with goals or assists plus the best-rated players for a 4-4-2; team-level
+// substitutions, cards and missed shots in the timeline are attributed to players on the pitch at the time.
+// Points and clean sheets are left for the caller to work out.
+func BuildPlayerPerformances(match *models.Match, events []models.MatchEvent, homeSquad, awaySquad []models.Player) []models.PlayerGameweek {
+	home := newSide(match.HomeTeamID, homeSquad, events)
+	away := newSide(match.AwayTeamID, awaySquad, events)
+
+	for _, event := range events {
+		own, opponent := home, away
+		if event.TeamID == match.AwayTeamID {
+			own, opponent = away, home
+		}
+
+		switch event.Type {
+		case models.EventGoal:
+			if scorer := own.find(event.PlayerID); scorer != nil {
+				scorer.record.Goals++
+			}
+			if assister := own.find(event.AssistPlayerID); assister != nil {
+				assister.record.Assists++
+			}
+			for _, player := range opponent.players {
+				if player.onPitch(event.Minute) {
+					player.record.GoalsConceded++
+				}
+			}
+		case models.EventShot:
+			if keeper := opponent.keeper(event.Minute); keeper != nil && rand.Float64() < saveShare {
+				keeper.record.Saves++
+			}
+		case models.EventYellowCard:
+			if player := own.pickOnPitch(event.Minute, false); player != nil {
+				player.record.YellowCards++
+			}
+		case models.EventRedCard:
+			if player := own.pickOnPitch(event.Minute, true); player != nil {
+				player.record.RedCards++
+				player.off = event.Minute
+			}
+		case models.EventSubstitution:
+			own.substitute(event.Minute)
+		}
+	}
+
+	var records []models.PlayerGameweek
+	for _, s := range []*side{home, away} {
+		for _, player := range s.players {
+			player.record.Week = match.Week
+			player.record.MatchID = match.ID
+			player.record.Minutes = max(player.off-player.on, 1)
+			records = append(records, *player.record)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].PlayerID < records[j].PlayerID
+	})
+	return records
+}
+
+// newSide picks a club's starting XI and bench for the match
+func newSide(teamID uint, squad []models.Player, events []models.MatchEvent) *side {
+	// Players credited with a goal or assist must play, and stay on until their last involvement
+	involvement := make(map[uint]int)
+	for _, event := range events {
+		if event.Type != models.EventGoal || event.TeamID != teamID {
+			continue
+		}
+		for _, id := range []*uint{event.PlayerID, event.AssistPlayerID} {
+			if id != nil {
+				involvement[*id] = max(involvement[*id], event.Minute)
+			}
+		}
+	}
+
+	ranked := slices.Clone(squad)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Rating > ranked[j].Rating
+	})
+
+	s := &side{teamID: teamID, players: make(map[uint]*appearance)}
+	slots := make(map[string]int)
+	for position, count := range matchdayFormation {
+		slots[position] = count
+	}
+
+	start := func(player models.Player) {
+		s.players[player.ID] = &appearance{
+			player:          player,
+			on:              0,
+			off:             90,
+			lastInvolvement: involvement[player.ID],
+			record: &models.PlayerGameweek{
+				PlayerID: player.ID,
+				TeamID:   teamID,
+				Position: player.Position,
+			},
+		}
+		slots[player.Position]--
+	}
+
+	for _, player := range ranked {
+		if _, ok := involvement[player.ID]; ok {
+			start(player)
+		}
+	}
+	for _, player := range ranked {
+		if _, started := s.players[player.ID]; !started && slots[player.Position] > 0 && len(s.players) < 11 {
+			start(player)
+		}
+	}
+
+	// A short squad fills the XI with the best remaining outfield players
+	for _, player := range ranked {
+		if _, started := s.players[player.ID]; !started && player.Position != models.PositionGoalkeeper && len(s.players) < 11 {
+			start(player)
+		}
+	}
+
+	for _, player := range ranked {
+		if _, started := s.players[player.ID]; !started && player.Position != models.PositionGoalkeeper {
+			s.bench = append(s.bench, player)
+		}
+	}
+	return s
+}
+
+// find returns the appearance of a credited player, if he is in this squad
+func (s *side) find(playerID *uint) *appearance {
+	if playerID == nil {
+		return nil
+	}
+	return s.players[*playerID]
+}
+
+// keeper returns the goalkeeper on the pitch at the given minute
+func (s *side) keeper(minute int) *appearance {
+	for _, player := range s.players {
+		if player.player.Position == models.PositionGoalkeeper && player.onPitch(minute) {
+			return player
+		}
+	}
+	return nil
+}
+
+// pickOnPitch draws a player on the pitch weighted by position. When the player will be
+// sent off, anyone still due to score or assist later is left out.
+func (s *side) pickOnPitch(minute int, sendingOff bool) *appearance {
+	var candidates []*appearance
+	var weights []float64
+	total := 0.0
+	for _, player := range s.sortedPlayers() {
+		if !player.onPitch(minute) || (sendingOff && player.lastInvolvement > minute) {
+			continue
+		}
+		candidates = append(candidates, player)
+		weights = append(weights, cardWeights[player.player.Position])
+		total += cardWeights[player.player.Position]
+	}
+	if total == 0 {
+		return nil
+	}
+
+	r := rand.Float64() * total
+	for i, candidate := range candidates {
+		r -= weights[i]
+		if r < 0 {
+			return candidate
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+// substitute replaces a random outfield player who started with the best unused bench player
+func (s *side) substitute(minute int) {
+	if len(s.bench) == 0 {
+		return
+	}
+
+	var candidates []*appearance
+	for _, player := range s.sortedPlayers() {
+		if player.on == 0 && player.off == 90 && player.player.Position != models.PositionGoalkeeper && player.lastInvolvement <= minute {
+			candidates = append(candidates, player)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	off := candidates[rand.Intn(len(candidates))]
+	off.off = minute
+
+	on := s.bench[0]
+	s.bench = s.bench[1:]
+	s.players[on.ID] = &appearance{
+		player: on,
+		on:     minute,
+		off:    90,
+		record: &models.PlayerGameweek{
+			PlayerID: on.ID,
+			TeamID:   s.teamID,
+			Position: on.Position,
+		},
+	}
+}
+
+// sortedPlayers returns the appearances in player ID order so random draws are reproducible for a given seed
+func (s *side) sortedPlayers() []*appearance {
+	players := make([]*appearance, 0, len(s.players))
+	for _, player := range s.players {
+		players = append(players, player)
+	}
+	sort.Slice(players, func(i, j int) bool {
+		return players[i].player.ID < players[j].player.ID
+	})
+	return players
+}
@@ -0,0 +1,80 @@
+package helpers
+
+import "insider-league/models"
+
+// SimulateSeason plays the remaining matches out the given number of times from the current table and returns,
+// for each team in the order given, the share of simulations in which it finished in each position. Matches are
+// simulated like the league plays them, from the teams' current attack and defence ratings.
+func SimulateSeason(teams []models.Team, remaining []models.Match, iterations int) [][]float64 {
+	index := make(map[uint]int, len(teams))
+	for i, team := range teams {
+		index[team.ID] = i
+	}
+
+	type fixture struct {
+		home, away                 int
+		homeExpected, awayExpected float64
+	}
+	var fixtures []fixture
+	for _, match := range remaining {
+		home, ok := index[match.HomeTeamID]
+		if !ok {
+			continue
+		}
+		away, ok := index[match.AwayTeamID]
+		if !ok {
+			continue
+		}
+		homeExpected, awayExpected := ExpectedGoals(teams[home].AttackRating(), teams[home].DefenceRating(), teams[away].AttackRating(), teams[away].DefenceRating())
+		fixtures = append(fixtures, fixture{home, away, homeExpected, awayExpected})
+	}
+
+	// With nothing left to play every simulation ends the same way
+	if len(fixtures) == 0 || iterations < 1 {
+		iterations = 1
+	}
+
+	counts := make([][]float64, len(teams))
+	for i := range counts {
+		counts[i] = make([]float64, len(teams))
+	}
+	table := make([]models.Team, len(teams))
+	for n := 0; n < iterations; n++ {
+		copy(table, teams)
+		for _, f := range fixtures {
+			homeGoals, awayGoals := SimulateMatchScore(f.homeExpected, f.awayExpected)
+			ApplyResultToStats(&table[f.home], &table[f.away], homeGoals, awayGoals, false)
+		}
+		RankTeams(table)
+		for position, team := range table {
+			counts[index[team.ID]][position]++
+		}
+	}
+
+	for i := range counts {
+		for position := range counts[i] {
+			counts[i][position] /= float64(iterations)
+		}
+	}
+	return counts
+}
+
+// ZoneProbability sums the chances of finishing in each position of a zone, clipped to the size of the league
+func ZoneProbability(positions []float64, zone models.Zone) float64 {
+	from, to := zonePosition(zone.From, len(positions)), zonePosition(zone.To, len(positions))
+	from, to = max(from, 1), min(to, len(positions))
+
+	total := 0.0
+	for position := from; position <= to; position++ {
+		total += positions[position-1]
+	}
+	return total
+}
+
+// zonePosition resolves a zone boundary, counting negative positions from the bottom of the table
+func zonePosition(position, teams int) int {
+	if position < 0 {
+		return teams + 1 + position
+	}
+	return position
+}
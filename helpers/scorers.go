@@ -0,0 +1,87 @@
+package helpers
+
+import (
+	"insider-league/models"
+	"math"
+	"math/rand"
+)
+
+// assistChance is the probability that a goal has an assister
+const assistChance = 0.75
+
+// scoringWeights is how likely each position is to score, relative to a forward
+var scoringWeights = map[string]float64{
+	models.PositionForward:    1.0,
+	models.PositionMidfielder: 0.55,
+	models.PositionDefender:   0.15,
+	models.PositionGoalkeeper: 0.005,
+}
+
+// assistWeights is how likely each position is to assist, relative to a midfielder
+var assistWeights = map[string]float64{
+	models.PositionMidfielder: 1.0,
+	models.PositionForward:    0.65,
+	models.PositionDefender:   0.4,
+	models.PositionGoalkeeper: 0.02,
+}
+
+// AttributeGoals credits every goal in a timeline to a scorer and, usually, an assister from the scoring side's squad.
+// Players are drawn by position weight and rating. Goals of a side without a squad are left unattributed.
+func AttributeGoals(events []models.MatchEvent, match *models.Match, homeSquad, awaySquad []models.Player) {
+	for i := range events {
+		if events[i].Type != models.EventGoal {
+			continue
+		}
+
+		squad := awaySquad
+		if events[i].TeamID == match.HomeTeamID {
+			squad = homeSquad
+		}
+
+		scorer := pickPlayer(squad, scoringWeights, 0)
+		if scorer == nil {
+			continue
+		}
+		events[i].PlayerID = &scorer.ID
+
+		if rand.Float64() < assistChance {
+			if assister := pickPlayer(squad, assistWeights, scorer.ID); assister != nil {
+				events[i].AssistPlayerID = &assister.ID
+			}
+		}
+	}
+}
+
+// pickPlayer draws a player weighted by position and rating, skipping the excluded player ID
+func pickPlayer(squad []models.Player, positionWeights map[string]float64, exclude uint) *models.Player {
+	weights := make([]float64, len(squad))
+	total := 0.0
+	for i, player := range squad {
+		if player.ID == exclude {
+			continue
+		}
+
+		// Rating to the fourth power makes stars clearly likelier than squad players
+		weights[i] = positionWeights[player.Position] * math.Pow(float64(player.Rating)/100, 4)
+		total += weights[i]
+	}
+	if total == 0 {
+		return nil
+	}
+
+	r := rand.Float64() * total
+	for i := range squad {
+		r -= weights[i]
+		if r < 0 && weights[i] > 0 {
+			return &squad[i]
+		}
+	}
+
+	// Rounding can leave r marginally above zero; fall back to the last eligible player
+	for i := len(squad) - 1; i >= 0; i-- {
+		if weights[i] > 0 {
+			return &squad[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package helpers
+
+import (
+	"insider-league/models"
+	"sort"
+)
+
+// ApplyResultToStats updates both teams' statistics in memory for a match result.
+// If revert is true, it subtracts the result instead of adding it.
+func ApplyResultToStats(homeTeam, awayTeam *models.Team, homeGoals, awayGoals int, revert bool) {
+	// Determine the multiplier based on whether we're reverting or applying
+	multiplier := 1
+	if revert {
+		multiplier = -1
+	}
+
+	// Update points and match results
+	if homeGoals > awayGoals {
+		// Home team wins
+		homeTeam.Stats.Points += 3 * multiplier
+		homeTeam.Stats.Wins += 1 * multiplier
+		awayTeam.Stats.Losses += 1 * multiplier
+	} else if homeGoals < awayGoals {
+		// Away team wins
+		awayTeam.Stats.Points += 3 * multiplier
+		awayTeam.Stats.Wins += 1 * multiplier
+		homeTeam.Stats.Losses += 1 * multiplier
+	} else {
+		// Draw
+		homeTeam.Stats.Points += 1 * multiplier
+		awayTeam.Stats.Points += 1 * multiplier
+		homeTeam.Stats.Draws += 1 * multiplier
+		awayTeam.Stats.Draws += 1 * multiplier
+	}
+
+	// Update goal statistics
+	homeTeam.Stats.GoalsFor += homeGoals * multiplier
+	homeTeam.Stats.GoalsAgainst += awayGoals * multiplier
+	awayTeam.Stats.GoalsFor += awayGoals * multiplier
+	awayTeam.Stats.GoalsAgainst += homeGoals * multiplier
+
+	// Update goal difference
+	homeTeam.Stats.GoalDifference = homeTeam.Stats.GoalsFor - homeTeam.Stats.GoalsAgainst
+	awayTeam.Stats.GoalDifference = awayTeam.Stats.GoalsFor - awayTeam.Stats.GoalsAgainst
+}
+
+// RankTeams sorts teams by their league position in place
+func RankTeams(teams []models.Team) {
+	// Sort teams by points (descending), goal difference (descending), and goals scored (descending)
+	sort.Slice(teams, func(i, j int) bool {
+		// First compare by points
+		if teams[i].Stats.Points != teams[j].Stats.Points {
+			return teams[i].Stats.Points > teams[j].Stats.Points
+		}
+
+		// If points are equal, compare by goal difference
+		iGD := teams[i].Stats.GoalsFor - teams[i].Stats.GoalsAgainst
+		jGD := teams[j].Stats.GoalsFor - teams[j].Stats.GoalsAgainst
+		if iGD != jGD {
+			return iGD > jGD
+		}
+
+		// If goal difference is equal, compare by goals scored
+		return teams[i].Stats.GoalsFor > teams[j].Stats.GoalsFor
+	})
+}
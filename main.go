@@ -1,15 +1,21 @@
 package main
 
 import (
+	"crypto/rand"
+	"encoding/json"
 	"fmt"
 	"insider-league/db"
 	"insider-league/db/seeds"
+	"insider-league/eventbus"
 	"insider-league/handlers"
+	"insider-league/helpers"
+	"insider-league/models"
 	"insider-league/repository"
 	"insider-league/services"
 	"log"
 	"os"
 
+	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
 	"github.com/joho/godotenv"
 )
@@ -41,11 +47,68 @@ func main() {
 	// Initialize repositories
 	teamRepo := repository.NewTeamRepository(db.DB)
 	matchRepo := repository.NewMatchRepository(db.DB)
+	ratingRepo := repository.NewRatingRepository(db.DB)
+	matchEventRepo := repository.NewMatchEventRepository(db.DB)
+	webhookRepo := repository.NewWebhookRepository(db.DB)
+	playerRepo := repository.NewPlayerRepository(db.DB)
+	managerRepo := repository.NewManagerRepository(db.DB)
+	fantasySquadRepo := repository.NewFantasySquadRepository(db.DB)
+	fantasyPointsRepo := repository.NewFantasyPointsRepository(db.DB)
+	transferRepo := repository.NewTransferRepository(db.DB)
+	lineupRepo := repository.NewLineupRepository(db.DB)
+	chipRepo := repository.NewChipRepository(db.DB)
+	miniLeagueRepo := repository.NewMiniLeagueRepository(db.DB)
+	priceRepo := repository.NewPriceRepository(db.DB)
+	userRepo := repository.NewUserRepository(db.DB)
+	apiKeyRepo := repository.NewAPIKeyRepository(db.DB)
+	scorePredictionRepo := repository.NewScorePredictionRepository(db.DB)
+	bettingRepo := repository.NewBettingRepository(db.DB)
+
+	// Initialize the event bus that notifies subscribers of league changes, the status that closes
+	// predictions, bets and squad changes while a week is being played, and the lock that keeps operations
+	// changing results or strengths from overlapping
+	bus := eventbus.NewBus()
+	weeks := services.NewWeekStatus()
+	leagueLock := services.NewLeagueLock()
 
 	// Initialize services
-	teamService := services.NewTeamService(teamRepo)
-	matchService := services.NewMatchService(matchRepo)
-	leagueService := services.NewLeagueService(teamService, matchService)
+	teamService := services.NewTeamService(teamRepo, bus)
+	matchService := services.NewMatchService(matchRepo, teamRepo)
+	ratingService := services.NewRatingService(ratingRepo, teamRepo, matchRepo, eloConfigFromEnv())
+	matchEventService := services.NewMatchEventService(matchEventRepo, matchRepo, playerRepo)
+	playerService := services.NewPlayerService(playerRepo, teamRepo)
+	fantasyConfig := fantasyConfigFromEnv()
+	managerService := services.NewManagerService(managerRepo, fantasySquadRepo, playerRepo, fantasyConfig)
+	fantasyScoringService := services.NewFantasyScoringService(fantasyPointsRepo, managerRepo, fantasySquadRepo, playerRepo, matchEventRepo, transferRepo, lineupRepo, chipRepo, fantasyConfig, fantasyScoringRulesFromEnv())
+	transferService := services.NewTransferService(transferRepo, managerRepo, fantasySquadRepo, playerRepo, matchRepo, chipRepo, weeks, fantasyConfig)
+	lineupService := services.NewLineupService(lineupRepo, managerRepo, fantasySquadRepo, matchRepo, weeks, fantasyConfig)
+	chipService := services.NewChipService(chipRepo, managerRepo, fantasySquadRepo, lineupRepo, matchRepo, weeks)
+	miniLeagueService := services.NewMiniLeagueService(miniLeagueRepo, managerRepo, fantasyPointsRepo, matchRepo, weeks)
+	priceService := services.NewPriceService(priceRepo, playerRepo, managerRepo, transferRepo, fantasyPointsRepo, fantasyConfig)
+	authService := services.NewAuthService(userRepo, authConfigFromEnv())
+	if err := authService.SeedAdmin(); err != nil {
+		log.Fatalf("Failed to seed the admin account: %v", err)
+	}
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, userRepo)
+	scorePredictionService := services.NewScorePredictionService(scorePredictionRepo, matchRepo, userRepo, weeks)
+	oddsService := services.NewOddsService(matchRepo, weeks, oddsConfigFromEnv())
+	bettingService := services.NewBettingService(bettingRepo, oddsService, bettingConfigFromEnv())
+	calibrationService := services.NewCalibrationService(teamService, matchService, bus, leagueLock)
+	leagueService := services.NewLeagueService(teamService, matchService, ratingService, matchEventService, bus, weeks, leagueLock, simulationConfigFromEnv())
+	webhookService := services.NewWebhookService(webhookRepo, webhookConfigFromEnv())
+
+	// Forward league events to webhook subscribers and keep fantasy scores, transfers, lineups, chips,
+	// mini-leagues, prices, score predictions and bets in step with the league. Chips, mini-leagues and prices come
+	// after scoring so a free hit squad is scored before it is reverted and matchups and form see the week's scores.
+	bus.Subscribe(webhookService.HandleEvent)
+	bus.Subscribe(fantasyScoringService.HandleEvent)
+	bus.Subscribe(transferService.HandleEvent)
+	bus.Subscribe(lineupService.HandleEvent)
+	bus.Subscribe(chipService.HandleEvent)
+	bus.Subscribe(miniLeagueService.HandleEvent)
+	bus.Subscribe(priceService.HandleEvent)
+	bus.Subscribe(scorePredictionService.HandleEvent)
+	bus.Subscribe(bettingService.HandleEvent)
 
 	// Create a new Fiber app
 	app := fiber.New()
@@ -58,20 +121,100 @@ func main() {
 	// API routes
 	api := app.Group("/api")
 
-	// Teams routes
-	teams := api.Group("/teams")
+	// Handlers
 	teamHandler := handlers.NewTeamHandler(teamService)
+	matchHandler := handlers.NewMatchHandler(matchService)
+	ratingHandler := handlers.NewRatingHandler(ratingService)
+	calibrationHandler := handlers.NewCalibrationHandler(calibrationService)
+	matchEventHandler := handlers.NewMatchEventHandler(matchEventService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	playerHandler := handlers.NewPlayerHandler(playerService)
+	managerHandler := handlers.NewManagerHandler(managerService)
+	fantasyScoringHandler := handlers.NewFantasyScoringHandler(fantasyScoringService)
+	transferHandler := handlers.NewTransferHandler(transferService)
+	lineupHandler := handlers.NewLineupHandler(lineupService)
+	chipHandler := handlers.NewChipHandler(chipService)
+	miniLeagueHandler := handlers.NewMiniLeagueHandler(miniLeagueService)
+	priceHandler := handlers.NewPriceHandler(priceService)
+	authHandler := handlers.NewAuthHandler(authService, apiKeyService)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
+	scorePredictionHandler := handlers.NewScorePredictionHandler(scorePredictionService)
+	oddsHandler := handlers.NewOddsHandler(oddsService)
+	bettingHandler := handlers.NewBettingHandler(bettingService)
+
+	// Auth routes are open so accounts can be created and signed in
+	auth := api.Group("/auth")
+	auth.Post("/register", authHandler.Register)
+	auth.Post("/login", authHandler.Login)
+	auth.Post("/refresh", authHandler.Refresh)
+	auth.Post("/logout", authHandler.Logout)
+
+	// Every route below reads the access token or API key when one is sent, and those that change data require one
+	api.Use(authHandler.Authenticate)
+	auth.Get("/me", authHandler.RequireUser, authHandler.GetCurrentUser)
+
+	// API key routes; keys are managed only from a signed-in session, never with another key
+	apiKeys := auth.Group("/api-keys", authHandler.RequireSession)
+	apiKeys.Get("/", apiKeyHandler.GetAPIKeys)
+	apiKeys.Post("/", apiKeyHandler.CreateAPIKey)
+	apiKeys.Delete("/:id", apiKeyHandler.RevokeAPIKey)
+
+	// Role checks: groups map each method that changes data to the permission it needs, so viewers stay
+	// read-only, commissioners run the league and only admins delete league data or reset. Managers are deleted
+	// with the fantasy permission instead, since their routes also check ownership. Webhooks also guard reads,
+	// since subscriptions and their delivery logs expose subscriber URLs and payloads.
+	manageLeague := authHandler.Authorize(map[string]string{
+		fiber.MethodPost:   models.PermissionManageLeague,
+		fiber.MethodPut:    models.PermissionManageLeague,
+		fiber.MethodDelete: models.PermissionDelete,
+	})
+	editResults := authHandler.Authorize(map[string]string{
+		fiber.MethodPost:   models.PermissionEditResults,
+		fiber.MethodPut:    models.PermissionEditResults,
+		fiber.MethodDelete: models.PermissionDelete,
+	})
+	playFantasy := authHandler.Authorize(map[string]string{
+		fiber.MethodPost:   models.PermissionPlayFantasy,
+		fiber.MethodPut:    models.PermissionPlayFantasy,
+		fiber.MethodDelete: models.PermissionPlayFantasy,
+	})
+	manageWebhooks := authHandler.Authorize(map[string]string{
+		fiber.MethodGet:    models.PermissionManageWebhooks,
+		fiber.MethodPost:   models.PermissionManageWebhooks,
+		fiber.MethodDelete: models.PermissionManageWebhooks,
+	})
+
+	// User administration routes
+	users := api.Group("/users", authHandler.Require(models.PermissionManageUsers))
+	users.Get("/", authHandler.GetAllUsers)
+	users.Put("/:id/role", authHandler.SetUserRole)
+
+	// Teams routes
+	teams := api.Group("/teams", manageLeague)
 	teams.Get("/", teamHandler.GetAllTeams)
 	teams.Get("/:id", teamHandler.GetTeamByID)
 	teams.Put("/:id", teamHandler.UpdateTeam)
 	teams.Delete("/:id", teamHandler.DeleteTeam)
 	teams.Post("/", teamHandler.CreateTeam)
+	teams.Get("/:id/head-to-head/:otherId", matchHandler.GetHeadToHead)
+	teams.Get("/:id/ratings", ratingHandler.GetTeamRatings)
+	teams.Get("/:id/players", playerHandler.GetTeamPlayers)
+
+	// Players routes
+	players := api.Group("/players", manageLeague)
+	players.Get("/", playerHandler.GetAllPlayers)
+	players.Get("/:id", playerHandler.GetPlayerByID)
+	players.Put("/:id", playerHandler.UpdatePlayer)
+	players.Delete("/:id", playerHandler.DeletePlayer)
+	players.Post("/", playerHandler.CreatePlayer)
+	players.Get("/:id/prices", priceHandler.GetPlayerPrices)
 
 	// Matches routes
-	matches := api.Group("/matches")
-	matchHandler := handlers.NewMatchHandler(matchService)
+	matches := api.Group("/matches", editResults)
 	matches.Get("/", matchHandler.GetAllMatches)
 	matches.Get("/:id", matchHandler.GetMatchByID)
+	matches.Get("/:id/events", matchEventHandler.GetMatchEvents)
+	matches.Get("/:id/odds", oddsHandler.GetMatchOdds)
 	matches.Put("/:id", matchHandler.UpdateMatch)
 	matches.Delete("/:id", matchHandler.DeleteMatch)
 	matches.Post("/", matchHandler.CreateMatch)
@@ -80,11 +223,82 @@ func main() {
 	league := api.Group("/league")
 	leagueHandler := handlers.NewLeagueHandler(leagueService)
 	league.Get("/", leagueHandler.GetLeagueTable)
-	league.Get("/play", leagueHandler.PlayNextWeek)
-	league.Get("/play-all", leagueHandler.PlayAll)
+	league.Get("/play", authHandler.Require(models.PermissionPlayWeeks), leagueHandler.PlayNextWeek)
+	league.Get("/play-all", authHandler.Require(models.PermissionPlayWeeks), leagueHandler.PlayAll)
 	league.Get("/week/:id", leagueHandler.GetWeekResults)
-	league.Put("/edit-match/:id", leagueHandler.EditMatchResult)
-	league.Post("/reset", leagueHandler.ResetLeague)
+	league.Get("/projections", leagueHandler.GetProjections)
+	league.Get("/power-rankings", ratingHandler.GetPowerRankings)
+	league.Get("/top-scorers", matchEventHandler.GetTopScorers)
+	league.Get("/top-assists", matchEventHandler.GetTopAssisters)
+	league.Put("/edit-match/:id", authHandler.Require(models.PermissionEditResults), leagueHandler.EditMatchResult)
+	league.Post("/reset", authHandler.Require(models.PermissionResetLeague), leagueHandler.ResetLeague)
+	league.Post("/calibrate", authHandler.Require(models.PermissionManageLeague), calibrationHandler.Calibrate)
+	league.Post("/calibrate/upload", authHandler.Require(models.PermissionManageLeague), calibrationHandler.CalibrateUpload)
+
+	// Fantasy routes; changes to a manager are limited to the account that registered it, or an admin
+	managers := api.Group("/managers", playFantasy)
+	managers.Get("/", managerHandler.GetAllManagers)
+	managers.Get("/:id", managerHandler.GetManagerByID)
+	managers.Delete("/:id", managerHandler.RequireOwner, managerHandler.DeleteManager)
+	managers.Post("/", managerHandler.CreateManager)
+	managers.Get("/:id/squad", managerHandler.GetSquad)
+	managers.Post("/:id/squad", managerHandler.RequireOwner, managerHandler.CreateSquad)
+	managers.Get("/:id/squad/validate", managerHandler.ValidateSquad)
+	managers.Get("/:id/gameweeks", fantasyScoringHandler.GetManagerGameweeks)
+	managers.Get("/:id/gameweeks/:week", fantasyScoringHandler.GetManagerGameweek)
+	managers.Get("/:id/transfers", transferHandler.GetTransfers)
+	managers.Post("/:id/transfers", managerHandler.RequireOwner, transferHandler.MakeTransfers)
+	managers.Get("/:id/lineup", lineupHandler.GetLineup)
+	managers.Put("/:id/lineup", managerHandler.RequireOwner, lineupHandler.SetLineup)
+	managers.Get("/:id/chips", chipHandler.GetChips)
+	managers.Post("/:id/chips", managerHandler.RequireOwner, chipHandler.ActivateChip)
+	managers.Get("/:id/mini-leagues", miniLeagueHandler.GetManagerMiniLeagues)
+	fantasy := api.Group("/fantasy", playFantasy)
+	fantasy.Post("/validate-squad", managerHandler.ValidatePlayers)
+	fantasy.Get("/scoring-rules", fantasyScoringHandler.GetScoringRules)
+	fantasy.Get("/gameweeks/:week/players", fantasyScoringHandler.GetPlayerGameweeks)
+	fantasy.Get("/gameweeks/:week/prices", priceHandler.GetPriceChanges)
+	fantasy.Get("/deadline", transferHandler.GetDeadline)
+
+	// Mini-league routes
+	miniLeagues := api.Group("/mini-leagues", playFantasy)
+	miniLeagues.Post("/", miniLeagueHandler.CreateMiniLeague)
+	miniLeagues.Post("/join", miniLeagueHandler.JoinMiniLeague)
+	miniLeagues.Get("/:id", miniLeagueHandler.GetMiniLeagueByID)
+	miniLeagues.Get("/:id/standings", miniLeagueHandler.GetStandings)
+	miniLeagues.Get("/:id/fixtures", miniLeagueHandler.GetFixtures)
+
+	// Score prediction routes
+	predictions := api.Group("/predictions", authHandler.Authorize(map[string]string{
+		fiber.MethodPost: models.PermissionPredict,
+	}))
+	predictions.Post("/", scorePredictionHandler.Predict)
+	predictions.Get("/me", authHandler.RequireUser, scorePredictionHandler.GetMyPredictions)
+	predictions.Get("/leaderboard", scorePredictionHandler.GetLeaderboard)
+	predictions.Get("/week/:week", scorePredictionHandler.GetWeekPredictions)
+
+	// Betting routes; wallets and bets belong to the signed-in user
+	wallet := api.Group("/wallet", authHandler.RequireUser)
+	wallet.Get("/", bettingHandler.GetWallet)
+	wallet.Get("/transactions", bettingHandler.GetTransactions)
+	bets := api.Group("/bets", authHandler.RequireUser, authHandler.Authorize(map[string]string{
+		fiber.MethodPost: models.PermissionBet,
+	}))
+	bets.Get("/", bettingHandler.GetBets)
+	bets.Post("/", bettingHandler.PlaceBet)
+
+	// Webhook routes
+	webhooks := api.Group("/webhooks", manageWebhooks)
+	webhooks.Get("/", webhookHandler.GetAllWebhooks)
+	webhooks.Get("/:id", webhookHandler.GetWebhookByID)
+	webhooks.Get("/:id/deliveries", webhookHandler.GetWebhookDeliveries)
+	webhooks.Delete("/:id", webhookHandler.DeleteWebhook)
+	webhooks.Post("/", webhookHandler.CreateWebhook)
+
+	// WebSocket routes
+	socketHandler := handlers.NewLeagueSocketHandler(leagueService, bus)
+	ws := api.Group("/ws", socketHandler.Upgrade)
+	ws.Get("/league", websocket.New(socketHandler.Stream))
 
 	// Start the server
 	port := os.Getenv("SERVER_PORT")
@@ -94,3 +308,101 @@ func main() {
 	log.Printf("Server starting on port %s", port)
 	log.Fatal(app.Listen(fmt.Sprintf(":%s", port)))
 }
+
+// eloConfigFromEnv builds the Elo configuration, allowing ELO_K_FACTOR and ELO_HOME_ADVANTAGE overrides
+func eloConfigFromEnv() services.EloConfig {
+	config := services.DefaultEloConfig()
+	config.KFactor = helpers.GetEnvFloat("ELO_K_FACTOR", config.KFactor)
+	config.HomeAdvantage = helpers.GetEnvFloat("ELO_HOME_ADVANTAGE", config.HomeAdvantage)
+	return config
+}
+
+// simulationConfigFromEnv builds the simulation configuration from SIMULATION_MODE, the STRENGTH_* and the PROJECTION_*
+// variables. PROJECTION_ZONES replaces the default table zones with a JSON list such as
+// [{"name":"promotion","from":1,"to":2},{"name":"relegation","from":-2,"to":-1}].
+func simulationConfigFromEnv() services.SimulationConfig {
+	config := services.DefaultSimulationConfig()
+	if os.Getenv("SIMULATION_MODE") == services.SimulationModeEvents {
+		config.Mode = services.SimulationModeEvents
+	}
+	config.Dynamics.Enabled = helpers.GetEnvBool("STRENGTH_DYNAMICS", config.Dynamics.Enabled)
+	config.Dynamics.ShockStdDev = helpers.GetEnvFloat("STRENGTH_SHOCK_STDDEV", config.Dynamics.ShockStdDev)
+	config.Dynamics.RegressionRate = helpers.GetEnvFloat("STRENGTH_REGRESSION_RATE", config.Dynamics.RegressionRate)
+	config.Projection.Iterations = helpers.GetEnvInt("PROJECTION_ITERATIONS", config.Projection.Iterations)
+	if zones := os.Getenv("PROJECTION_ZONES"); zones != "" {
+		config.Projection.Zones = nil
+		if err := json.Unmarshal([]byte(zones), &config.Projection.Zones); err != nil {
+			log.Fatalf("Failed to parse PROJECTION_ZONES: %v", err)
+		}
+	}
+	return config
+}
+
+// authConfigFromEnv builds the account settings from JWT_SECRET, JWT_ACCESS_TTL, JWT_REFRESH_TTL, ADMIN_EMAIL and
+// ADMIN_PASSWORD. Without a secret a random one is generated, so tokens stop working when the server restarts.
+func authConfigFromEnv() services.AuthConfig {
+	config := services.DefaultAuthConfig()
+	config.Secret = []byte(os.Getenv("JWT_SECRET"))
+	if len(config.Secret) == 0 {
+		log.Println("JWT_SECRET is not set; using a random secret, so tokens will not survive a restart")
+		config.Secret = make([]byte, 32)
+		if _, err := rand.Read(config.Secret); err != nil {
+			log.Fatalf("Failed to generate a JWT secret: %v", err)
+		}
+	}
+	config.AccessTTL = helpers.GetEnvDuration("JWT_ACCESS_TTL", config.AccessTTL)
+	config.RefreshTTL = helpers.GetEnvDuration("JWT_REFRESH_TTL", config.RefreshTTL)
+	config.AdminEmail = os.Getenv("ADMIN_EMAIL")
+	config.AdminPassword = os.Getenv("ADMIN_PASSWORD")
+	return config
+}
+
+// webhookConfigFromEnv builds the delivery settings; WEBHOOK_WORKERS and WEBHOOK_QUEUE_SIZE size the delivery pool,
+// and WEBHOOK_ALLOW_PRIVATE_TARGETS lets local development subscribe loopback and private network addresses
+func webhookConfigFromEnv() services.WebhookConfig {
+	config := services.DefaultWebhookConfig()
+	config.Workers = helpers.GetEnvInt("WEBHOOK_WORKERS", config.Workers)
+	config.QueueSize = helpers.GetEnvInt("WEBHOOK_QUEUE_SIZE", config.QueueSize)
+	config.AllowPrivateTargets = helpers.GetEnvBool("WEBHOOK_ALLOW_PRIVATE_TARGETS", config.AllowPrivateTargets)
+	return config
+}
+
+// oddsConfigFromEnv builds the bookmaker settings, allowing the margin to be overridden with ODDS_MARGIN
+func oddsConfigFromEnv() services.OddsConfig {
+	config := services.DefaultOddsConfig()
+	config.Margin = helpers.GetEnvFloat("ODDS_MARGIN", config.Margin)
+	return config
+}
+
+// bettingConfigFromEnv builds the betting rules, allowing the starting balance to be overridden with BETTING_STARTING_BALANCE
+func bettingConfigFromEnv() services.BettingConfig {
+	config := services.DefaultBettingConfig()
+	config.StartingBalance = helpers.GetEnvFloat("BETTING_STARTING_BALANCE", config.StartingBalance)
+	return config
+}
+
+// fantasyConfigFromEnv builds the fantasy rules, allowing budget, club cap, transfer and price limit overrides
+func fantasyConfigFromEnv() services.FantasyConfig {
+	config := services.DefaultFantasyConfig()
+	config.Budget = helpers.GetEnvFloat("FANTASY_BUDGET", config.Budget)
+	config.MaxPerClub = helpers.GetEnvInt("FANTASY_MAX_PER_CLUB", config.MaxPerClub)
+	config.MaxFreeTransfers = helpers.GetEnvInt("FANTASY_MAX_FREE_TRANSFERS", config.MaxFreeTransfers)
+	config.TransferHit = helpers.GetEnvInt("FANTASY_TRANSFER_HIT", config.TransferHit)
+	config.PriceFloor = helpers.GetEnvFloat("FANTASY_PRICE_FLOOR", config.PriceFloor)
+	config.PriceCeiling = helpers.GetEnvFloat("FANTASY_PRICE_CEILING", config.PriceCeiling)
+	return config
+}
+
+// fantasyScoringRulesFromEnv loads the scoring table from the JSON file in FANTASY_SCORING_RULES, or the defaults
+func fantasyScoringRulesFromEnv() services.FantasyScoringRules {
+	path := os.Getenv("FANTASY_SCORING_RULES")
+	if path == "" {
+		return services.DefaultFantasyScoringRules()
+	}
+
+	rules, err := services.LoadFantasyScoringRules(path)
+	if err != nil {
+		log.Fatalf("Failed to load fantasy scoring rules from %s: %v", path, err)
+	}
+	return rules
+}
@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAPIKeyRepository is a mock implementation of repository.APIKeyRepository
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+// GetByUser mocks the GetByUser method
+func (m *MockAPIKeyRepository) GetByUser(userID int) ([]models.APIKey, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]models.APIKey), args.Error(1)
+}
+
+// GetByID mocks the GetByID method
+func (m *MockAPIKeyRepository) GetByID(id int) (*models.APIKey, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+// GetByHash mocks the GetByHash method
+func (m *MockAPIKeyRepository) GetByHash(hash string) (*models.APIKey, error) {
+	args := m.Called(hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockAPIKeyRepository) Create(key *models.APIKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+// Revoke mocks the Revoke method
+func (m *MockAPIKeyRepository) Revoke(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// TouchLastUsed mocks the TouchLastUsed method
+func (m *MockAPIKeyRepository) TouchLastUsed(id int, usedAt time.Time) error {
+	args := m.Called(id, usedAt)
+	return args.Error(0)
+}
+
+// Ensure MockAPIKeyRepository implements repository.APIKeyRepository
+var _ repository.APIKeyRepository = (*MockAPIKeyRepository)(nil)
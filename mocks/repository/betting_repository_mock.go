@@ -0,0 +1,67 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBettingRepository is a mock implementation of repository.BettingRepository
+type MockBettingRepository struct {
+	mock.Mock
+}
+
+// GetWallet mocks the GetWallet method
+func (m *MockBettingRepository) GetWallet(userID int) (*models.Wallet, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Wallet), args.Error(1)
+}
+
+// CreateWallet mocks the CreateWallet method
+func (m *MockBettingRepository) CreateWallet(wallet *models.Wallet, entry *models.WalletTransaction) error {
+	args := m.Called(wallet, entry)
+	return args.Error(0)
+}
+
+// GetBetsByUser mocks the GetBetsByUser method
+func (m *MockBettingRepository) GetBetsByUser(userID int) ([]models.Bet, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]models.Bet), args.Error(1)
+}
+
+// GetBetsByMatch mocks the GetBetsByMatch method
+func (m *MockBettingRepository) GetBetsByMatch(matchID int) ([]models.Bet, error) {
+	args := m.Called(matchID)
+	return args.Get(0).([]models.Bet), args.Error(1)
+}
+
+// GetPendingBets mocks the GetPendingBets method
+func (m *MockBettingRepository) GetPendingBets() ([]models.Bet, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Bet), args.Error(1)
+}
+
+// GetTransactions mocks the GetTransactions method
+func (m *MockBettingRepository) GetTransactions(userID int) ([]models.WalletTransaction, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]models.WalletTransaction), args.Error(1)
+}
+
+// SaveBet mocks the SaveBet method
+func (m *MockBettingRepository) SaveBet(wallet *models.Wallet, bet *models.Bet, entry *models.WalletTransaction) error {
+	args := m.Called(wallet, bet, entry)
+	return args.Error(0)
+}
+
+// FinalizeBets mocks the FinalizeBets method
+func (m *MockBettingRepository) FinalizeBets() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// Ensure MockBettingRepository implements repository.BettingRepository
+var _ repository.BettingRepository = (*MockBettingRepository)(nil)
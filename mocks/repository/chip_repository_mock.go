@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockChipRepository is a mock implementation of repository.ChipRepository
+type MockChipRepository struct {
+	mock.Mock
+}
+
+// GetByManager mocks the GetByManager method
+func (m *MockChipRepository) GetByManager(managerID int) ([]models.ChipActivation, error) {
+	args := m.Called(managerID)
+	return args.Get(0).([]models.ChipActivation), args.Error(1)
+}
+
+// GetByManagerWeek mocks the GetByManagerWeek method
+func (m *MockChipRepository) GetByManagerWeek(managerID, week int) (*models.ChipActivation, error) {
+	args := m.Called(managerID, week)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ChipActivation), args.Error(1)
+}
+
+// GetByWeek mocks the GetByWeek method
+func (m *MockChipRepository) GetByWeek(week int) ([]models.ChipActivation, error) {
+	args := m.Called(week)
+	return args.Get(0).([]models.ChipActivation), args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockChipRepository) Create(activation *models.ChipActivation) error {
+	args := m.Called(activation)
+	return args.Error(0)
+}
+
+// DeleteAll mocks the DeleteAll method
+func (m *MockChipRepository) DeleteAll() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// Ensure MockChipRepository implements repository.ChipRepository
+var _ repository.ChipRepository = (*MockChipRepository)(nil)
@@ -0,0 +1,67 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockFantasyPointsRepository is a mock implementation of repository.FantasyPointsRepository
+type MockFantasyPointsRepository struct {
+	mock.Mock
+}
+
+// ReplaceMatchPerformances mocks the ReplaceMatchPerformances method
+func (m *MockFantasyPointsRepository) ReplaceMatchPerformances(matchID int, records []models.PlayerGameweek) error {
+	args := m.Called(matchID, records)
+	return args.Error(0)
+}
+
+// GetPlayerGameweeks mocks the GetPlayerGameweeks method
+func (m *MockFantasyPointsRepository) GetPlayerGameweeks(week int) ([]models.PlayerGameweek, error) {
+	args := m.Called(week)
+	return args.Get(0).([]models.PlayerGameweek), args.Error(1)
+}
+
+// GetPicks mocks the GetPicks method
+func (m *MockFantasyPointsRepository) GetPicks(managerID, week int) ([]models.FantasyPick, error) {
+	args := m.Called(managerID, week)
+	return args.Get(0).([]models.FantasyPick), args.Error(1)
+}
+
+// SavePicks mocks the SavePicks method
+func (m *MockFantasyPointsRepository) SavePicks(picks []models.FantasyPick) error {
+	args := m.Called(picks)
+	return args.Error(0)
+}
+
+// SaveManagerGameweek mocks the SaveManagerGameweek method
+func (m *MockFantasyPointsRepository) SaveManagerGameweek(gameweek *models.ManagerGameweek) error {
+	args := m.Called(gameweek)
+	return args.Error(0)
+}
+
+// GetManagerGameweeks mocks the GetManagerGameweeks method
+func (m *MockFantasyPointsRepository) GetManagerGameweeks(managerID int) ([]models.ManagerGameweek, error) {
+	args := m.Called(managerID)
+	return args.Get(0).([]models.ManagerGameweek), args.Error(1)
+}
+
+// GetManagerGameweek mocks the GetManagerGameweek method
+func (m *MockFantasyPointsRepository) GetManagerGameweek(managerID, week int) (*models.ManagerGameweek, error) {
+	args := m.Called(managerID, week)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ManagerGameweek), args.Error(1)
+}
+
+// DeleteAll mocks the DeleteAll method
+func (m *MockFantasyPointsRepository) DeleteAll() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// Ensure MockFantasyPointsRepository implements repository.FantasyPointsRepository
+var _ repository.FantasyPointsRepository = (*MockFantasyPointsRepository)(nil)
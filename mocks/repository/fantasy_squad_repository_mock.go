@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockFantasySquadRepository is a mock implementation of repository.FantasySquadRepository
+type MockFantasySquadRepository struct {
+	mock.Mock
+}
+
+// GetAll mocks the GetAll method
+func (m *MockFantasySquadRepository) GetAll() ([]models.FantasySquad, error) {
+	args := m.Called()
+	return args.Get(0).([]models.FantasySquad), args.Error(1)
+}
+
+// GetByManager mocks the GetByManager method
+func (m *MockFantasySquadRepository) GetByManager(managerID int) (*models.FantasySquad, error) {
+	args := m.Called(managerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FantasySquad), args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockFantasySquadRepository) Create(squad *models.FantasySquad, manager *models.Manager) error {
+	args := m.Called(squad, manager)
+	return args.Error(0)
+}
+
+// ReplacePlayers mocks the ReplacePlayers method
+func (m *MockFantasySquadRepository) ReplacePlayers(squad *models.FantasySquad) error {
+	args := m.Called(squad)
+	return args.Error(0)
+}
+
+// Ensure MockFantasySquadRepository implements repository.FantasySquadRepository
+var _ repository.FantasySquadRepository = (*MockFantasySquadRepository)(nil)
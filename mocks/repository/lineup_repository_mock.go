@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLineupRepository is a mock implementation of repository.LineupRepository
+type MockLineupRepository struct {
+	mock.Mock
+}
+
+// GetLatest mocks the GetLatest method
+func (m *MockLineupRepository) GetLatest(managerID, week int) (*models.Lineup, error) {
+	args := m.Called(managerID, week)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Lineup), args.Error(1)
+}
+
+// Save mocks the Save method
+func (m *MockLineupRepository) Save(lineup *models.Lineup) error {
+	args := m.Called(lineup)
+	return args.Error(0)
+}
+
+// Delete mocks the Delete method
+func (m *MockLineupRepository) Delete(managerID, week int) error {
+	args := m.Called(managerID, week)
+	return args.Error(0)
+}
+
+// DeleteAll mocks the DeleteAll method
+func (m *MockLineupRepository) DeleteAll() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// Ensure MockLineupRepository implements repository.LineupRepository
+var _ repository.LineupRepository = (*MockLineupRepository)(nil)
@@ -0,0 +1,73 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockManagerRepository is a mock implementation of repository.ManagerRepository
+type MockManagerRepository struct {
+	mock.Mock
+}
+
+// GetAll mocks the GetAll method
+func (m *MockManagerRepository) GetAll() ([]models.Manager, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Manager), args.Error(1)
+}
+
+// GetByID mocks the GetByID method
+func (m *MockManagerRepository) GetByID(id int) (*models.Manager, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Manager), args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockManagerRepository) Create(manager *models.Manager) error {
+	args := m.Called(manager)
+	return args.Error(0)
+}
+
+// Update mocks the Update method
+func (m *MockManagerRepository) Update(manager *models.Manager) error {
+	args := m.Called(manager)
+	return args.Error(0)
+}
+
+// UpdateBank mocks the UpdateBank method
+func (m *MockManagerRepository) UpdateBank(id uint, bank float64) error {
+	args := m.Called(id, bank)
+	return args.Error(0)
+}
+
+// UpdateTotalPoints mocks the UpdateTotalPoints method
+func (m *MockManagerRepository) UpdateTotalPoints(id uint, totalPoints int) error {
+	args := m.Called(id, totalPoints)
+	return args.Error(0)
+}
+
+// AddFreeTransfers mocks the AddFreeTransfers method
+func (m *MockManagerRepository) AddFreeTransfers(amount, max int) error {
+	args := m.Called(amount, max)
+	return args.Error(0)
+}
+
+// ResetFreeTransfers mocks the ResetFreeTransfers method
+func (m *MockManagerRepository) ResetFreeTransfers(freeTransfers int) error {
+	args := m.Called(freeTransfers)
+	return args.Error(0)
+}
+
+// Delete mocks the Delete method
+func (m *MockManagerRepository) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// Ensure MockManagerRepository implements repository.ManagerRepository
+var _ repository.ManagerRepository = (*MockManagerRepository)(nil)
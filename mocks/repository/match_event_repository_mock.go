@@ -0,0 +1,58 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMatchEventRepository is a mock implementation of repository.MatchEventRepository
+type MockMatchEventRepository struct {
+	mock.Mock
+}
+
+// GetByMatch mocks the GetByMatch method
+func (m *MockMatchEventRepository) GetByMatch(matchID int) ([]models.MatchEvent, error) {
+	args := m.Called(matchID)
+	return args.Get(0).([]models.MatchEvent), args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockMatchEventRepository) Create(events []models.MatchEvent) error {
+	args := m.Called(events)
+	return args.Error(0)
+}
+
+// ReplaceByMatch mocks the ReplaceByMatch method
+func (m *MockMatchEventRepository) ReplaceByMatch(matchID int, events []models.MatchEvent) error {
+	args := m.Called(matchID, events)
+	return args.Error(0)
+}
+
+// DeleteByMatch mocks the DeleteByMatch method
+func (m *MockMatchEventRepository) DeleteByMatch(matchID int) error {
+	args := m.Called(matchID)
+	return args.Error(0)
+}
+
+// DeleteAll mocks the DeleteAll method
+func (m *MockMatchEventRepository) DeleteAll() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// GetTopScorers mocks the GetTopScorers method
+func (m *MockMatchEventRepository) GetTopScorers(limit int) ([]models.PlayerLeader, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]models.PlayerLeader), args.Error(1)
+}
+
+// GetTopAssisters mocks the GetTopAssisters method
+func (m *MockMatchEventRepository) GetTopAssisters(limit int) ([]models.PlayerLeader, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]models.PlayerLeader), args.Error(1)
+}
+
+// Ensure MockMatchEventRepository implements repository.MatchEventRepository
+var _ repository.MatchEventRepository = (*MockMatchEventRepository)(nil)
@@ -57,5 +57,11 @@ func (m *MockMatchRepository) GetUnplayedWeeks() ([]int, error) {
 	return args.Get(0).([]int), args.Error(1)
 }
 
+// GetPlayedBetween mocks the GetPlayedBetween method
+func (m *MockMatchRepository) GetPlayedBetween(teamID, otherTeamID int) ([]models.Match, error) {
+	args := m.Called(teamID, otherTeamID)
+	return args.Get(0).([]models.Match), args.Error(1)
+}
+
 // Ensure MockMatchRepository implements repository.MatchRepository
 var _ repository.MatchRepository = (*MockMatchRepository)(nil)
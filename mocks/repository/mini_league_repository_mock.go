@@ -0,0 +1,82 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMiniLeagueRepository is a mock implementation of repository.MiniLeagueRepository
+type MockMiniLeagueRepository struct {
+	mock.Mock
+}
+
+// GetByID mocks the GetByID method
+func (m *MockMiniLeagueRepository) GetByID(id int) (*models.MiniLeague, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MiniLeague), args.Error(1)
+}
+
+// GetByCode mocks the GetByCode method
+func (m *MockMiniLeagueRepository) GetByCode(code string) (*models.MiniLeague, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MiniLeague), args.Error(1)
+}
+
+// GetByManager mocks the GetByManager method
+func (m *MockMiniLeagueRepository) GetByManager(managerID int) ([]models.MiniLeague, error) {
+	args := m.Called(managerID)
+	return args.Get(0).([]models.MiniLeague), args.Error(1)
+}
+
+// GetByType mocks the GetByType method
+func (m *MockMiniLeagueRepository) GetByType(leagueType string) ([]models.MiniLeague, error) {
+	args := m.Called(leagueType)
+	return args.Get(0).([]models.MiniLeague), args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockMiniLeagueRepository) Create(league *models.MiniLeague) error {
+	args := m.Called(league)
+	return args.Error(0)
+}
+
+// AddMember mocks the AddMember method
+func (m *MockMiniLeagueRepository) AddMember(member *models.MiniLeagueMember) error {
+	args := m.Called(member)
+	return args.Error(0)
+}
+
+// GetFixtures mocks the GetFixtures method
+func (m *MockMiniLeagueRepository) GetFixtures(leagueID int) ([]models.HeadToHeadFixture, error) {
+	args := m.Called(leagueID)
+	return args.Get(0).([]models.HeadToHeadFixture), args.Error(1)
+}
+
+// CreateFixtures mocks the CreateFixtures method
+func (m *MockMiniLeagueRepository) CreateFixtures(fixtures []models.HeadToHeadFixture) error {
+	args := m.Called(fixtures)
+	return args.Error(0)
+}
+
+// SaveFixtures mocks the SaveFixtures method
+func (m *MockMiniLeagueRepository) SaveFixtures(fixtures []models.HeadToHeadFixture) error {
+	args := m.Called(fixtures)
+	return args.Error(0)
+}
+
+// ResetSeason mocks the ResetSeason method
+func (m *MockMiniLeagueRepository) ResetSeason() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// Ensure MockMiniLeagueRepository implements repository.MiniLeagueRepository
+var _ repository.MiniLeagueRepository = (*MockMiniLeagueRepository)(nil)
@@ -0,0 +1,61 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPlayerRepository is a mock implementation of repository.PlayerRepository
+type MockPlayerRepository struct {
+	mock.Mock
+}
+
+// GetAll mocks the GetAll method
+func (m *MockPlayerRepository) GetAll() ([]models.Player, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Player), args.Error(1)
+}
+
+// GetByID mocks the GetByID method
+func (m *MockPlayerRepository) GetByID(id int) (*models.Player, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Player), args.Error(1)
+}
+
+// GetByTeam mocks the GetByTeam method
+func (m *MockPlayerRepository) GetByTeam(teamID int) ([]models.Player, error) {
+	args := m.Called(teamID)
+	return args.Get(0).([]models.Player), args.Error(1)
+}
+
+// GetByIDs mocks the GetByIDs method
+func (m *MockPlayerRepository) GetByIDs(ids []uint) ([]models.Player, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]models.Player), args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockPlayerRepository) Create(player *models.Player) error {
+	args := m.Called(player)
+	return args.Error(0)
+}
+
+// Update mocks the Update method
+func (m *MockPlayerRepository) Update(player *models.Player) error {
+	args := m.Called(player)
+	return args.Error(0)
+}
+
+// Delete mocks the Delete method
+func (m *MockPlayerRepository) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// Ensure MockPlayerRepository implements repository.PlayerRepository
+var _ repository.PlayerRepository = (*MockPlayerRepository)(nil)
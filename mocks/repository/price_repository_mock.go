@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPriceRepository is a mock implementation of repository.PriceRepository
+type MockPriceRepository struct {
+	mock.Mock
+}
+
+// GetAll mocks the GetAll method
+func (m *MockPriceRepository) GetAll() ([]models.PriceChange, error) {
+	args := m.Called()
+	return args.Get(0).([]models.PriceChange), args.Error(1)
+}
+
+// GetByPlayer mocks the GetByPlayer method
+func (m *MockPriceRepository) GetByPlayer(playerID int) ([]models.PriceChange, error) {
+	args := m.Called(playerID)
+	return args.Get(0).([]models.PriceChange), args.Error(1)
+}
+
+// GetByWeek mocks the GetByWeek method
+func (m *MockPriceRepository) GetByWeek(week int) ([]models.PriceChange, error) {
+	args := m.Called(week)
+	return args.Get(0).([]models.PriceChange), args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockPriceRepository) Create(changes []models.PriceChange) error {
+	args := m.Called(changes)
+	return args.Error(0)
+}
+
+// DeleteAll mocks the DeleteAll method
+func (m *MockPriceRepository) DeleteAll() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// Ensure MockPriceRepository implements repository.PriceRepository
+var _ repository.PriceRepository = (*MockPriceRepository)(nil)
@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRatingRepository is a mock implementation of repository.RatingRepository
+type MockRatingRepository struct {
+	mock.Mock
+}
+
+// GetByTeam mocks the GetByTeam method
+func (m *MockRatingRepository) GetByTeam(teamID int) ([]models.TeamRating, error) {
+	args := m.Called(teamID)
+	return args.Get(0).([]models.TeamRating), args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockRatingRepository) Create(ratings []models.TeamRating) error {
+	args := m.Called(ratings)
+	return args.Error(0)
+}
+
+// DeleteAll mocks the DeleteAll method
+func (m *MockRatingRepository) DeleteAll() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// Ensure MockRatingRepository implements repository.RatingRepository
+var _ repository.RatingRepository = (*MockRatingRepository)(nil)
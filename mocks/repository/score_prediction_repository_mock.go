@@ -0,0 +1,67 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockScorePredictionRepository is a mock implementation of repository.ScorePredictionRepository
+type MockScorePredictionRepository struct {
+	mock.Mock
+}
+
+// GetAll mocks the GetAll method
+func (m *MockScorePredictionRepository) GetAll() ([]models.ScorePrediction, error) {
+	args := m.Called()
+	return args.Get(0).([]models.ScorePrediction), args.Error(1)
+}
+
+// GetByUser mocks the GetByUser method
+func (m *MockScorePredictionRepository) GetByUser(userID int) ([]models.ScorePrediction, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]models.ScorePrediction), args.Error(1)
+}
+
+// GetByWeek mocks the GetByWeek method
+func (m *MockScorePredictionRepository) GetByWeek(week int) ([]models.ScorePrediction, error) {
+	args := m.Called(week)
+	return args.Get(0).([]models.ScorePrediction), args.Error(1)
+}
+
+// GetByMatch mocks the GetByMatch method
+func (m *MockScorePredictionRepository) GetByMatch(matchID int) ([]models.ScorePrediction, error) {
+	args := m.Called(matchID)
+	return args.Get(0).([]models.ScorePrediction), args.Error(1)
+}
+
+// GetByUserAndMatch mocks the GetByUserAndMatch method
+func (m *MockScorePredictionRepository) GetByUserAndMatch(userID, matchID int) (*models.ScorePrediction, error) {
+	args := m.Called(userID, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ScorePrediction), args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockScorePredictionRepository) Create(prediction *models.ScorePrediction) error {
+	args := m.Called(prediction)
+	return args.Error(0)
+}
+
+// Update mocks the Update method
+func (m *MockScorePredictionRepository) Update(prediction *models.ScorePrediction) error {
+	args := m.Called(prediction)
+	return args.Error(0)
+}
+
+// DeleteAll mocks the DeleteAll method
+func (m *MockScorePredictionRepository) DeleteAll() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// Ensure MockScorePredictionRepository implements repository.ScorePredictionRepository
+var _ repository.ScorePredictionRepository = (*MockScorePredictionRepository)(nil)
@@ -39,6 +39,12 @@ func (m *MockTeamRepository) Update(team *models.Team) error {
 	return args.Error(0)
 }
 
+// UpdateRatings mocks the UpdateRatings method
+func (m *MockTeamRepository) UpdateRatings(team *models.Team) error {
+	args := m.Called(team)
+	return args.Error(0)
+}
+
 // Delete mocks the Delete method
 func (m *MockTeamRepository) Delete(id int) error {
 	args := m.Called(id)
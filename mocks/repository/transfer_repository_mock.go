@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTransferRepository is a mock implementation of repository.TransferRepository
+type MockTransferRepository struct {
+	mock.Mock
+}
+
+// GetByManager mocks the GetByManager method
+func (m *MockTransferRepository) GetByManager(managerID int) ([]models.Transfer, error) {
+	args := m.Called(managerID)
+	return args.Get(0).([]models.Transfer), args.Error(1)
+}
+
+// GetCost mocks the GetCost method
+func (m *MockTransferRepository) GetCost(managerID, week int) (int, error) {
+	args := m.Called(managerID, week)
+	return args.Int(0), args.Error(1)
+}
+
+// GetByWeek mocks the GetByWeek method
+func (m *MockTransferRepository) GetByWeek(week int) ([]models.Transfer, error) {
+	args := m.Called(week)
+	return args.Get(0).([]models.Transfer), args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockTransferRepository) Create(squad *models.FantasySquad, transfers []models.Transfer, bank float64, freeTransfersUsed int) error {
+	args := m.Called(squad, transfers, bank, freeTransfersUsed)
+	return args.Error(0)
+}
+
+// DeleteAll mocks the DeleteAll method
+func (m *MockTransferRepository) DeleteAll() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// Ensure MockTransferRepository implements repository.TransferRepository
+var _ repository.TransferRepository = (*MockTransferRepository)(nil)
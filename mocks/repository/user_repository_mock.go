@@ -0,0 +1,64 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUserRepository is a mock implementation of repository.UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+// GetAll mocks the GetAll method
+func (m *MockUserRepository) GetAll() ([]models.User, error) {
+	args := m.Called()
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
+// GetByID mocks the GetByID method
+func (m *MockUserRepository) GetByID(id int) (*models.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+// GetByEmail mocks the GetByEmail method
+func (m *MockUserRepository) GetByEmail(email string) (*models.User, error) {
+	args := m.Called(email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockUserRepository) Create(user *models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+// Update mocks the Update method
+func (m *MockUserRepository) Update(user *models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+// CreateRefreshToken mocks the CreateRefreshToken method
+func (m *MockUserRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+// RevokeRefreshToken mocks the RevokeRefreshToken method
+func (m *MockUserRepository) RevokeRefreshToken(tokenID string) error {
+	args := m.Called(tokenID)
+	return args.Error(0)
+}
+
+// Ensure MockUserRepository implements repository.UserRepository
+var _ repository.UserRepository = (*MockUserRepository)(nil)
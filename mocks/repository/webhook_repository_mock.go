@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"insider-league/models"
+	"insider-league/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWebhookRepository is a mock implementation of repository.WebhookRepository
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+// GetAll mocks the GetAll method
+func (m *MockWebhookRepository) GetAll() ([]models.WebhookSubscription, error) {
+	args := m.Called()
+	return args.Get(0).([]models.WebhookSubscription), args.Error(1)
+}
+
+// GetByID mocks the GetByID method
+func (m *MockWebhookRepository) GetByID(id int) (*models.WebhookSubscription, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WebhookSubscription), args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockWebhookRepository) Create(subscription *models.WebhookSubscription) error {
+	args := m.Called(subscription)
+	return args.Error(0)
+}
+
+// Delete mocks the Delete method
+func (m *MockWebhookRepository) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// CreateDelivery mocks the CreateDelivery method
+func (m *MockWebhookRepository) CreateDelivery(delivery *models.WebhookDelivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+// GetDeliveries mocks the GetDeliveries method
+func (m *MockWebhookRepository) GetDeliveries(subscriptionID int) ([]models.WebhookDelivery, error) {
+	args := m.Called(subscriptionID)
+	return args.Get(0).([]models.WebhookDelivery), args.Error(1)
+}
+
+// Ensure MockWebhookRepository implements repository.WebhookRepository
+var _ repository.WebhookRepository = (*MockWebhookRepository)(nil)
@@ -0,0 +1,66 @@
+package mocks
+
+import (
+	"insider-league/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMatchEventService is a mock implementation of MatchEventService interface
+type MockMatchEventService struct {
+	mock.Mock
+}
+
+// GetByMatch mocks the GetByMatch method
+func (m *MockMatchEventService) GetByMatch(matchID int) ([]models.MatchEvent, error) {
+	args := m.Called(matchID)
+	return args.Get(0).([]models.MatchEvent), args.Error(1)
+}
+
+// SimulateForMatch mocks the SimulateForMatch method
+func (m *MockMatchEventService) SimulateForMatch(match *models.Match) ([]models.MatchEvent, error) {
+	args := m.Called(match)
+	return args.Get(0).([]models.MatchEvent), args.Error(1)
+}
+
+// GenerateForMatch mocks the GenerateForMatch method
+func (m *MockMatchEventService) GenerateForMatch(match *models.Match) ([]models.MatchEvent, error) {
+	args := m.Called(match)
+	return args.Get(0).([]models.MatchEvent), args.Error(1)
+}
+
+// GenerateGoalsForMatch mocks the GenerateGoalsForMatch method
+func (m *MockMatchEventService) GenerateGoalsForMatch(match *models.Match) ([]models.MatchEvent, error) {
+	args := m.Called(match)
+	return args.Get(0).([]models.MatchEvent), args.Error(1)
+}
+
+// SaveForMatch mocks the SaveForMatch method
+func (m *MockMatchEventService) SaveForMatch(matchID int, events []models.MatchEvent) error {
+	args := m.Called(matchID, events)
+	return args.Error(0)
+}
+
+// DeleteByMatch mocks the DeleteByMatch method
+func (m *MockMatchEventService) DeleteByMatch(matchID int) error {
+	args := m.Called(matchID)
+	return args.Error(0)
+}
+
+// DeleteAll mocks the DeleteAll method
+func (m *MockMatchEventService) DeleteAll() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// GetTopScorers mocks the GetTopScorers method
+func (m *MockMatchEventService) GetTopScorers(limit int) ([]models.PlayerLeader, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]models.PlayerLeader), args.Error(1)
+}
+
+// GetTopAssisters mocks the GetTopAssisters method
+func (m *MockMatchEventService) GetTopAssisters(limit int) ([]models.PlayerLeader, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]models.PlayerLeader), args.Error(1)
+}
@@ -55,3 +55,12 @@ func (m *MockMatchService) GetUnplayedWeeks() ([]int, error) {
 	args := m.Called()
 	return args.Get(0).([]int), args.Error(1)
 }
+
+// GetHeadToHead mocks the GetHeadToHead method
+func (m *MockMatchService) GetHeadToHead(teamID, otherTeamID int) (*models.HeadToHead, error) {
+	args := m.Called(teamID, otherTeamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.HeadToHead), args.Error(1)
+}
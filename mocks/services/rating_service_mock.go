@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"insider-league/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRatingService is a mock implementation of RatingService interface
+type MockRatingService struct {
+	mock.Mock
+}
+
+// GetHistory mocks the GetHistory method
+func (m *MockRatingService) GetHistory(teamID int) ([]models.TeamRating, error) {
+	args := m.Called(teamID)
+	return args.Get(0).([]models.TeamRating), args.Error(1)
+}
+
+// GetPowerRankings mocks the GetPowerRankings method
+func (m *MockRatingService) GetPowerRankings() ([]models.PowerRanking, error) {
+	args := m.Called()
+	return args.Get(0).([]models.PowerRanking), args.Error(1)
+}
+
+// ApplyMatchResult mocks the ApplyMatchResult method
+func (m *MockRatingService) ApplyMatchResult(match *models.Match, homeTeam, awayTeam *models.Team) error {
+	args := m.Called(match, homeTeam, awayTeam)
+	return args.Error(0)
+}
+
+// Rebuild mocks the Rebuild method
+func (m *MockRatingService) Rebuild() error {
+	args := m.Called()
+	return args.Error(0)
+}
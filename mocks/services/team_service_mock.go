@@ -50,6 +50,18 @@ func (m *MockTeamService) GetLeagueTable() ([]models.Team, error) {
 	return args.Get(0).([]models.Team), args.Error(1)
 }
 
+// Save mocks the Save method
+func (m *MockTeamService) Save(team *models.Team) error {
+	args := m.Called(team)
+	return args.Error(0)
+}
+
+// SaveRatings mocks the SaveRatings method
+func (m *MockTeamService) SaveRatings(team *models.Team) error {
+	args := m.Called(team)
+	return args.Error(0)
+}
+
 // UpdateTeamStats mocks the UpdateTeamStats method
 func (m *MockTeamService) UpdateTeamStats(homeTeam, awayTeam *models.Team, homeGoals, awayGoals int, revert bool) error {
 	args := m.Called(homeTeam, awayTeam, homeGoals, awayGoals, revert)
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// API key scopes. A key can never do more than its owner's role allows; the scope narrows it further.
+const (
+	ScopeReadOnly = "read-only"
+	ScopeSimulate = "simulate"
+	ScopeAdmin    = "admin"
+)
+
+// Scopes lists every valid API key scope
+var Scopes = []string{ScopeReadOnly, ScopeSimulate, ScopeAdmin}
+
+// ScopePermissions lists the permissions each API key scope can use. Read-only keys can only read.
+var ScopePermissions = map[string][]string{
+	ScopeReadOnly: {},
+	ScopeSimulate: {PermissionPlayWeeks},
+	ScopeAdmin:    RolePermissions[RoleAdmin],
+}
+
+// APIKey represents a long-lived key a machine client sends in the X-API-Key header instead of signing in.
+// Only a SHA-256 hash of the key is stored; the key itself is shown once, when it is issued.
+type APIKey struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	UserID     uint       `json:"userId" gorm:"index"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-" gorm:"uniqueIndex"`
+	Scope      string     `json:"scope"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// APIKeyRequest represents the body accepted when issuing an API key
+type APIKeyRequest struct {
+	Name  string `json:"name"`
+	Scope string `json:"scope"`
+}
+
+// IssuedAPIKey is returned once when a key is issued and is the only time the full key is shown
+type IssuedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
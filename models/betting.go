@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// Bet statuses
+const (
+	BetPending = "pending"
+	BetWon     = "won"
+	BetLost    = "lost"
+	BetVoid    = "void"
+)
+
+// Wallet transaction types
+const (
+	TransactionGrant          = "grant"
+	TransactionStake          = "stake"
+	TransactionPayout         = "payout"
+	TransactionPayoutReversal = "payout_reversal"
+	TransactionRefund         = "refund"
+)
+
+// Wallet represents a user's balance of virtual currency for betting
+type Wallet struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"userId" gorm:"uniqueIndex"`
+	Balance   float64   `json:"balance"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Bet represents a virtual bet on a selection of an unplayed match, struck at the decimal odds of the time
+type Bet struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	UserID          uint       `json:"userId" gorm:"index"`
+	MatchID         uint       `json:"matchId" gorm:"index"`
+	Match           *Match     `json:"match,omitempty" gorm:"foreignKey:MatchID"`
+	Market          string     `json:"market"`
+	Selection       string     `json:"selection"`
+	Stake           float64    `json:"stake"`
+	Odds            float64    `json:"odds"`
+	PotentialReturn float64    `json:"potentialReturn"`
+	Status          string     `json:"status"`
+	Payout          float64    `json:"payout"`
+	SettledAt       *time.Time `json:"settledAt"`
+
+	// Final marks a bet that can no longer change, because the results it was settled on were reset
+	Final     bool      `json:"final"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// WalletTransaction represents an entry in a user's ledger. Amount is signed and Balance is the balance after it.
+type WalletTransaction struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	UserID      uint      `json:"userId" gorm:"index"`
+	BetID       *uint     `json:"betId,omitempty" gorm:"index"`
+	Type        string    `json:"type"`
+	Amount      float64   `json:"amount"`
+	Balance     float64   `json:"balance"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// BetRequest represents the body accepted when placing a bet. Odds are the decimal odds the bettor saw;
+// the bet is refused if the price has moved since, and left out to take the current price.
+type BetRequest struct {
+	MatchID   uint    `json:"matchId"`
+	Market    string  `json:"market"`
+	Selection string  `json:"selection"`
+	Stake     float64 `json:"stake"`
+	Odds      float64 `json:"odds"`
+}
@@ -0,0 +1,46 @@
+package models
+
+// MatchResult represents a single final score used as calibration input
+type MatchResult struct {
+	HomeTeamID uint `json:"homeTeamId"`
+	AwayTeamID uint `json:"awayTeamId"`
+	HomeGoals  int  `json:"homeGoals"`
+	AwayGoals  int  `json:"awayGoals"`
+}
+
+// FittedTeam represents the strength parameters fitted for a team
+type FittedTeam struct {
+	TeamID           uint    `json:"teamId"`
+	TeamName         string  `json:"teamName"`
+	Attack           float64 `json:"attack"`
+	Defence          float64 `json:"defence"`
+	AttackRating     int     `json:"attackRating"`
+	DefenceRating    int     `json:"defenceRating"`
+	Strength         int     `json:"strength"`
+	PreviousStrength int     `json:"previousStrength"`
+	GoalsFor         int     `json:"goals_for"`
+	GoalsAgainst     int     `json:"goals_against"`
+	ExpectedFor      float64 `json:"expected_for"`
+	ExpectedAgainst  float64 `json:"expected_against"`
+}
+
+// CalibrationDiagnostics describes how well the fitted model reproduces the results
+type CalibrationDiagnostics struct {
+	Matches           int     `json:"matches"`
+	Iterations        int     `json:"iterations"`
+	Converged         bool    `json:"converged"`
+	LogLikelihood     float64 `json:"logLikelihood"`
+	NullLogLikelihood float64 `json:"nullLogLikelihood"`
+	PseudoRSquared    float64 `json:"pseudoRSquared"`
+	Deviance          float64 `json:"deviance"`
+	MeanAbsoluteError float64 `json:"meanAbsoluteError"`
+}
+
+// CalibrationResult represents the outcome of fitting team strengths to results
+type CalibrationResult struct {
+	BaseRate      float64                `json:"baseRate"`
+	HomeAdvantage float64                `json:"homeAdvantage"`
+	Teams         []FittedTeam           `json:"teams"`
+	Diagnostics   CalibrationDiagnostics `json:"diagnostics"`
+	Applied       bool                   `json:"applied"`
+}
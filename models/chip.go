@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Fantasy chips a manager can play once per season, or once per half-season for the wildcard
+const (
+	ChipWildcard      = "wildcard"
+	ChipBenchBoost    = "bench_boost"
+	ChipTripleCaptain = "triple_captain"
+	ChipFreeHit       = "free_hit"
+)
+
+// Chips lists every fantasy chip
+var Chips = []string{ChipWildcard, ChipBenchBoost, ChipTripleCaptain, ChipFreeHit}
+
+// ChipActivation represents a chip a manager played for a gameweek
+type ChipActivation struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	ManagerID uint   `json:"managerId" gorm:"uniqueIndex:idx_chip_activations_manager_week"`
+	Week      int    `json:"week" gorm:"uniqueIndex:idx_chip_activations_manager_week"`
+	Chip      string `json:"chip"`
+
+	// SavedPlayers and SavedBank hold the squad a free hit reverts to once its week is played
+	SavedPlayers []FantasySquadPlayer `json:"-" gorm:"serializer:json"`
+	SavedBank    float64              `json:"-"`
+	CreatedAt    time.Time            `json:"createdAt"`
+}
+
+// ChipStatus represents whether a manager can still play a chip and the weeks it was played
+type ChipStatus struct {
+	Chip      string `json:"chip"`
+	Available bool   `json:"available"`
+	UsedWeeks []int  `json:"usedWeeks"`
+}
+
+// ChipInventory represents a manager's chips for the season and the one active in the open gameweek
+type ChipInventory struct {
+	Week   int          `json:"week"`
+	Active string       `json:"active,omitempty"`
+	Chips  []ChipStatus `json:"chips"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Manager represents a fantasy player who picks a squad of real players
+type Manager struct {
+	ID       uint   `json:"id" gorm:"primaryKey"`
+	Name     string `json:"name"`
+	TeamName string `json:"teamName"`
+
+	// Bank is the unspent budget in millions
+	Bank        float64 `json:"bank"`
+	TotalPoints int     `json:"totalPoints"`
+
+	// FreeTransfers is how many transfers the manager can still make this gameweek without a points hit
+	FreeTransfers int `json:"freeTransfers"`
+
+	// UserID is the account that registered the manager
+	UserID    *uint     `json:"userId,omitempty" gorm:"index"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// FantasySquad represents the players a manager currently owns
+type FantasySquad struct {
+	ID        uint                 `json:"id" gorm:"primaryKey"`
+	ManagerID uint                 `json:"managerId" gorm:"uniqueIndex"`
+	Players   []FantasySquadPlayer `json:"players" gorm:"foreignKey:SquadID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time            `json:"createdAt"`
+	UpdatedAt time.Time            `json:"updatedAt"`
+}
+
+// FantasySquadPlayer represents one player in a fantasy squad and what the manager paid for him
+type FantasySquadPlayer struct {
+	ID            uint    `json:"id" gorm:"primaryKey"`
+	SquadID       uint    `json:"squadId" gorm:"index"`
+	PlayerID      uint    `json:"playerId" gorm:"index"`
+	Player        Player  `json:"player" gorm:"foreignKey:PlayerID"`
+	PurchasePrice float64 `json:"purchasePrice"`
+
+	// SellingPrice is what the player would raise if sold now; it is worked out from the prices, not stored
+	SellingPrice float64 `json:"sellingPrice" gorm:"-"`
+}
+
+// SquadValidation represents the outcome of checking a squad against the fantasy rules
+type SquadValidation struct {
+	Valid     bool     `json:"valid"`
+	Errors    []string `json:"errors"`
+	Cost      float64  `json:"cost"`
+	Budget    float64  `json:"budget"`
+	Remaining float64  `json:"remaining"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// PlayerGameweek represents what a player did in one match and the fantasy points it earned
+type PlayerGameweek struct {
+	ID            uint   `json:"id" gorm:"primaryKey"`
+	Week          int    `json:"week" gorm:"index"`
+	MatchID       uint   `json:"matchId" gorm:"index"`
+	PlayerID      uint   `json:"playerId" gorm:"index"`
+	TeamID        uint   `json:"teamId"`
+	Position      string `json:"position"`
+	Minutes       int    `json:"minutes"`
+	Goals         int    `json:"goals"`
+	Assists       int    `json:"assists"`
+	CleanSheet    bool   `json:"cleanSheet"`
+	GoalsConceded int    `json:"goalsConceded"`
+	Saves         int    `json:"saves"`
+	YellowCards   int    `json:"yellowCards"`
+	RedCards      int    `json:"redCards"`
+	Points        int    `json:"points"`
+}
+
+// FantasyPick represents a player in a manager's squad for a gameweek, frozen with the lineup when the week is scored
+type FantasyPick struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	ManagerID uint   `json:"managerId" gorm:"index:idx_fantasy_picks_manager_week"`
+	Week      int    `json:"week" gorm:"index:idx_fantasy_picks_manager_week"`
+	PlayerID  uint   `json:"playerId"`
+	Player    Player `json:"player" gorm:"foreignKey:PlayerID"`
+
+	// Slot is 1 to 11 for starters and 12 onwards for the bench in substitution order
+	Slot          int  `json:"slot"`
+	IsCaptain     bool `json:"isCaptain"`
+	IsViceCaptain bool `json:"isViceCaptain"`
+
+	// Points is what the player scored; Multiplier is how many times it counts for the manager,
+	// 0 on the bench, 1 in the team and more for the captain. AutoSubbed marks both sides of an automatic substitution.
+	Points     int  `json:"points"`
+	Multiplier int  `json:"multiplier"`
+	AutoSubbed bool `json:"autoSubbed"`
+}
+
+// ManagerGameweek represents a manager's fantasy score for one gameweek
+type ManagerGameweek struct {
+	ID        uint `json:"id" gorm:"primaryKey"`
+	ManagerID uint `json:"managerId" gorm:"uniqueIndex:idx_manager_gameweeks_manager_week"`
+	Week      int  `json:"week" gorm:"uniqueIndex:idx_manager_gameweeks_manager_week"`
+	Points    int  `json:"points"`
+
+	// TransferCost is the points hit for extra transfers, already taken off Points
+	TransferCost int `json:"transferCost"`
+
+	// Chip is the chip played for the week, if any
+	Chip      string        `json:"chip,omitempty"`
+	Picks     []FantasyPick `json:"picks,omitempty" gorm:"-"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// HeadToHead represents the record between two teams from the first team's perspective
+type HeadToHead struct {
+	TeamID       uint    `json:"teamId"`
+	OtherTeamID  uint    `json:"otherTeamId"`
+	Played       int     `json:"played"`
+	Wins         int     `json:"wins"`
+	Draws        int     `json:"draws"`
+	Losses       int     `json:"losses"`
+	GoalsFor     int     `json:"goalsFor"`
+	GoalsAgainst int     `json:"goalsAgainst"`
+	BiggestWin   *Match  `json:"biggestWin"`
+	BiggestLoss  *Match  `json:"biggestLoss"`
+	HighestScore *Match  `json:"highestScore"`
+	Meetings     []Match `json:"meetings"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Lineup represents a manager's team selection for a gameweek. It carries over to later weeks until changed.
+type Lineup struct {
+	ID        uint `json:"id" gorm:"primaryKey"`
+	ManagerID uint `json:"managerId" gorm:"uniqueIndex:idx_lineups_manager_week"`
+	Week      int  `json:"week" gorm:"uniqueIndex:idx_lineups_manager_week"`
+
+	// Starters are the players who score; Bench is in the order substitutes come on
+	Starters      []uint    `json:"starters" gorm:"serializer:json"`
+	Bench         []uint    `json:"bench" gorm:"serializer:json"`
+	CaptainID     uint      `json:"captainId"`
+	ViceCaptainID uint      `json:"viceCaptainId"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
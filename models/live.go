@@ -0,0 +1,19 @@
+package models
+
+// Live update types streamed during live match playback
+const (
+	LiveKickoff  = "kickoff"
+	LiveEvent    = "event"
+	LiveHalfTime = "half_time"
+	LiveFullTime = "full_time"
+)
+
+// LiveUpdate represents a single message streamed while a week is played live
+type LiveUpdate struct {
+	Type        string       `json:"type"`
+	Minute      int          `json:"minute"`
+	Event       *MatchEvent  `json:"event,omitempty"`
+	Matches     []Match      `json:"matches"`
+	LeagueTable []Team       `json:"league_table,omitempty"`
+	Predictions []Prediction `json:"predictions,omitempty"`
+}
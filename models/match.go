@@ -10,6 +10,12 @@ type Match struct {
 	AwayTeamScore int  `json:"awayTeamScore" db:"away_team_score"`
 	IsPlayed      bool `json:"isPlayed" db:"is_played"`
 
+	// Strengths and expected goals the match was simulated with
+	HomeStrength      int     `json:"homeStrength" db:"home_strength"`
+	AwayStrength      int     `json:"awayStrength" db:"away_strength"`
+	HomeExpectedGoals float64 `json:"homeExpectedGoals" db:"home_expected_goals"`
+	AwayExpectedGoals float64 `json:"awayExpectedGoals" db:"away_expected_goals"`
+
 	// Foreign key relationships
 	HomeTeam Team `json:"homeTeam" gorm:"foreignKey:HomeTeamID"`
 	AwayTeam Team `json:"awayTeam" gorm:"foreignKey:AwayTeamID"`
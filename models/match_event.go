@@ -0,0 +1,29 @@
+package models
+
+// Match event types
+const (
+	EventGoal         = "goal"
+	EventShot         = "shot"
+	EventYellowCard   = "yellow_card"
+	EventRedCard      = "red_card"
+	EventSubstitution = "substitution"
+	EventHalfTime     = "half_time"
+	EventFullTime     = "full_time"
+)
+
+// MatchEvent represents a single moment in a simulated match timeline
+type MatchEvent struct {
+	ID      uint   `json:"id" gorm:"primaryKey"`
+	MatchID uint   `json:"matchId" gorm:"index"`
+	Minute  int    `json:"minute"`
+	Type    string `json:"type"`
+	TeamID  uint   `json:"teamId"`
+
+	// Scorer and assister of a goal, when the team has a squad
+	PlayerID       *uint `json:"playerId,omitempty" gorm:"index"`
+	AssistPlayerID *uint `json:"assistPlayerId,omitempty" gorm:"index"`
+
+	// Running score after the event
+	HomeScore int `json:"homeScore"`
+	AwayScore int `json:"awayScore"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// Mini-league scoring types
+const (
+	MiniLeagueClassic    = "classic"
+	MiniLeagueHeadToHead = "head_to_head"
+)
+
+// MiniLeague represents a private fantasy competition that managers join with a code
+type MiniLeague struct {
+	ID   uint   `json:"id" gorm:"primaryKey"`
+	Name string `json:"name"`
+	Code string `json:"code" gorm:"uniqueIndex"`
+	Type string `json:"type"`
+
+	// StartWeek is the first gameweek that counts; head-to-head fixtures start from it and close the league to new members
+	StartWeek        int                `json:"startWeek"`
+	CreatorManagerID uint               `json:"creatorManagerId"`
+	Members          []MiniLeagueMember `json:"members,omitempty" gorm:"foreignKey:LeagueID;constraint:OnDelete:CASCADE"`
+	CreatedAt        time.Time          `json:"createdAt"`
+}
+
+// MiniLeagueMember represents a manager taking part in a mini-league
+type MiniLeagueMember struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	LeagueID  uint      `json:"leagueId" gorm:"uniqueIndex:idx_mini_league_members_league_manager"`
+	ManagerID uint      `json:"managerId" gorm:"uniqueIndex:idx_mini_league_members_league_manager"`
+	Manager   Manager   `json:"manager" gorm:"foreignKey:ManagerID"`
+	JoinedAt  time.Time `json:"joinedAt" gorm:"autoCreateTime"`
+}
+
+// HeadToHeadFixture represents a gameweek matchup between two managers in a head-to-head mini-league
+type HeadToHeadFixture struct {
+	ID            uint `json:"id" gorm:"primaryKey"`
+	LeagueID      uint `json:"leagueId" gorm:"index"`
+	Week          int  `json:"week" gorm:"index"`
+	HomeManagerID uint `json:"homeManagerId"`
+	AwayManagerID uint `json:"awayManagerId"`
+	HomePoints    int  `json:"homePoints"`
+	AwayPoints    int  `json:"awayPoints"`
+	IsPlayed      bool `json:"isPlayed"`
+}
+
+// MiniLeagueStanding represents a manager's row in a mini-league table.
+// Classic leagues rank by Total; head-to-head leagues by Points, then Total.
+type MiniLeagueStanding struct {
+	Rank      int    `json:"rank"`
+	ManagerID uint   `json:"managerId"`
+	Name      string `json:"name"`
+	TeamName  string `json:"teamName"`
+
+	// Total is the fantasy points scored since the league's start week
+	Total int `json:"total"`
+
+	// Head-to-head record and league points, left at zero in classic leagues
+	Played int `json:"played"`
+	Won    int `json:"won"`
+	Drawn  int `json:"drawn"`
+	Lost   int `json:"lost"`
+	Points int `json:"points"`
+}
@@ -0,0 +1,31 @@
+package models
+
+// OddsPrice represents one selection of a betting market with its price in every format
+type OddsPrice struct {
+	Selection string `json:"selection"`
+
+	// Probability is the model's fair chance of the selection; the prices include the bookmaker margin
+	Probability float64 `json:"probability"`
+	Decimal     float64 `json:"decimal"`
+	Fractional  string  `json:"fractional"`
+	American    string  `json:"american"`
+}
+
+// OddsMarket represents a betting market. Overround is the sum of its implied probabilities, one plus the margin.
+type OddsMarket struct {
+	Name       string      `json:"name"`
+	Overround  float64     `json:"overround"`
+	Selections []OddsPrice `json:"selections"`
+}
+
+// MatchOdds represents the pre-match markets of an unplayed fixture
+type MatchOdds struct {
+	MatchID           uint         `json:"matchId"`
+	Week              int          `json:"week"`
+	HomeTeam          string       `json:"homeTeam"`
+	AwayTeam          string       `json:"awayTeam"`
+	HomeExpectedGoals float64      `json:"homeExpectedGoals"`
+	AwayExpectedGoals float64      `json:"awayExpectedGoals"`
+	Margin            float64      `json:"margin"`
+	Markets           []OddsMarket `json:"markets"`
+}
@@ -0,0 +1,34 @@
+package models
+
+// Player positions
+const (
+	PositionGoalkeeper = "GK"
+	PositionDefender   = "DEF"
+	PositionMidfielder = "MID"
+	PositionForward    = "FWD"
+)
+
+// Positions lists every valid player position
+var Positions = []string{PositionGoalkeeper, PositionDefender, PositionMidfielder, PositionForward}
+
+// Player represents a footballer in a team's squad
+type Player struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	Name        string `json:"name"`
+	Position    string `json:"position"`
+	TeamID      uint   `json:"teamId" gorm:"index"`
+	Rating      int    `json:"rating"`
+	ShirtNumber int    `json:"shirtNumber"`
+
+	// Price is the fantasy cost in millions
+	Price float64 `json:"price"`
+}
+
+// PlayerLeader represents a player's goal or assist total in a leaderboard
+type PlayerLeader struct {
+	PlayerID uint   `json:"playerId"`
+	Name     string `json:"name"`
+	Position string `json:"position"`
+	TeamID   uint   `json:"teamId"`
+	Total    int    `json:"total"`
+}
@@ -1,7 +1,27 @@
 package models
 
-// Prediction represents a team's chance of winning the championship
+// Prediction represents a team's projected finish, from simulating the rest of the season many times
 type Prediction struct {
+	TeamID   uint   `json:"teamId"`
 	TeamName string `json:"teamName"`
-	Chance   string `json:"chance"`
+
+	// Chance is the title probability formatted as a percentage, kept for clients that read it as a string
+	Chance string `json:"chance"`
+
+	// TitleProbability is the chance of finishing first, between 0 and 1
+	TitleProbability float64 `json:"titleProbability"`
+
+	// Positions holds the chance of finishing in each position; Positions[0] is first place
+	Positions []float64 `json:"positions"`
+
+	// Zones holds the chance of finishing in each configured zone, keyed by zone name
+	Zones map[string]float64 `json:"zones"`
+}
+
+// Zone represents a range of league positions, such as the European places. Negative positions count from the
+// bottom, so -3 to -1 is the bottom three.
+type Zone struct {
+	Name string `json:"name"`
+	From int    `json:"from"`
+	To   int    `json:"to"`
 }
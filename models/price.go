@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// PriceChange represents a move in a player's fantasy price after a gameweek
+type PriceChange struct {
+	ID       uint    `json:"id" gorm:"primaryKey"`
+	PlayerID uint    `json:"playerId" gorm:"index"`
+	Player   *Player `json:"player,omitempty" gorm:"foreignKey:PlayerID"`
+	Week     int     `json:"week" gorm:"index"`
+	OldPrice float64 `json:"oldPrice"`
+	NewPrice float64 `json:"newPrice"`
+
+	// NetTransfers is transfers in less transfers out for the week, Form the average points over the recent weeks
+	NetTransfers int       `json:"netTransfers"`
+	Form         float64   `json:"form"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
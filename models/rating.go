@@ -0,0 +1,20 @@
+package models
+
+// TeamRating represents a single entry in a team's Elo rating history
+type TeamRating struct {
+	ID           uint    `json:"id" gorm:"primaryKey"`
+	TeamID       uint    `json:"teamId" gorm:"index"`
+	MatchID      uint    `json:"matchId"`
+	Week         int     `json:"week"`
+	RatingBefore float64 `json:"ratingBefore"`
+	RatingAfter  float64 `json:"ratingAfter"`
+	Change       float64 `json:"change"`
+}
+
+// PowerRanking represents a team's position when ordered by Elo rating
+type PowerRanking struct {
+	Position int     `json:"position"`
+	TeamID   uint    `json:"teamId"`
+	TeamName string  `json:"teamName"`
+	Rating   float64 `json:"rating"`
+}
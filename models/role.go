@@ -0,0 +1,42 @@
+package models
+
+// User roles, from most to least privileged
+const (
+	RoleAdmin        = "admin"
+	RoleCommissioner = "commissioner"
+	RoleManager      = "manager"
+	RoleViewer       = "viewer"
+)
+
+// Roles lists every valid role
+var Roles = []string{RoleAdmin, RoleCommissioner, RoleManager, RoleViewer}
+
+// Permissions checked before a request may change data; reads need none
+const (
+	PermissionPlayWeeks      = "league:play"
+	PermissionEditResults    = "league:edit"
+	PermissionManageLeague   = "league:manage"
+	PermissionResetLeague    = "league:reset"
+	PermissionDelete         = "league:delete"
+	PermissionPlayFantasy    = "fantasy:play"
+	PermissionPredict        = "predictions:play"
+	PermissionBet            = "bets:place"
+	PermissionManageWebhooks = "webhooks:manage"
+	PermissionManageUsers    = "users:manage"
+)
+
+// RolePermissions is the permission matrix: what each role may do. Viewers are read-only.
+var RolePermissions = map[string][]string{
+	RoleAdmin: {
+		PermissionPlayWeeks, PermissionEditResults, PermissionManageLeague, PermissionResetLeague,
+		PermissionDelete, PermissionPlayFantasy, PermissionPredict, PermissionBet, PermissionManageWebhooks, PermissionManageUsers,
+	},
+	RoleCommissioner: {PermissionPlayWeeks, PermissionEditResults, PermissionManageLeague, PermissionPlayFantasy, PermissionPredict, PermissionBet},
+	RoleManager:      {PermissionPlayFantasy, PermissionPredict, PermissionBet},
+	RoleViewer:       {},
+}
+
+// RoleUpdate represents the body accepted when changing a user's role
+type RoleUpdate struct {
+	Role string `json:"role"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// ScorePrediction represents a user's predicted scoreline for a match. It is locked once the match's week is
+// played, and Points stays empty until then.
+type ScorePrediction struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"userId" gorm:"uniqueIndex:idx_score_predictions_user_match"`
+	MatchID   uint      `json:"matchId" gorm:"uniqueIndex:idx_score_predictions_user_match;index"`
+	Match     *Match    `json:"match,omitempty" gorm:"foreignKey:MatchID"`
+	Week      int       `json:"week" gorm:"index"`
+	HomeGoals int       `json:"homeGoals"`
+	AwayGoals int       `json:"awayGoals"`
+	Points    *int      `json:"points"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ScorePredictionRequest represents the body accepted when predicting a match
+type ScorePredictionRequest struct {
+	MatchID   uint `json:"matchId"`
+	HomeGoals int  `json:"homeGoals"`
+	AwayGoals int  `json:"awayGoals"`
+}
+
+// PredictionLeaderboardEntry represents a user's row in the season prediction leaderboard
+type PredictionLeaderboardEntry struct {
+	Rank            int    `json:"rank"`
+	UserID          uint   `json:"userId"`
+	Name            string `json:"name"`
+	Points          int    `json:"points"`
+	ExactScores     int    `json:"exactScores"`
+	CorrectOutcomes int    `json:"correctOutcomes"`
+	Predictions     int    `json:"predictions"`
+}
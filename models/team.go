@@ -13,8 +13,45 @@ type Stats struct {
 
 // Team represents a football team in the league
 type Team struct {
-	ID       uint   `json:"id" gorm:"primaryKey"`
-	Name     string `json:"name"`
-	Strength int    `json:"strength"`
-	Stats    Stats  `json:"stats" gorm:"embedded"`
+	ID      uint   `json:"id" gorm:"primaryKey"`
+	Name    string `json:"name"`
+	Attack  int    `json:"attack"`
+	Defence int    `json:"defence"`
+
+	// Strength is the overall rating derived from attack and defence
+	Strength     int     `json:"strength"`
+	BaseStrength int     `json:"baseStrength"`
+	Rating       float64 `json:"rating" gorm:"default:1500"`
+	Stats        Stats   `json:"stats" gorm:"embedded"`
+}
+
+// NormalizeRatings fills missing attack and defence ratings from Strength
+// and recomputes Strength as their rounded average
+func (t *Team) NormalizeRatings() {
+	switch {
+	case t.Attack == 0 && t.Defence == 0:
+		t.Attack = t.Strength
+		t.Defence = t.Strength
+	case t.Attack == 0:
+		t.Attack = t.Defence
+	case t.Defence == 0:
+		t.Defence = t.Attack
+	}
+	t.Strength = (t.Attack + t.Defence + 1) / 2
+}
+
+// AttackRating returns the attack rating, falling back to Strength for teams without one
+func (t *Team) AttackRating() int {
+	if t.Attack == 0 {
+		return t.Strength
+	}
+	return t.Attack
+}
+
+// DefenceRating returns the defence rating, falling back to Strength for teams without one
+func (t *Team) DefenceRating() int {
+	if t.Defence == 0 {
+		return t.Strength
+	}
+	return t.Defence
 }
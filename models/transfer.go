@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// Transfer represents one player swapped out of a fantasy squad for another ahead of a gameweek
+type Transfer struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	ManagerID   uint   `json:"managerId" gorm:"index"`
+	Week        int    `json:"week"`
+	PlayerOutID uint   `json:"playerOutId"`
+	PlayerOut   Player `json:"playerOut" gorm:"foreignKey:PlayerOutID"`
+	PlayerInID  uint   `json:"playerInId"`
+	PlayerIn    Player `json:"playerIn" gorm:"foreignKey:PlayerInID"`
+
+	// SellingPrice is what the outgoing player raised and PurchasePrice what the incoming one cost, in millions
+	SellingPrice  float64 `json:"sellingPrice"`
+	PurchasePrice float64 `json:"purchasePrice"`
+
+	// Cost is the points deducted for the transfer, zero when it used a free transfer
+	Cost      int       `json:"cost"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TransferRequest represents one swap a manager asks for
+type TransferRequest struct {
+	PlayerOutID uint `json:"playerOutId"`
+	PlayerInID  uint `json:"playerInId"`
+}
+
+// TransferDeadline represents the gameweek transfers currently count towards
+type TransferDeadline struct {
+	Week int  `json:"week"`
+	Open bool `json:"open"`
+}
+
+// TransferResult represents the outcome of a batch of transfers
+type TransferResult struct {
+	Week              int           `json:"week"`
+	Chip              string        `json:"chip,omitempty"`
+	Transfers         []Transfer    `json:"transfers"`
+	FreeTransfersUsed int           `json:"freeTransfersUsed"`
+	PointsCost        int           `json:"pointsCost"`
+	FreeTransfersLeft int           `json:"freeTransfersLeft"`
+	Bank              float64       `json:"bank"`
+	Squad             *FantasySquad `json:"squad"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// User represents an account that signs in to the API
+type User struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	Email        string    `json:"email" gorm:"uniqueIndex"`
+	Name         string    `json:"name"`
+	PasswordHash string    `json:"-"`
+	Role         string    `json:"role" gorm:"default:manager"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// RefreshToken represents an issued refresh token, kept so it can be rotated and revoked
+type RefreshToken struct {
+	ID uint `gorm:"primaryKey"`
+
+	// TokenID is the token's jti claim
+	TokenID   string `gorm:"uniqueIndex"`
+	UserID    uint   `gorm:"index"`
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// Credentials represents the body accepted when registering or signing in
+type Credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+
+	// Name is only used when registering
+	Name string `json:"name"`
+}
+
+// TokenPair represents the tokens handed out on sign-in
+type TokenPair struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	TokenType    string `json:"tokenType"`
+
+	// ExpiresIn is the access token's lifetime in seconds
+	ExpiresIn int   `json:"expiresIn"`
+	User      *User `json:"user"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Webhook event names sent to subscribers
+const (
+	WebhookMatchPlayed    = "match_played"
+	WebhookResultEdited   = "result_edited"
+	WebhookSeasonFinished = "season_finished"
+	WebhookLeagueReset    = "league_reset"
+)
+
+// WebhookSubscription represents a partner endpoint that receives league events
+type WebhookSubscription struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	TargetURL string `json:"targetUrl"`
+
+	// Events lists the event names to deliver; an empty list receives every event
+	Events    []string  `json:"events" gorm:"serializer:json"`
+	Secret    string    `json:"-"`
+	Active    bool      `json:"active" gorm:"default:true"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// WebhookDelivery represents a single attempt to deliver an event to a subscription
+type WebhookDelivery struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	SubscriptionID uint      `json:"subscriptionId" gorm:"index"`
+	DeliveryID     string    `json:"deliveryId"`
+	Event          string    `json:"event"`
+	Attempt        int       `json:"attempt"`
+	StatusCode     int       `json:"statusCode"`
+	Success        bool      `json:"success"`
+	Error          string    `json:"error,omitempty"`
+	Payload        string    `json:"payload"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// WebhookPayload represents the JSON body posted to subscribers
+type WebhookPayload struct {
+	DeliveryID  string    `json:"deliveryId"`
+	Event       string    `json:"event"`
+	OccurredAt  time.Time `json:"occurredAt"`
+	Week        int       `json:"week,omitempty"`
+	Match       *Match    `json:"match,omitempty"`
+	LeagueTable []Team    `json:"league_table,omitempty"`
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"insider-league/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository defines the interface for API key data operations
+type APIKeyRepository interface {
+	GetByUser(userID int) ([]models.APIKey, error)
+	GetByID(id int) (*models.APIKey, error)
+	GetByHash(hash string) (*models.APIKey, error)
+	Create(key *models.APIKey) error
+	Revoke(id int) error
+	TouchLastUsed(id int, usedAt time.Time) error
+}
+
+// apiKeyRepository implements APIKeyRepository interface
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new instance of apiKeyRepository
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+// GetByUser retrieves every API key issued to a user, newest first
+func (r *apiKeyRepository) GetByUser(userID int) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	result := r.db.Where("user_id = ?", userID).Order("id DESC").Find(&keys)
+	return keys, result.Error
+}
+
+// GetByID retrieves an API key by its ID
+func (r *apiKeyRepository) GetByID(id int) (*models.APIKey, error) {
+	var key models.APIKey
+	result := r.db.First(&key, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &key, nil
+}
+
+// GetByHash retrieves an API key by the hash of the key
+func (r *apiKeyRepository) GetByHash(hash string) (*models.APIKey, error) {
+	var key models.APIKey
+	result := r.db.Where("key_hash = ?", hash).First(&key)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &key, nil
+}
+
+// Create adds a new API key to the database
+func (r *apiKeyRepository) Create(key *models.APIKey) error {
+	result := r.db.Create(key)
+	return result.Error
+}
+
+// Revoke marks an API key as no longer usable
+func (r *apiKeyRepository) Revoke(id int) error {
+	result := r.db.Model(&models.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	return result.Error
+}
+
+// TouchLastUsed records when an API key was last used
+func (r *apiKeyRepository) TouchLastUsed(id int, usedAt time.Time) error {
+	result := r.db.Model(&models.APIKey{}).Where("id = ?", id).Update("last_used_at", usedAt)
+	return result.Error
+}
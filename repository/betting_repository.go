@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"insider-league/models"
+
+	"gorm.io/gorm"
+)
+
+// BettingRepository defines the interface for wallet, bet and ledger data operations
+type BettingRepository interface {
+	GetWallet(userID int) (*models.Wallet, error)
+	CreateWallet(wallet *models.Wallet, entry *models.WalletTransaction) error
+	GetBetsByUser(userID int) ([]models.Bet, error)
+	GetBetsByMatch(matchID int) ([]models.Bet, error)
+	GetPendingBets() ([]models.Bet, error)
+	GetTransactions(userID int) ([]models.WalletTransaction, error)
+	SaveBet(wallet *models.Wallet, bet *models.Bet, entry *models.WalletTransaction) error
+	FinalizeBets() error
+}
+
+// bettingRepository implements BettingRepository interface
+type bettingRepository struct {
+	db *gorm.DB
+}
+
+// NewBettingRepository creates a new instance of bettingRepository
+func NewBettingRepository(db *gorm.DB) BettingRepository {
+	return &bettingRepository{
+		db: db,
+	}
+}
+
+// GetWallet retrieves a user's wallet
+func (r *bettingRepository) GetWallet(userID int) (*models.Wallet, error) {
+	var wallet models.Wallet
+	result := r.db.Where("user_id = ?", userID).First(&wallet)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &wallet, nil
+}
+
+// CreateWallet opens a wallet together with the ledger entry of its opening balance
+func (r *bettingRepository) CreateWallet(wallet *models.Wallet, entry *models.WalletTransaction) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(wallet).Error; err != nil {
+			return err
+		}
+		return tx.Create(entry).Error
+	})
+}
+
+// GetBetsByUser retrieves a user's bets with their matches, newest first
+func (r *bettingRepository) GetBetsByUser(userID int) ([]models.Bet, error) {
+	var bets []models.Bet
+	result := r.db.Preload("Match.HomeTeam").Preload("Match.AwayTeam").
+		Where("user_id = ?", userID).Order("id DESC").Find(&bets)
+	return bets, result.Error
+}
+
+// GetBetsByMatch retrieves every bet on a match
+func (r *bettingRepository) GetBetsByMatch(matchID int) ([]models.Bet, error) {
+	var bets []models.Bet
+	result := r.db.Where("match_id = ?", matchID).Order("id").Find(&bets)
+	return bets, result.Error
+}
+
+// GetPendingBets retrieves every bet still waiting to be settled with its match, oldest first
+func (r *bettingRepository) GetPendingBets() ([]models.Bet, error) {
+	var bets []models.Bet
+	result := r.db.Preload("Match").Where("status = ?", models.BetPending).Order("id").Find(&bets)
+	return bets, result.Error
+}
+
+// GetTransactions retrieves a user's ledger, newest first
+func (r *bettingRepository) GetTransactions(userID int) ([]models.WalletTransaction, error) {
+	var entries []models.WalletTransaction
+	result := r.db.Where("user_id = ?", userID).Order("id DESC").Find(&entries)
+	return entries, result.Error
+}
+
+// SaveBet stores a bet, the wallet balance it changed and its ledger entry in one transaction.
+// The entry may be nil when the bet changed without money moving.
+func (r *bettingRepository) SaveBet(wallet *models.Wallet, bet *models.Bet, entry *models.WalletTransaction) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Match").Save(bet).Error; err != nil {
+			return err
+		}
+		if entry == nil {
+			return nil
+		}
+		if err := tx.Save(wallet).Error; err != nil {
+			return err
+		}
+		entry.BetID = &bet.ID
+		return tx.Create(entry).Error
+	})
+}
+
+// FinalizeBets marks every bet as final, so results played after a reset never resettle it
+func (r *bettingRepository) FinalizeBets() error {
+	result := r.db.Model(&models.Bet{}).Where("final = ?", false).Update("final", true)
+	return result.Error
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"insider-league/models"
+
+	"gorm.io/gorm"
+)
+
+// ChipRepository defines the interface for fantasy chip data operations
+type ChipRepository interface {
+	GetByManager(managerID int) ([]models.ChipActivation, error)
+	GetByManagerWeek(managerID, week int) (*models.ChipActivation, error)
+	GetByWeek(week int) ([]models.ChipActivation, error)
+	Create(activation *models.ChipActivation) error
+	DeleteAll() error
+}
+
+// chipRepository implements ChipRepository interface
+type chipRepository struct {
+	db *gorm.DB
+}
+
+// NewChipRepository creates a new instance of chipRepository
+func NewChipRepository(db *gorm.DB) ChipRepository {
+	return &chipRepository{
+		db: db,
+	}
+}
+
+// GetByManager retrieves every chip a manager has played, in week order
+func (r *chipRepository) GetByManager(managerID int) ([]models.ChipActivation, error) {
+	var activations []models.ChipActivation
+	result := r.db.Where("manager_id = ?", managerID).Order("week ASC").Find(&activations)
+	return activations, result.Error
+}
+
+// GetByManagerWeek retrieves the chip a manager played for a week
+func (r *chipRepository) GetByManagerWeek(managerID, week int) (*models.ChipActivation, error) {
+	var activation models.ChipActivation
+	result := r.db.Where("manager_id = ? AND week = ?", managerID, week).First(&activation)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &activation, nil
+}
+
+// GetByWeek retrieves every chip played for a week
+func (r *chipRepository) GetByWeek(week int) ([]models.ChipActivation, error) {
+	var activations []models.ChipActivation
+	result := r.db.Where("week = ?", week).Find(&activations)
+	return activations, result.Error
+}
+
+// Create adds a chip activation to the database
+func (r *chipRepository) Create(activation *models.ChipActivation) error {
+	result := r.db.Create(activation)
+	return result.Error
+}
+
+// DeleteAll removes every chip activation
+func (r *chipRepository) DeleteAll() error {
+	result := r.db.Where("1 = 1").Delete(&models.ChipActivation{})
+	return result.Error
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"insider-league/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FantasyPointsRepository defines the interface for fantasy scoring data operations
+type FantasyPointsRepository interface {
+	ReplaceMatchPerformances(matchID int, records []models.PlayerGameweek) error
+	GetPlayerGameweeks(week int) ([]models.PlayerGameweek, error)
+	GetPicks(managerID, week int) ([]models.FantasyPick, error)
+	SavePicks(picks []models.FantasyPick) error
+	SaveManagerGameweek(gameweek *models.ManagerGameweek) error
+	GetManagerGameweeks(managerID int) ([]models.ManagerGameweek, error)
+	GetManagerGameweek(managerID, week int) (*models.ManagerGameweek, error)
+	DeleteAll() error
+}
+
+// fantasyPointsRepository implements FantasyPointsRepository interface
+type fantasyPointsRepository struct {
+	db *gorm.DB
+}
+
+// NewFantasyPointsRepository creates a new instance of fantasyPointsRepository
+func NewFantasyPointsRepository(db *gorm.DB) FantasyPointsRepository {
+	return &fantasyPointsRepository{
+		db: db,
+	}
+}
+
+// ReplaceMatchPerformances swaps the stored player performances of a match for new ones
+func (r *fantasyPointsRepository) ReplaceMatchPerformances(matchID int, records []models.PlayerGameweek) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("match_id = ?", matchID).Delete(&models.PlayerGameweek{}).Error; err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		return tx.Create(&records).Error
+	})
+}
+
+// GetPlayerGameweeks retrieves every player performance of a week, highest scoring first
+func (r *fantasyPointsRepository) GetPlayerGameweeks(week int) ([]models.PlayerGameweek, error) {
+	var records []models.PlayerGameweek
+	result := r.db.Where("week = ?", week).Order("points DESC, player_id").Find(&records)
+	return records, result.Error
+}
+
+// GetPicks retrieves a manager's frozen picks for a week with their players in slot order
+func (r *fantasyPointsRepository) GetPicks(managerID, week int) ([]models.FantasyPick, error) {
+	var picks []models.FantasyPick
+	result := r.db.Preload("Player").Where("manager_id = ? AND week = ?", managerID, week).Order("slot, id").Find(&picks)
+	return picks, result.Error
+}
+
+// SavePicks creates new picks or updates the points of existing ones
+func (r *fantasyPointsRepository) SavePicks(picks []models.FantasyPick) error {
+	if len(picks) == 0 {
+		return nil
+	}
+	result := r.db.Omit("Player").Save(&picks)
+	return result.Error
+}
+
+// SaveManagerGameweek creates or updates a manager's score for a week
+func (r *fantasyPointsRepository) SaveManagerGameweek(gameweek *models.ManagerGameweek) error {
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "manager_id"}, {Name: "week"}},
+		DoUpdates: clause.AssignmentColumns([]string{"points", "transfer_cost", "chip", "updated_at"}),
+	}).Create(gameweek)
+	return result.Error
+}
+
+// GetManagerGameweeks retrieves a manager's score for every scored week
+func (r *fantasyPointsRepository) GetManagerGameweeks(managerID int) ([]models.ManagerGameweek, error) {
+	var gameweeks []models.ManagerGameweek
+	result := r.db.Where("manager_id = ?", managerID).Order("week").Find(&gameweeks)
+	return gameweeks, result.Error
+}
+
+// GetManagerGameweek retrieves a manager's score for a single week
+func (r *fantasyPointsRepository) GetManagerGameweek(managerID, week int) (*models.ManagerGameweek, error) {
+	var gameweek models.ManagerGameweek
+	result := r.db.Where("manager_id = ? AND week = ?", managerID, week).First(&gameweek)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &gameweek, nil
+}
+
+// DeleteAll removes every performance, pick and manager score
+func (r *fantasyPointsRepository) DeleteAll() error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []any{&models.PlayerGameweek{}, &models.FantasyPick{}, &models.ManagerGameweek{}} {
+			if err := tx.Where("1 = 1").Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"insider-league/models"
+
+	"gorm.io/gorm"
+)
+
+// FantasySquadRepository defines the interface for fantasy squad data operations
+type FantasySquadRepository interface {
+	GetAll() ([]models.FantasySquad, error)
+	GetByManager(managerID int) (*models.FantasySquad, error)
+	Create(squad *models.FantasySquad, manager *models.Manager) error
+	ReplacePlayers(squad *models.FantasySquad) error
+}
+
+// fantasySquadRepository implements FantasySquadRepository interface
+type fantasySquadRepository struct {
+	db *gorm.DB
+}
+
+// NewFantasySquadRepository creates a new instance of fantasySquadRepository
+func NewFantasySquadRepository(db *gorm.DB) FantasySquadRepository {
+	return &fantasySquadRepository{
+		db: db,
+	}
+}
+
+// GetAll retrieves every squad with its players
+func (r *fantasySquadRepository) GetAll() ([]models.FantasySquad, error) {
+	var squads []models.FantasySquad
+	result := r.db.Preload("Players.Player").Find(&squads)
+	return squads, result.Error
+}
+
+// GetByManager retrieves a manager's squad with its players
+func (r *fantasySquadRepository) GetByManager(managerID int) (*models.FantasySquad, error) {
+	var squad models.FantasySquad
+	result := r.db.Preload("Players.Player").Where("manager_id = ?", managerID).First(&squad)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &squad, nil
+}
+
+// Create adds a new squad and its players to the database and saves the manager who paid for them in one
+// transaction, so a squad is never stored without its cost leaving the bank
+func (r *fantasySquadRepository) Create(squad *models.FantasySquad, manager *models.Manager) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Players.Player").Create(squad).Error; err != nil {
+			return err
+		}
+		return tx.Save(manager).Error
+	})
+}
+
+// ReplacePlayers swaps the stored players of a squad for the ones it currently holds
+func (r *fantasySquadRepository) ReplacePlayers(squad *models.FantasySquad) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceSquadPlayers(tx, squad)
+	})
+}
+
+// replaceSquadPlayers swaps the stored players of a squad within a transaction
+func replaceSquadPlayers(tx *gorm.DB, squad *models.FantasySquad) error {
+	if err := tx.Where("squad_id = ?", squad.ID).Delete(&models.FantasySquadPlayer{}).Error; err != nil {
+		return err
+	}
+	for i := range squad.Players {
+		squad.Players[i].ID = 0
+		squad.Players[i].SquadID = squad.ID
+	}
+	if len(squad.Players) > 0 {
+		if err := tx.Omit("Player").Create(&squad.Players).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(squad).Update("updated_at", gorm.Expr("NOW()")).Error
+}
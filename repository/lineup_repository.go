@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"insider-league/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LineupRepository defines the interface for fantasy lineup data operations
+type LineupRepository interface {
+	GetLatest(managerID, week int) (*models.Lineup, error)
+	Save(lineup *models.Lineup) error
+	Delete(managerID, week int) error
+	DeleteAll() error
+}
+
+// lineupRepository implements LineupRepository interface
+type lineupRepository struct {
+	db *gorm.DB
+}
+
+// NewLineupRepository creates a new instance of lineupRepository
+func NewLineupRepository(db *gorm.DB) LineupRepository {
+	return &lineupRepository{
+		db: db,
+	}
+}
+
+// GetLatest retrieves the lineup a manager last set for the given week or any earlier one
+func (r *lineupRepository) GetLatest(managerID, week int) (*models.Lineup, error) {
+	var lineup models.Lineup
+	result := r.db.Where("manager_id = ? AND week <= ?", managerID, week).Order("week DESC").First(&lineup)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &lineup, nil
+}
+
+// Save creates or replaces a manager's lineup for a week
+func (r *lineupRepository) Save(lineup *models.Lineup) error {
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "manager_id"}, {Name: "week"}},
+		DoUpdates: clause.AssignmentColumns([]string{"starters", "bench", "captain_id", "vice_captain_id", "updated_at"}),
+	}).Create(lineup)
+	return result.Error
+}
+
+// Delete removes the lineup a manager set for a week, if any
+func (r *lineupRepository) Delete(managerID, week int) error {
+	result := r.db.Where("manager_id = ? AND week = ?", managerID, week).Delete(&models.Lineup{})
+	return result.Error
+}
+
+// DeleteAll removes every lineup
+func (r *lineupRepository) DeleteAll() error {
+	result := r.db.Where("1 = 1").Delete(&models.Lineup{})
+	return result.Error
+}
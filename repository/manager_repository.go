@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"insider-league/models"
+
+	"gorm.io/gorm"
+)
+
+// ManagerRepository defines the interface for fantasy manager data operations
+type ManagerRepository interface {
+	GetAll() ([]models.Manager, error)
+	GetByID(id int) (*models.Manager, error)
+	Create(manager *models.Manager) error
+	Update(manager *models.Manager) error
+	UpdateBank(id uint, bank float64) error
+	UpdateTotalPoints(id uint, totalPoints int) error
+	AddFreeTransfers(amount, max int) error
+	ResetFreeTransfers(freeTransfers int) error
+	Delete(id int) error
+}
+
+// managerRepository implements ManagerRepository interface
+type managerRepository struct {
+	db *gorm.DB
+}
+
+// NewManagerRepository creates a new instance of managerRepository
+func NewManagerRepository(db *gorm.DB) ManagerRepository {
+	return &managerRepository{
+		db: db,
+	}
+}
+
+// GetAll retrieves all managers from the database
+func (r *managerRepository) GetAll() ([]models.Manager, error) {
+	var managers []models.Manager
+	result := r.db.Find(&managers)
+	return managers, result.Error
+}
+
+// GetByID retrieves a manager by its ID
+func (r *managerRepository) GetByID(id int) (*models.Manager, error) {
+	var manager models.Manager
+	result := r.db.First(&manager, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &manager, nil
+}
+
+// Create adds a new manager to the database
+func (r *managerRepository) Create(manager *models.Manager) error {
+	result := r.db.Create(manager)
+	return result.Error
+}
+
+// Update modifies an existing manager in the database
+func (r *managerRepository) Update(manager *models.Manager) error {
+	result := r.db.Save(manager)
+	return result.Error
+}
+
+// UpdateBank stores a manager's bank without touching the rest of the row
+func (r *managerRepository) UpdateBank(id uint, bank float64) error {
+	result := r.db.Model(&models.Manager{}).Where("id = ?", id).Update("bank", bank)
+	return result.Error
+}
+
+// UpdateTotalPoints stores a manager's season total without touching the rest of the row
+func (r *managerRepository) UpdateTotalPoints(id uint, totalPoints int) error {
+	result := r.db.Model(&models.Manager{}).Where("id = ?", id).Update("total_points", totalPoints)
+	return result.Error
+}
+
+// AddFreeTransfers gives every manager more free transfers in a single statement, up to the most that can be banked
+func (r *managerRepository) AddFreeTransfers(amount, max int) error {
+	result := r.db.Model(&models.Manager{}).Where("1 = 1").Update("free_transfers", gorm.Expr("LEAST(free_transfers + ?, ?)", amount, max))
+	return result.Error
+}
+
+// ResetFreeTransfers puts every manager on the same number of free transfers
+func (r *managerRepository) ResetFreeTransfers(freeTransfers int) error {
+	result := r.db.Model(&models.Manager{}).Where("1 = 1").Update("free_transfers", freeTransfers)
+	return result.Error
+}
+
+// Delete removes a manager from the database by its ID
+func (r *managerRepository) Delete(id int) error {
+	result := r.db.Delete(&models.Manager{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"insider-league/models"
+
+	"gorm.io/gorm"
+)
+
+// MatchEventRepository defines the interface for match event data operations
+type MatchEventRepository interface {
+	GetByMatch(matchID int) ([]models.MatchEvent, error)
+	Create(events []models.MatchEvent) error
+	ReplaceByMatch(matchID int, events []models.MatchEvent) error
+	DeleteByMatch(matchID int) error
+	DeleteAll() error
+	GetTopScorers(limit int) ([]models.PlayerLeader, error)
+	GetTopAssisters(limit int) ([]models.PlayerLeader, error)
+}
+
+// matchEventRepository implements MatchEventRepository interface
+type matchEventRepository struct {
+	db *gorm.DB
+}
+
+// NewMatchEventRepository creates a new instance of matchEventRepository
+func NewMatchEventRepository(db *gorm.DB) MatchEventRepository {
+	return &matchEventRepository{
+		db: db,
+	}
+}
+
+// GetByMatch retrieves the timeline of a match in chronological order
+func (r *matchEventRepository) GetByMatch(matchID int) ([]models.MatchEvent, error) {
+	var events []models.MatchEvent
+	result := r.db.Where("match_id = ?", matchID).Order("id ASC").Find(&events)
+	return events, result.Error
+}
+
+// Create adds match events to the database
+func (r *matchEventRepository) Create(events []models.MatchEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	result := r.db.Create(&events)
+	return result.Error
+}
+
+// ReplaceByMatch swaps the stored timeline of a match for the given events in one transaction. The events are
+// stored afresh in the order given, which is the order GetByMatch returns them in.
+func (r *matchEventRepository) ReplaceByMatch(matchID int, events []models.MatchEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("match_id = ?", matchID).Delete(&models.MatchEvent{}).Error; err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		for i := range events {
+			events[i].ID = 0
+		}
+		return tx.Create(&events).Error
+	})
+}
+
+// DeleteByMatch removes all events of a match from the database
+func (r *matchEventRepository) DeleteByMatch(matchID int) error {
+	result := r.db.Where("match_id = ?", matchID).Delete(&models.MatchEvent{})
+	return result.Error
+}
+
+// DeleteAll removes every match event from the database
+func (r *matchEventRepository) DeleteAll() error {
+	result := r.db.Where("1 = 1").Delete(&models.MatchEvent{})
+	return result.Error
+}
+
+// GetTopScorers retrieves the players with the most goals
+func (r *matchEventRepository) GetTopScorers(limit int) ([]models.PlayerLeader, error) {
+	return r.topPlayers("player_id", limit)
+}
+
+// GetTopAssisters retrieves the players with the most assists
+func (r *matchEventRepository) GetTopAssisters(limit int) ([]models.PlayerLeader, error) {
+	return r.topPlayers("assist_player_id", limit)
+}
+
+// topPlayers counts goal events per player in the given column, highest first
+func (r *matchEventRepository) topPlayers(column string, limit int) ([]models.PlayerLeader, error) {
+	var leaders []models.PlayerLeader
+	result := r.db.Model(&models.MatchEvent{}).
+		Select("players.id AS player_id, players.name, players.position, players.team_id, COUNT(*) AS total").
+		Joins("JOIN players ON players.id = match_events."+column).
+		Where("match_events.type = ?", models.EventGoal).
+		Group("players.id, players.name, players.position, players.team_id").
+		Order("total DESC, players.name").
+		Limit(limit).
+		Scan(&leaders)
+	return leaders, result.Error
+}
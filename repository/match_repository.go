@@ -12,6 +12,7 @@ type MatchRepository interface {
 	GetByID(id int) (*models.Match, error)
 	GetByWeek(week int) ([]models.Match, error)
 	GetUnplayedWeeks() ([]int, error)
+	GetPlayedBetween(teamID, otherTeamID int) ([]models.Match, error)
 	Create(match *models.Match) error
 	Update(match *models.Match) error
 	Delete(id int) error
@@ -64,6 +65,18 @@ func (r *matchRepository) GetUnplayedWeeks() ([]int, error) {
 	return weeks, err
 }
 
+// GetPlayedBetween retrieves all played matches between two teams regardless of venue
+func (r *matchRepository) GetPlayedBetween(teamID, otherTeamID int) ([]models.Match, error) {
+	var matches []models.Match
+	result := r.db.Preload("HomeTeam").Preload("AwayTeam").
+		Where("is_played = ?", true).
+		Where("(home_team_id = ? AND away_team_id = ?) OR (home_team_id = ? AND away_team_id = ?)",
+			teamID, otherTeamID, otherTeamID, teamID).
+		Order("week ASC").
+		Find(&matches)
+	return matches, result.Error
+}
+
 // Create adds a new match to the database
 func (r *matchRepository) Create(match *models.Match) error {
 	result := r.db.Create(match)
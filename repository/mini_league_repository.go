@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"insider-league/models"
+
+	"gorm.io/gorm"
+)
+
+// MiniLeagueRepository defines the interface for mini-league data operations
+type MiniLeagueRepository interface {
+	GetByID(id int) (*models.MiniLeague, error)
+	GetByCode(code string) (*models.MiniLeague, error)
+	GetByManager(managerID int) ([]models.MiniLeague, error)
+	GetByType(leagueType string) ([]models.MiniLeague, error)
+	Create(league *models.MiniLeague) error
+	AddMember(member *models.MiniLeagueMember) error
+	GetFixtures(leagueID int) ([]models.HeadToHeadFixture, error)
+	CreateFixtures(fixtures []models.HeadToHeadFixture) error
+	SaveFixtures(fixtures []models.HeadToHeadFixture) error
+	ResetSeason() error
+}
+
+// miniLeagueRepository implements MiniLeagueRepository interface
+type miniLeagueRepository struct {
+	db *gorm.DB
+}
+
+// NewMiniLeagueRepository creates a new instance of miniLeagueRepository
+func NewMiniLeagueRepository(db *gorm.DB) MiniLeagueRepository {
+	return &miniLeagueRepository{
+		db: db,
+	}
+}
+
+// GetByID retrieves a mini-league with its members
+func (r *miniLeagueRepository) GetByID(id int) (*models.MiniLeague, error) {
+	var league models.MiniLeague
+	result := r.db.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Preload("Members.Manager").First(&league, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &league, nil
+}
+
+// GetByCode retrieves a mini-league by its join code
+func (r *miniLeagueRepository) GetByCode(code string) (*models.MiniLeague, error) {
+	var league models.MiniLeague
+	result := r.db.Preload("Members").Where("code = ?", code).First(&league)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &league, nil
+}
+
+// GetByManager retrieves every mini-league a manager belongs to
+func (r *miniLeagueRepository) GetByManager(managerID int) ([]models.MiniLeague, error) {
+	var leagues []models.MiniLeague
+	result := r.db.Joins("JOIN mini_league_members ON mini_league_members.league_id = mini_leagues.id").
+		Where("mini_league_members.manager_id = ?", managerID).
+		Order("mini_leagues.id ASC").
+		Find(&leagues)
+	return leagues, result.Error
+}
+
+// GetByType retrieves every mini-league of a scoring type with its members
+func (r *miniLeagueRepository) GetByType(leagueType string) ([]models.MiniLeague, error) {
+	var leagues []models.MiniLeague
+	result := r.db.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Where("type = ?", leagueType).Find(&leagues)
+	return leagues, result.Error
+}
+
+// Create adds a new mini-league and its first members to the database
+func (r *miniLeagueRepository) Create(league *models.MiniLeague) error {
+	result := r.db.Omit("Members.Manager").Create(league)
+	return result.Error
+}
+
+// AddMember adds a manager to a mini-league
+func (r *miniLeagueRepository) AddMember(member *models.MiniLeagueMember) error {
+	result := r.db.Omit("Manager").Create(member)
+	return result.Error
+}
+
+// GetFixtures retrieves a head-to-head mini-league's fixtures in week order
+func (r *miniLeagueRepository) GetFixtures(leagueID int) ([]models.HeadToHeadFixture, error) {
+	var fixtures []models.HeadToHeadFixture
+	result := r.db.Where("league_id = ?", leagueID).Order("week ASC, id ASC").Find(&fixtures)
+	return fixtures, result.Error
+}
+
+// CreateFixtures adds a batch of head-to-head fixtures to the database
+func (r *miniLeagueRepository) CreateFixtures(fixtures []models.HeadToHeadFixture) error {
+	if len(fixtures) == 0 {
+		return nil
+	}
+	result := r.db.Create(&fixtures)
+	return result.Error
+}
+
+// SaveFixtures updates the results of head-to-head fixtures
+func (r *miniLeagueRepository) SaveFixtures(fixtures []models.HeadToHeadFixture) error {
+	if len(fixtures) == 0 {
+		return nil
+	}
+	result := r.db.Save(&fixtures)
+	return result.Error
+}
+
+// ResetSeason removes every head-to-head fixture and starts every mini-league from the first week again
+func (r *miniLeagueRepository) ResetSeason() error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.HeadToHeadFixture{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.MiniLeague{}).Where("1 = 1").Update("start_week", 1).Error
+	})
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"insider-league/models"
+
+	"gorm.io/gorm"
+)
+
+// PlayerRepository defines the interface for player data operations
+type PlayerRepository interface {
+	GetAll() ([]models.Player, error)
+	GetByID(id int) (*models.Player, error)
+	GetByTeam(teamID int) ([]models.Player, error)
+	GetByIDs(ids []uint) ([]models.Player, error)
+	Create(player *models.Player) error
+	Update(player *models.Player) error
+	Delete(id int) error
+}
+
+// playerRepository implements PlayerRepository interface
+type playerRepository struct {
+	db *gorm.DB
+}
+
+// NewPlayerRepository creates a new instance of playerRepository
+func NewPlayerRepository(db *gorm.DB) PlayerRepository {
+	return &playerRepository{
+		db: db,
+	}
+}
+
+// GetAll retrieves all players from the database
+func (r *playerRepository) GetAll() ([]models.Player, error) {
+	var players []models.Player
+	result := r.db.Order("team_id, shirt_number").Find(&players)
+	return players, result.Error
+}
+
+// GetByID retrieves a player by its ID
+func (r *playerRepository) GetByID(id int) (*models.Player, error) {
+	var player models.Player
+	result := r.db.First(&player, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &player, nil
+}
+
+// GetByTeam retrieves the squad of a team ordered by shirt number
+func (r *playerRepository) GetByTeam(teamID int) ([]models.Player, error) {
+	var players []models.Player
+	result := r.db.Where("team_id = ?", teamID).Order("shirt_number").Find(&players)
+	return players, result.Error
+}
+
+// GetByIDs retrieves the players with the given IDs; unknown IDs are skipped
+func (r *playerRepository) GetByIDs(ids []uint) ([]models.Player, error) {
+	var players []models.Player
+	result := r.db.Where("id IN ?", ids).Find(&players)
+	return players, result.Error
+}
+
+// Create adds a new player to the database
+func (r *playerRepository) Create(player *models.Player) error {
+	result := r.db.Create(player)
+	return result.Error
+}
+
+// Update modifies an existing player in the database
+func (r *playerRepository) Update(player *models.Player) error {
+	result := r.db.Save(player)
+	return result.Error
+}
+
+// Delete removes a player from the database by its ID
+func (r *playerRepository) Delete(id int) error {
+	result := r.db.Delete(&models.Player{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
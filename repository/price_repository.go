@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"insider-league/models"
+
+	"gorm.io/gorm"
+)
+
+// PriceRepository defines the interface for player price history data operations
+type PriceRepository interface {
+	GetAll() ([]models.PriceChange, error)
+	GetByPlayer(playerID int) ([]models.PriceChange, error)
+	GetByWeek(week int) ([]models.PriceChange, error)
+	Create(changes []models.PriceChange) error
+	DeleteAll() error
+}
+
+// priceRepository implements PriceRepository interface
+type priceRepository struct {
+	db *gorm.DB
+}
+
+// NewPriceRepository creates a new instance of priceRepository
+func NewPriceRepository(db *gorm.DB) PriceRepository {
+	return &priceRepository{
+		db: db,
+	}
+}
+
+// GetAll retrieves every price change, oldest first
+func (r *priceRepository) GetAll() ([]models.PriceChange, error) {
+	var changes []models.PriceChange
+	result := r.db.Order("id ASC").Find(&changes)
+	return changes, result.Error
+}
+
+// GetByPlayer retrieves a player's price changes, oldest first
+func (r *priceRepository) GetByPlayer(playerID int) ([]models.PriceChange, error) {
+	var changes []models.PriceChange
+	result := r.db.Where("player_id = ?", playerID).Order("id ASC").Find(&changes)
+	return changes, result.Error
+}
+
+// GetByWeek retrieves the price changes made after a gameweek with their players
+func (r *priceRepository) GetByWeek(week int) ([]models.PriceChange, error) {
+	var changes []models.PriceChange
+	result := r.db.Preload("Player").Where("week = ?", week).Order("id ASC").Find(&changes)
+	return changes, result.Error
+}
+
+// Create adds a batch of price changes to the database
+func (r *priceRepository) Create(changes []models.PriceChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	result := r.db.Omit("Player").Create(&changes)
+	return result.Error
+}
+
+// DeleteAll removes the whole price history
+func (r *priceRepository) DeleteAll() error {
+	result := r.db.Where("1 = 1").Delete(&models.PriceChange{})
+	return result.Error
+}
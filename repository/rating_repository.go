@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"insider-league/models"
+
+	"gorm.io/gorm"
+)
+
+// RatingRepository defines the interface for rating history data operations
+type RatingRepository interface {
+	GetByTeam(teamID int) ([]models.TeamRating, error)
+	Create(ratings []models.TeamRating) error
+	DeleteAll() error
+}
+
+// ratingRepository implements RatingRepository interface
+type ratingRepository struct {
+	db *gorm.DB
+}
+
+// NewRatingRepository creates a new instance of ratingRepository
+func NewRatingRepository(db *gorm.DB) RatingRepository {
+	return &ratingRepository{
+		db: db,
+	}
+}
+
+// GetByTeam retrieves the rating history of a team in chronological order
+func (r *ratingRepository) GetByTeam(teamID int) ([]models.TeamRating, error) {
+	var ratings []models.TeamRating
+	result := r.db.Where("team_id = ?", teamID).Order("id ASC").Find(&ratings)
+	return ratings, result.Error
+}
+
+// Create adds rating history entries to the database
+func (r *ratingRepository) Create(ratings []models.TeamRating) error {
+	if len(ratings) == 0 {
+		return nil
+	}
+	result := r.db.Create(&ratings)
+	return result.Error
+}
+
+// DeleteAll removes the complete rating history from the database
+func (r *ratingRepository) DeleteAll() error {
+	result := r.db.Where("1 = 1").Delete(&models.TeamRating{})
+	return result.Error
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"insider-league/models"
+
+	"gorm.io/gorm"
+)
+
+// ScorePredictionRepository defines the interface for score prediction data operations
+type ScorePredictionRepository interface {
+	GetAll() ([]models.ScorePrediction, error)
+	GetByUser(userID int) ([]models.ScorePrediction, error)
+	GetByWeek(week int) ([]models.ScorePrediction, error)
+	GetByMatch(matchID int) ([]models.ScorePrediction, error)
+	GetByUserAndMatch(userID, matchID int) (*models.ScorePrediction, error)
+	Create(prediction *models.ScorePrediction) error
+	Update(prediction *models.ScorePrediction) error
+	DeleteAll() error
+}
+
+// scorePredictionRepository implements ScorePredictionRepository interface
+type scorePredictionRepository struct {
+	db *gorm.DB
+}
+
+// NewScorePredictionRepository creates a new instance of scorePredictionRepository
+func NewScorePredictionRepository(db *gorm.DB) ScorePredictionRepository {
+	return &scorePredictionRepository{
+		db: db,
+	}
+}
+
+// GetAll retrieves every score prediction
+func (r *scorePredictionRepository) GetAll() ([]models.ScorePrediction, error) {
+	var predictions []models.ScorePrediction
+	result := r.db.Find(&predictions)
+	return predictions, result.Error
+}
+
+// GetByUser retrieves a user's predictions with their matches, in week order
+func (r *scorePredictionRepository) GetByUser(userID int) ([]models.ScorePrediction, error) {
+	var predictions []models.ScorePrediction
+	result := r.db.Preload("Match.HomeTeam").Preload("Match.AwayTeam").
+		Where("user_id = ?", userID).Order("week, match_id").Find(&predictions)
+	return predictions, result.Error
+}
+
+// GetByWeek retrieves every prediction for the matches of a week with their matches
+func (r *scorePredictionRepository) GetByWeek(week int) ([]models.ScorePrediction, error) {
+	var predictions []models.ScorePrediction
+	result := r.db.Preload("Match.HomeTeam").Preload("Match.AwayTeam").
+		Where("week = ?", week).Order("match_id, user_id").Find(&predictions)
+	return predictions, result.Error
+}
+
+// GetByMatch retrieves every prediction for a match
+func (r *scorePredictionRepository) GetByMatch(matchID int) ([]models.ScorePrediction, error) {
+	var predictions []models.ScorePrediction
+	result := r.db.Where("match_id = ?", matchID).Find(&predictions)
+	return predictions, result.Error
+}
+
+// GetByUserAndMatch retrieves a user's prediction for a match
+func (r *scorePredictionRepository) GetByUserAndMatch(userID, matchID int) (*models.ScorePrediction, error) {
+	var prediction models.ScorePrediction
+	result := r.db.Where("user_id = ? AND match_id = ?", userID, matchID).First(&prediction)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &prediction, nil
+}
+
+// Create adds a new score prediction to the database
+func (r *scorePredictionRepository) Create(prediction *models.ScorePrediction) error {
+	result := r.db.Create(prediction)
+	return result.Error
+}
+
+// Update updates an existing score prediction in the database
+func (r *scorePredictionRepository) Update(prediction *models.ScorePrediction) error {
+	result := r.db.Omit("Match").Save(prediction)
+	return result.Error
+}
+
+// DeleteAll removes every score prediction, starting a new season
+func (r *scorePredictionRepository) DeleteAll() error {
+	result := r.db.Where("1 = 1").Delete(&models.ScorePrediction{})
+	return result.Error
+}
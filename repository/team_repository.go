@@ -12,6 +12,7 @@ type TeamRepository interface {
 	GetByID(id int) (*models.Team, error)
 	Create(team *models.Team) error
 	Update(team *models.Team) error
+	UpdateRatings(team *models.Team) error
 	Delete(id int) error
 }
 
@@ -56,6 +57,12 @@ func (r *teamRepository) Update(team *models.Team) error {
 	return result.Error
 }
 
+// UpdateRatings stores only a team's attack, defence, strength and baseline strength, leaving its results alone
+func (r *teamRepository) UpdateRatings(team *models.Team) error {
+	result := r.db.Model(team).Select("attack", "defence", "strength", "base_strength").Updates(team)
+	return result.Error
+}
+
 // Delete removes a team from the database by its ID
 func (r *teamRepository) Delete(id int) error {
 	result := r.db.Delete(&models.Team{}, id)
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"insider-league/models"
+
+	"gorm.io/gorm"
+)
+
+// TransferRepository defines the interface for fantasy transfer data operations
+type TransferRepository interface {
+	GetByManager(managerID int) ([]models.Transfer, error)
+	GetCost(managerID, week int) (int, error)
+	GetByWeek(week int) ([]models.Transfer, error)
+	Create(squad *models.FantasySquad, transfers []models.Transfer, bank float64, freeTransfersUsed int) error
+	DeleteAll() error
+}
+
+// transferRepository implements TransferRepository interface
+type transferRepository struct {
+	db *gorm.DB
+}
+
+// NewTransferRepository creates a new instance of transferRepository
+func NewTransferRepository(db *gorm.DB) TransferRepository {
+	return &transferRepository{
+		db: db,
+	}
+}
+
+// GetByManager retrieves a manager's transfer history with both players, oldest first
+func (r *transferRepository) GetByManager(managerID int) ([]models.Transfer, error) {
+	var transfers []models.Transfer
+	result := r.db.Preload("PlayerOut").Preload("PlayerIn").
+		Where("manager_id = ?", managerID).
+		Order("id ASC").
+		Find(&transfers)
+	return transfers, result.Error
+}
+
+// GetCost sums the points deducted for a manager's transfers in a gameweek
+func (r *transferRepository) GetCost(managerID, week int) (int, error) {
+	var cost int
+	result := r.db.Model(&models.Transfer{}).
+		Where("manager_id = ? AND week = ?", managerID, week).
+		Select("COALESCE(SUM(cost), 0)").
+		Scan(&cost)
+	return cost, result.Error
+}
+
+// GetByWeek retrieves every manager's transfers for a gameweek
+func (r *transferRepository) GetByWeek(week int) ([]models.Transfer, error) {
+	var transfers []models.Transfer
+	result := r.db.Where("week = ?", week).Order("id ASC").Find(&transfers)
+	return transfers, result.Error
+}
+
+// Create adds a batch of transfers to the database together with the squad they leave and the manager's new
+// bank in one transaction, so a squad is never changed without its transfers being paid for. Only the bank and
+// free transfers columns are written, and the free transfers used are taken off whatever is stored.
+func (r *transferRepository) Create(squad *models.FantasySquad, transfers []models.Transfer, bank float64, freeTransfersUsed int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := replaceSquadPlayers(tx, squad); err != nil {
+			return err
+		}
+		if err := tx.Omit("PlayerOut", "PlayerIn").Create(&transfers).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Manager{}).Where("id = ?", squad.ManagerID).Updates(map[string]any{
+			"bank":           bank,
+			"free_transfers": gorm.Expr("free_transfers - ?", freeTransfersUsed),
+		}).Error
+	})
+}
+
+// DeleteAll removes every transfer
+func (r *transferRepository) DeleteAll() error {
+	result := r.db.Where("1 = 1").Delete(&models.Transfer{})
+	return result.Error
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"insider-league/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserRepository defines the interface for user account and refresh token data operations
+type UserRepository interface {
+	GetAll() ([]models.User, error)
+	GetByID(id int) (*models.User, error)
+	GetByEmail(email string) (*models.User, error)
+	Create(user *models.User) error
+	Update(user *models.User) error
+	CreateRefreshToken(token *models.RefreshToken) error
+	RevokeRefreshToken(tokenID string) error
+}
+
+// userRepository implements UserRepository interface
+type userRepository struct {
+	db *gorm.DB
+}
+
+// NewUserRepository creates a new instance of userRepository
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepository{
+		db: db,
+	}
+}
+
+// GetAll retrieves all users
+func (r *userRepository) GetAll() ([]models.User, error) {
+	var users []models.User
+	result := r.db.Order("id").Find(&users)
+	return users, result.Error
+}
+
+// GetByID retrieves a user by its ID
+func (r *userRepository) GetByID(id int) (*models.User, error) {
+	var user models.User
+	result := r.db.First(&user, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
+// GetByEmail retrieves a user by email address
+func (r *userRepository) GetByEmail(email string) (*models.User, error) {
+	var user models.User
+	result := r.db.Where("email = ?", email).First(&user)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
+// Create adds a new user to the database
+func (r *userRepository) Create(user *models.User) error {
+	result := r.db.Create(user)
+	return result.Error
+}
+
+// Update updates an existing user in the database
+func (r *userRepository) Update(user *models.User) error {
+	result := r.db.Save(user)
+	return result.Error
+}
+
+// CreateRefreshToken records an issued refresh token
+func (r *userRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	result := r.db.Create(token)
+	return result.Error
+}
+
+// RevokeRefreshToken marks a refresh token as no longer usable in a single conditional update, so two requests
+// can never both revoke it. It returns gorm.ErrRecordNotFound when the token is unknown or already revoked.
+func (r *userRepository) RevokeRefreshToken(tokenID string) error {
+	result := r.db.Model(&models.RefreshToken{}).
+		Where("token_id = ? AND revoked_at IS NULL", tokenID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"insider-league/models"
+
+	"gorm.io/gorm"
+)
+
+// WebhookRepository defines the interface for webhook subscription and delivery data operations
+type WebhookRepository interface {
+	GetAll() ([]models.WebhookSubscription, error)
+	GetByID(id int) (*models.WebhookSubscription, error)
+	Create(subscription *models.WebhookSubscription) error
+	Delete(id int) error
+	CreateDelivery(delivery *models.WebhookDelivery) error
+	GetDeliveries(subscriptionID int) ([]models.WebhookDelivery, error)
+}
+
+// webhookRepository implements WebhookRepository interface
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new instance of webhookRepository
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{
+		db: db,
+	}
+}
+
+// GetAll retrieves all webhook subscriptions from the database
+func (r *webhookRepository) GetAll() ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	result := r.db.Find(&subscriptions)
+	return subscriptions, result.Error
+}
+
+// GetByID retrieves a webhook subscription by its ID
+func (r *webhookRepository) GetByID(id int) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	result := r.db.First(&subscription, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &subscription, nil
+}
+
+// Create adds a new webhook subscription to the database
+func (r *webhookRepository) Create(subscription *models.WebhookSubscription) error {
+	result := r.db.Create(subscription)
+	return result.Error
+}
+
+// Delete removes a webhook subscription from the database by its ID
+func (r *webhookRepository) Delete(id int) error {
+	result := r.db.Delete(&models.WebhookSubscription{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CreateDelivery records a delivery attempt in the database
+func (r *webhookRepository) CreateDelivery(delivery *models.WebhookDelivery) error {
+	result := r.db.Create(delivery)
+	return result.Error
+}
+
+// GetDeliveries retrieves the delivery log of a subscription, newest first
+func (r *webhookRepository) GetDeliveries(subscriptionID int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	result := r.db.Where("subscription_id = ?", subscriptionID).Order("id DESC").Find(&deliveries)
+	return deliveries, result.Error
+}
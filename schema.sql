@@ -2,7 +2,11 @@
 CREATE TABLE teams (
     id SERIAL PRIMARY KEY,
     name VARCHAR(255) NOT NULL,
+    attack INTEGER NOT NULL DEFAULT 0,
+    defence INTEGER NOT NULL DEFAULT 0,
     strength INTEGER NOT NULL,
+    base_strength INTEGER NOT NULL DEFAULT 0,
+    rating DOUBLE PRECISION NOT NULL DEFAULT 1500,
     points INTEGER NOT NULL DEFAULT 0,
     goals_for INTEGER NOT NULL DEFAULT 0,
     goals_against INTEGER NOT NULL DEFAULT 0,
@@ -20,10 +24,340 @@ CREATE TABLE matches (
     away_team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
     home_team_score INTEGER NOT NULL DEFAULT 0,
     away_team_score INTEGER NOT NULL DEFAULT 0,
-    is_played BOOLEAN NOT NULL DEFAULT false
+    is_played BOOLEAN NOT NULL DEFAULT false,
+    home_strength INTEGER NOT NULL DEFAULT 0,
+    away_strength INTEGER NOT NULL DEFAULT 0,
+    home_expected_goals DOUBLE PRECISION NOT NULL DEFAULT 0,
+    away_expected_goals DOUBLE PRECISION NOT NULL DEFAULT 0
+);
+
+-- Team rating history table
+CREATE TABLE team_ratings (
+    id SERIAL PRIMARY KEY,
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    match_id INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    rating_before DOUBLE PRECISION NOT NULL,
+    rating_after DOUBLE PRECISION NOT NULL,
+    change DOUBLE PRECISION NOT NULL
+);
+
+-- Match events table
+CREATE TABLE match_events (
+    id SERIAL PRIMARY KEY,
+    match_id INTEGER NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    minute INTEGER NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    team_id INTEGER NOT NULL DEFAULT 0,
+    player_id INTEGER,
+    assist_player_id INTEGER,
+    home_score INTEGER NOT NULL DEFAULT 0,
+    away_score INTEGER NOT NULL DEFAULT 0
+);
+
+-- Players table
+CREATE TABLE players (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    position VARCHAR(3) NOT NULL,
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    rating INTEGER NOT NULL DEFAULT 0,
+    shirt_number INTEGER NOT NULL,
+    price DOUBLE PRECISION NOT NULL DEFAULT 0,
+    UNIQUE (team_id, shirt_number)
+);
+
+-- User accounts table
+CREATE TABLE users (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    name VARCHAR(255),
+    password_hash VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'manager',
+    created_at TIMESTAMPTZ
+);
+
+-- Issued refresh tokens table
+CREATE TABLE refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    token_id VARCHAR(64) NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+
+-- API keys table; only a SHA-256 hash of each key is stored
+CREATE TABLE api_keys (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(20) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    scope VARCHAR(20) NOT NULL,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+
+-- Fantasy managers table
+CREATE TABLE managers (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    team_name VARCHAR(255) NOT NULL,
+    bank DOUBLE PRECISION NOT NULL DEFAULT 0,
+    total_points INTEGER NOT NULL DEFAULT 0,
+    free_transfers INTEGER NOT NULL DEFAULT 0,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ
+);
+
+-- Fantasy squads table
+CREATE TABLE fantasy_squads (
+    id SERIAL PRIMARY KEY,
+    manager_id INTEGER NOT NULL UNIQUE REFERENCES managers(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+-- Fantasy squad players table
+CREATE TABLE fantasy_squad_players (
+    id SERIAL PRIMARY KEY,
+    squad_id INTEGER NOT NULL REFERENCES fantasy_squads(id) ON DELETE CASCADE,
+    player_id INTEGER NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    purchase_price DOUBLE PRECISION NOT NULL DEFAULT 0
+);
+
+-- Player gameweeks table
+CREATE TABLE player_gameweeks (
+    id SERIAL PRIMARY KEY,
+    week INTEGER NOT NULL,
+    match_id INTEGER NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    player_id INTEGER NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    team_id INTEGER NOT NULL REFERENCES teams(id),
+    position VARCHAR(3) NOT NULL,
+    minutes INTEGER NOT NULL DEFAULT 0,
+    goals INTEGER NOT NULL DEFAULT 0,
+    assists INTEGER NOT NULL DEFAULT 0,
+    clean_sheet BOOLEAN NOT NULL DEFAULT false,
+    goals_conceded INTEGER NOT NULL DEFAULT 0,
+    saves INTEGER NOT NULL DEFAULT 0,
+    yellow_cards INTEGER NOT NULL DEFAULT 0,
+    red_cards INTEGER NOT NULL DEFAULT 0,
+    points INTEGER NOT NULL DEFAULT 0
+);
+
+-- Fantasy picks table
+CREATE TABLE fantasy_picks (
+    id SERIAL PRIMARY KEY,
+    manager_id INTEGER NOT NULL REFERENCES managers(id) ON DELETE CASCADE,
+    week INTEGER NOT NULL,
+    player_id INTEGER NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    slot INTEGER NOT NULL DEFAULT 0,
+    is_captain BOOLEAN NOT NULL DEFAULT false,
+    is_vice_captain BOOLEAN NOT NULL DEFAULT false,
+    points INTEGER NOT NULL DEFAULT 0,
+    multiplier INTEGER NOT NULL DEFAULT 0,
+    auto_subbed BOOLEAN NOT NULL DEFAULT false
+);
+
+-- Manager gameweeks table
+CREATE TABLE manager_gameweeks (
+    id SERIAL PRIMARY KEY,
+    manager_id INTEGER NOT NULL REFERENCES managers(id) ON DELETE CASCADE,
+    week INTEGER NOT NULL,
+    points INTEGER NOT NULL DEFAULT 0,
+    transfer_cost INTEGER NOT NULL DEFAULT 0,
+    chip VARCHAR(32),
+    updated_at TIMESTAMPTZ
+);
+
+-- Fantasy transfers table
+CREATE TABLE transfers (
+    id SERIAL PRIMARY KEY,
+    manager_id INTEGER NOT NULL REFERENCES managers(id) ON DELETE CASCADE,
+    week INTEGER NOT NULL,
+    player_out_id INTEGER NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    player_in_id INTEGER NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    selling_price DOUBLE PRECISION NOT NULL DEFAULT 0,
+    purchase_price DOUBLE PRECISION NOT NULL DEFAULT 0,
+    cost INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ
+);
+
+-- Fantasy lineups table
+CREATE TABLE lineups (
+    id SERIAL PRIMARY KEY,
+    manager_id INTEGER NOT NULL REFERENCES managers(id) ON DELETE CASCADE,
+    week INTEGER NOT NULL,
+    starters TEXT,
+    bench TEXT,
+    captain_id INTEGER NOT NULL REFERENCES players(id),
+    vice_captain_id INTEGER NOT NULL REFERENCES players(id),
+    updated_at TIMESTAMPTZ
+);
+
+-- Fantasy chip activations table
+CREATE TABLE chip_activations (
+    id SERIAL PRIMARY KEY,
+    manager_id INTEGER NOT NULL REFERENCES managers(id) ON DELETE CASCADE,
+    week INTEGER NOT NULL,
+    chip VARCHAR(32) NOT NULL,
+    saved_players TEXT,
+    saved_bank DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ
+);
+
+-- Fantasy mini-leagues table
+CREATE TABLE mini_leagues (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    code VARCHAR(16) NOT NULL UNIQUE,
+    type VARCHAR(32) NOT NULL DEFAULT 'classic',
+    start_week INTEGER NOT NULL DEFAULT 1,
+    creator_manager_id INTEGER NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+-- Fantasy mini-league members table
+CREATE TABLE mini_league_members (
+    id SERIAL PRIMARY KEY,
+    league_id INTEGER NOT NULL REFERENCES mini_leagues(id) ON DELETE CASCADE,
+    manager_id INTEGER NOT NULL REFERENCES managers(id) ON DELETE CASCADE,
+    joined_at TIMESTAMPTZ
+);
+
+-- Head-to-head mini-league fixtures table
+CREATE TABLE head_to_head_fixtures (
+    id SERIAL PRIMARY KEY,
+    league_id INTEGER NOT NULL REFERENCES mini_leagues(id) ON DELETE CASCADE,
+    week INTEGER NOT NULL,
+    home_manager_id INTEGER NOT NULL REFERENCES managers(id) ON DELETE CASCADE,
+    away_manager_id INTEGER NOT NULL REFERENCES managers(id) ON DELETE CASCADE,
+    home_points INTEGER NOT NULL DEFAULT 0,
+    away_points INTEGER NOT NULL DEFAULT 0,
+    is_played BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- Fantasy player price changes table
+CREATE TABLE price_changes (
+    id SERIAL PRIMARY KEY,
+    player_id INTEGER NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    week INTEGER NOT NULL,
+    old_price DOUBLE PRECISION NOT NULL,
+    new_price DOUBLE PRECISION NOT NULL,
+    net_transfers INTEGER NOT NULL DEFAULT 0,
+    form DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ
+);
+
+-- Score predictions table
+CREATE TABLE score_predictions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    match_id INTEGER NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    week INTEGER NOT NULL,
+    home_goals INTEGER NOT NULL,
+    away_goals INTEGER NOT NULL,
+    points INTEGER,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+-- Betting wallets table
+CREATE TABLE wallets (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    balance DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+-- Bets table
+CREATE TABLE bets (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    match_id INTEGER NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    market VARCHAR(50) NOT NULL,
+    selection VARCHAR(50) NOT NULL,
+    stake DOUBLE PRECISION NOT NULL,
+    odds DOUBLE PRECISION NOT NULL,
+    potential_return DOUBLE PRECISION NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    payout DOUBLE PRECISION NOT NULL DEFAULT 0,
+    settled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+
+-- Wallet ledger table
+CREATE TABLE wallet_transactions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    bet_id INTEGER REFERENCES bets(id) ON DELETE SET NULL,
+    type VARCHAR(20) NOT NULL,
+    amount DOUBLE PRECISION NOT NULL,
+    balance DOUBLE PRECISION NOT NULL,
+    description VARCHAR(255),
+    created_at TIMESTAMPTZ
+);
+
+-- Webhook subscriptions table
+CREATE TABLE webhook_subscriptions (
+    id SERIAL PRIMARY KEY,
+    target_url TEXT NOT NULL,
+    events TEXT,
+    secret VARCHAR(128) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ
+);
+
+-- Webhook delivery log table
+CREATE TABLE webhook_deliveries (
+    id SERIAL PRIMARY KEY,
+    subscription_id INTEGER NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    delivery_id VARCHAR(64) NOT NULL,
+    event VARCHAR(32) NOT NULL,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    success BOOLEAN NOT NULL DEFAULT false,
+    error TEXT,
+    payload TEXT,
+    created_at TIMESTAMPTZ
 );
 
 -- Add indexes for better query performance
 CREATE INDEX idx_matches_week ON matches(week);
 CREATE INDEX idx_matches_home_team_id ON matches(home_team_id);
-CREATE INDEX idx_matches_away_team_id ON matches(away_team_id); 
\ No newline at end of file
+CREATE INDEX idx_matches_away_team_id ON matches(away_team_id); 
+CREATE INDEX idx_team_ratings_team_id ON team_ratings(team_id);
+CREATE INDEX idx_match_events_match_id ON match_events(match_id);
+CREATE INDEX idx_webhook_deliveries_subscription_id ON webhook_deliveries(subscription_id);
+CREATE INDEX idx_players_team_id ON players(team_id);
+CREATE INDEX idx_match_events_player_id ON match_events(player_id);
+CREATE INDEX idx_match_events_assist_player_id ON match_events(assist_player_id);
+CREATE INDEX idx_fantasy_squad_players_squad_id ON fantasy_squad_players(squad_id);
+CREATE INDEX idx_fantasy_squad_players_player_id ON fantasy_squad_players(player_id);
+CREATE INDEX idx_player_gameweeks_week ON player_gameweeks(week);
+CREATE INDEX idx_player_gameweeks_match_id ON player_gameweeks(match_id);
+CREATE INDEX idx_player_gameweeks_player_id ON player_gameweeks(player_id);
+CREATE INDEX idx_fantasy_picks_manager_week ON fantasy_picks(manager_id, week);
+CREATE UNIQUE INDEX idx_manager_gameweeks_manager_week ON manager_gameweeks(manager_id, week);
+CREATE INDEX idx_transfers_manager_id ON transfers(manager_id);
+CREATE UNIQUE INDEX idx_lineups_manager_week ON lineups(manager_id, week);
+CREATE UNIQUE INDEX idx_chip_activations_manager_week ON chip_activations(manager_id, week);
+CREATE UNIQUE INDEX idx_mini_league_members_league_manager ON mini_league_members(league_id, manager_id);
+CREATE INDEX idx_mini_league_members_manager_id ON mini_league_members(manager_id);
+CREATE INDEX idx_head_to_head_fixtures_league_id ON head_to_head_fixtures(league_id);
+CREATE INDEX idx_head_to_head_fixtures_week ON head_to_head_fixtures(week);
+CREATE INDEX idx_price_changes_player_id ON price_changes(player_id);
+CREATE INDEX idx_price_changes_week ON price_changes(week);
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+CREATE INDEX idx_managers_user_id ON managers(user_id);
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
+CREATE UNIQUE INDEX idx_score_predictions_user_match ON score_predictions(user_id, match_id);
+CREATE INDEX idx_score_predictions_match_id ON score_predictions(match_id);
+CREATE INDEX idx_score_predictions_week ON score_predictions(week);
+CREATE INDEX idx_bets_user_id ON bets(user_id);
+CREATE INDEX idx_bets_match_id ON bets(match_id);
+CREATE INDEX idx_wallet_transactions_user_id ON wallet_transactions(user_id);
+CREATE INDEX idx_wallet_transactions_bet_id ON wallet_transactions(bet_id);
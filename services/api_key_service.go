@@ -0,0 +1,130 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"insider-league/models"
+	"insider-league/repository"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// apiKeyPrefix starts every API key so leaked keys are easy to spot
+const apiKeyPrefix = "ilk_"
+
+var (
+	// ErrInvalidAPIKeyRequest is returned when issuing a key without a name or with an unknown scope
+	ErrInvalidAPIKeyRequest = errors.New("invalid API key request")
+
+	// ErrInvalidAPIKey is returned when an API key is unknown or revoked
+	ErrInvalidAPIKey = errors.New("invalid or revoked API key")
+)
+
+// APIKeyService defines the interface for issuing, revoking and checking API keys
+type APIKeyService interface {
+	Create(user *models.User, req models.APIKeyRequest) (*models.IssuedAPIKey, error)
+	GetByUser(userID int) ([]models.APIKey, error)
+	Revoke(userID, keyID int) error
+	Authenticate(key string) (*models.User, *models.APIKey, error)
+}
+
+// apiKeyService implements APIKeyService interface
+type apiKeyService struct {
+	repo     repository.APIKeyRepository
+	userRepo repository.UserRepository
+}
+
+// NewAPIKeyService creates a new instance of apiKeyService
+func NewAPIKeyService(repo repository.APIKeyRepository, userRepo repository.UserRepository) APIKeyService {
+	return &apiKeyService{
+		repo:     repo,
+		userRepo: userRepo,
+	}
+}
+
+// Create issues a new API key to a user. The full key is only returned here; just its hash is stored.
+func (s *apiKeyService) Create(user *models.User, req models.APIKeyRequest) (*models.IssuedAPIKey, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: a name is required", ErrInvalidAPIKeyRequest)
+	}
+	if !slices.Contains(models.Scopes, req.Scope) {
+		return nil, fmt.Errorf("%w: the scope must be one of %s", ErrInvalidAPIKeyRequest, strings.Join(models.Scopes, ", "))
+	}
+
+	secret, err := randomHex(24)
+	if err != nil {
+		return nil, err
+	}
+	key := apiKeyPrefix + secret
+
+	apiKey := &models.APIKey{
+		UserID:  user.ID,
+		Name:    name,
+		Prefix:  key[:len(apiKeyPrefix)+8],
+		KeyHash: hashAPIKey(key),
+		Scope:   req.Scope,
+	}
+	if err := s.repo.Create(apiKey); err != nil {
+		return nil, err
+	}
+	return &models.IssuedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+// GetByUser returns the API keys issued to a user, including revoked ones
+func (s *apiKeyService) GetByUser(userID int) ([]models.APIKey, error) {
+	return s.repo.GetByUser(userID)
+}
+
+// Revoke stops an API key from working; a key issued to someone else is treated as not found
+func (s *apiKeyService) Revoke(userID, keyID int) error {
+	key, err := s.repo.GetByID(keyID)
+	if err != nil {
+		return err
+	}
+	if int(key.UserID) != userID {
+		return gorm.ErrRecordNotFound
+	}
+	return s.repo.Revoke(keyID)
+}
+
+// Authenticate returns the owner of an API key along with the key, and records that the key was used
+func (s *apiKeyService) Authenticate(key string) (*models.User, *models.APIKey, error) {
+	apiKey, err := s.repo.GetByHash(hashAPIKey(key))
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if apiKey.RevokedAt != nil {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	user, err := s.userRepo.GetByID(int(apiKey.UserID))
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if err := s.repo.TouchLastUsed(int(apiKey.ID), now); err != nil {
+		return nil, nil, err
+	}
+	apiKey.LastUsedAt = &now
+	return user, apiKey, nil
+}
+
+// hashAPIKey returns the SHA-256 hash stored for an API key. Keys are long and random, so a fast hash is enough
+// and lets a key be looked up directly.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,325 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"insider-league/models"
+	"insider-league/repository"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// Token types carried in the typ claim, so a refresh token cannot be used as an access token or the other way round
+const (
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
+)
+
+var (
+	// ErrInvalidUser is returned when a registration has an invalid email address or too short a password
+	ErrInvalidUser = errors.New("invalid user")
+
+	// ErrEmailTaken is returned when registering with an email address that already has an account
+	ErrEmailTaken = errors.New("an account with this email address already exists")
+
+	// ErrInvalidCredentials is returned when an email address and password do not match an account
+	ErrInvalidCredentials = errors.New("invalid email or password")
+
+	// ErrInvalidToken is returned when a token is malformed, expired, revoked or of the wrong type
+	ErrInvalidToken = errors.New("invalid or expired token")
+
+	// ErrInvalidRole is returned when assigning a role that is not in the permission matrix
+	ErrInvalidRole = errors.New("invalid role")
+
+	// ErrOwnRole is returned when a user tries to change their own role, which could lock out the last admin
+	ErrOwnRole = errors.New("you cannot change your own role")
+)
+
+// AuthConfig holds the token and password settings of user accounts
+type AuthConfig struct {
+	// Secret signs and verifies every token
+	Secret []byte
+
+	// AccessTTL and RefreshTTL are how long access and refresh tokens stay valid
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+
+	// MinPasswordLength is the shortest password accepted at registration
+	MinPasswordLength int
+
+	// BcryptCost is the work factor passwords are hashed with
+	BcryptCost int
+
+	// AdminEmail and AdminPassword, when set, are the admin account seeded at startup; everyone who registers
+	// starts as a manager
+	AdminEmail    string
+	AdminPassword string
+}
+
+// DefaultAuthConfig returns the account settings used when nothing is overridden; the secret must still be set
+func DefaultAuthConfig() AuthConfig {
+	return AuthConfig{
+		AccessTTL:         15 * time.Minute,
+		RefreshTTL:        7 * 24 * time.Hour,
+		MinPasswordLength: 8,
+		BcryptCost:        bcrypt.DefaultCost,
+	}
+}
+
+// tokenClaims are the claims carried by access and refresh tokens
+type tokenClaims struct {
+	Type string `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+// AuthService defines the interface for user account and token operations
+type AuthService interface {
+	Register(credentials models.Credentials) (*models.TokenPair, error)
+	Login(credentials models.Credentials) (*models.TokenPair, error)
+	Refresh(refreshToken string) (*models.TokenPair, error)
+	Logout(refreshToken string) error
+	Authenticate(accessToken string) (*models.User, error)
+	GetUsers() ([]models.User, error)
+	SetRole(actor *models.User, userID int, role string) (*models.User, error)
+	SeedAdmin() error
+}
+
+// authService implements AuthService interface
+type authService struct {
+	repo   repository.UserRepository
+	config AuthConfig
+}
+
+// NewAuthService creates a new instance of authService
+func NewAuthService(repo repository.UserRepository, config AuthConfig) AuthService {
+	return &authService{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// Register creates an account with a bcrypt-hashed password and signs it in
+func (s *authService) Register(credentials models.Credentials) (*models.TokenPair, error) {
+	email := normalizeEmail(credentials.Email)
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return nil, fmt.Errorf("%w: %q is not a valid email address", ErrInvalidUser, credentials.Email)
+	}
+	if len(credentials.Password) < s.config.MinPasswordLength {
+		return nil, fmt.Errorf("%w: the password needs at least %d characters", ErrInvalidUser, s.config.MinPasswordLength)
+	}
+
+	if _, err := s.repo.GetByEmail(email); err == nil {
+		return nil, ErrEmailTaken
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(credentials.Password), s.config.BcryptCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{Email: email, Name: strings.TrimSpace(credentials.Name), PasswordHash: string(hash), Role: models.RoleManager}
+	if err := s.repo.Create(user); err != nil {
+		return nil, err
+	}
+	return s.issueTokens(user)
+}
+
+// Login checks an email address and password and hands out a fresh pair of tokens
+func (s *authService) Login(credentials models.Credentials) (*models.TokenPair, error) {
+	user, err := s.repo.GetByEmail(normalizeEmail(credentials.Email))
+	if err == gorm.ErrRecordNotFound {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(credentials.Password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	return s.issueTokens(user)
+}
+
+// Refresh swaps a refresh token for a new pair. The old refresh token is revoked, so each one works only once.
+func (s *authService) Refresh(refreshToken string) (*models.TokenPair, error) {
+	claims, err := s.parseToken(refreshToken, tokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	// Revoking is the check: only the request that revokes the token gets a new pair
+	if err := s.repo.RevokeRefreshToken(claims.ID); err == gorm.ErrRecordNotFound {
+		return nil, ErrInvalidToken
+	} else if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userFromClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+	return s.issueTokens(user)
+}
+
+// Logout revokes a refresh token; access tokens already handed out stay valid until they expire.
+// Logging out with a token that is already revoked succeeds.
+func (s *authService) Logout(refreshToken string) error {
+	claims, err := s.parseToken(refreshToken, tokenTypeRefresh)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.RevokeRefreshToken(claims.ID); err != nil && err != gorm.ErrRecordNotFound {
+		return err
+	}
+	return nil
+}
+
+// Authenticate returns the user an access token was issued to
+func (s *authService) Authenticate(accessToken string) (*models.User, error) {
+	claims, err := s.parseToken(accessToken, tokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+	return s.userFromClaims(claims)
+}
+
+// GetUsers returns every account with its role
+func (s *authService) GetUsers() ([]models.User, error) {
+	return s.repo.GetAll()
+}
+
+// SetRole changes the role of a user. Nobody can change their own role, so an admin cannot demote themselves by mistake.
+func (s *authService) SetRole(actor *models.User, userID int, role string) (*models.User, error) {
+	if !ValidRole(role) {
+		return nil, fmt.Errorf("%w: %q is not one of %s", ErrInvalidRole, role, strings.Join(models.Roles, ", "))
+	}
+	if actor != nil && int(actor.ID) == userID {
+		return nil, ErrOwnRole
+	}
+
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	user.Role = role
+	if err := s.repo.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// SeedAdmin makes sure the configured admin account exists when the server starts. A missing account is created
+// with the admin password; an existing one is only made an admin if the admin password is its password, so
+// registering the address first does not make anybody an admin.
+func (s *authService) SeedAdmin() error {
+	if s.config.AdminEmail == "" {
+		return nil
+	}
+	if len(s.config.AdminPassword) < s.config.MinPasswordLength {
+		return fmt.Errorf("%w: the admin password needs at least %d characters", ErrInvalidUser, s.config.MinPasswordLength)
+	}
+
+	email := normalizeEmail(s.config.AdminEmail)
+	user, err := s.repo.GetByEmail(email)
+	if err == gorm.ErrRecordNotFound {
+		hash, err := bcrypt.GenerateFromPassword([]byte(s.config.AdminPassword), s.config.BcryptCost)
+		if err != nil {
+			return err
+		}
+		return s.repo.Create(&models.User{Email: email, Name: "Admin", PasswordHash: string(hash), Role: models.RoleAdmin})
+	}
+	if err != nil {
+		return err
+	}
+
+	if user.Role == models.RoleAdmin {
+		return nil
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(s.config.AdminPassword)); err != nil {
+		return fmt.Errorf("%w: %s is already registered with another password", ErrInvalidCredentials, email)
+	}
+	user.Role = models.RoleAdmin
+	return s.repo.Update(user)
+}
+
+// issueTokens signs a new access and refresh token for a user and records the refresh token
+func (s *authService) issueTokens(user *models.User) (*models.TokenPair, error) {
+	now := time.Now()
+
+	accessToken, err := s.signToken(user, tokenTypeAccess, "", now, s.config.AccessTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenID, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := s.signToken(user, tokenTypeRefresh, tokenID, now, s.config.RefreshTTL)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateRefreshToken(&models.RefreshToken{TokenID: tokenID, UserID: user.ID, ExpiresAt: now.Add(s.config.RefreshTTL)}); err != nil {
+		return nil, err
+	}
+
+	return &models.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(s.config.AccessTTL.Seconds()),
+		User:         user,
+	}, nil
+}
+
+// signToken builds and signs a token of the given type for a user
+func (s *authService) signToken(user *models.User, tokenType, tokenID string, now time.Time, ttl time.Duration) (string, error) {
+	claims := tokenClaims{
+		Type: tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(int(user.ID)),
+			ID:        tokenID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.config.Secret)
+}
+
+// parseToken verifies a token's signature, expiry and type
+func (s *authService) parseToken(token, tokenType string) (*tokenClaims, error) {
+	claims := &tokenClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return s.config.Secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || claims.Type != tokenType {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// userFromClaims loads the user a token's subject names; a deleted account makes the token invalid
+func (s *authService) userFromClaims(claims *tokenClaims) (*models.User, error) {
+	id, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	user, err := s.repo.GetByID(id)
+	if err == gorm.ErrRecordNotFound {
+		return nil, ErrInvalidToken
+	}
+	return user, err
+}
+
+// normalizeEmail trims and lower-cases an email address so lookups ignore case
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
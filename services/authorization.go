@@ -0,0 +1,59 @@
+package services
+
+import (
+	"errors"
+	"insider-league/models"
+	"slices"
+)
+
+// ErrNotManagerOwner is returned when an account changes a fantasy manager registered by another account
+var ErrNotManagerOwner = errors.New("only the account that registered this manager, or an admin, can change it")
+
+// ValidRole reports whether a role is one of the known roles
+func ValidRole(role string) bool {
+	return slices.Contains(models.Roles, role)
+}
+
+// HasPermission reports whether a role is granted a permission by the permission matrix
+func HasPermission(role, permission string) bool {
+	return slices.Contains(models.RolePermissions[role], permission)
+}
+
+// RolesWithPermission lists the roles granted a permission, most privileged first
+func RolesWithPermission(permission string) []string {
+	var roles []string
+	for _, role := range models.Roles {
+		if HasPermission(role, permission) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// CanManage reports whether a user may change a fantasy manager: admins may change any, everyone else only the
+// managers their account registered
+func CanManage(user *models.User, manager *models.Manager) bool {
+	if user == nil {
+		return false
+	}
+	if user.Role == models.RoleAdmin {
+		return true
+	}
+	return manager.UserID != nil && *manager.UserID == user.ID
+}
+
+// ScopeAllows reports whether an API key scope can use a permission
+func ScopeAllows(scope, permission string) bool {
+	return slices.Contains(models.ScopePermissions[scope], permission)
+}
+
+// ScopesWithPermission lists the API key scopes that can use a permission
+func ScopesWithPermission(permission string) []string {
+	var scopes []string
+	for _, scope := range models.Scopes {
+		if ScopeAllows(scope, permission) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
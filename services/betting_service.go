@@ -0,0 +1,334 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"insider-league/eventbus"
+	"insider-league/models"
+	"insider-league/repository"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidBet is returned when a bet's stake is too small or its market or selection is not priced
+	ErrInvalidBet = errors.New("invalid bet")
+
+	// ErrInsufficientFunds is returned when a stake is more than the wallet holds
+	ErrInsufficientFunds = errors.New("insufficient funds")
+
+	// ErrOddsChanged is returned when the odds a bettor saw are no longer the current price
+	ErrOddsChanged = errors.New("the odds have changed")
+)
+
+// BettingConfig holds the rules of virtual betting
+type BettingConfig struct {
+	// StartingBalance is the virtual currency every wallet opens with
+	StartingBalance float64
+
+	// MinStake is the smallest bet accepted
+	MinStake float64
+}
+
+// DefaultBettingConfig returns the betting rules used when nothing is overridden
+func DefaultBettingConfig() BettingConfig {
+	return BettingConfig{
+		StartingBalance: 1000,
+		MinStake:        1,
+	}
+}
+
+// BettingService defines the interface for virtual betting operations
+type BettingService interface {
+	GetWallet(userID int) (*models.Wallet, error)
+	GetTransactions(userID int) ([]models.WalletTransaction, error)
+	GetBets(userID int) ([]models.Bet, error)
+	PlaceBet(user *models.User, req models.BetRequest) (*models.Bet, error)
+	HandleEvent(event eventbus.Event)
+}
+
+// bettingService implements BettingService interface
+type bettingService struct {
+	repo   repository.BettingRepository
+	odds   OddsService
+	config BettingConfig
+
+	// mu serialises balance changes, so a bet and a settlement never overwrite each other's balance
+	mu sync.Mutex
+}
+
+// NewBettingService creates a new instance of bettingService
+func NewBettingService(repo repository.BettingRepository, odds OddsService, config BettingConfig) BettingService {
+	return &bettingService{
+		repo:   repo,
+		odds:   odds,
+		config: config,
+	}
+}
+
+// GetWallet returns a user's wallet, opening it with the starting balance on first use
+func (s *bettingService) GetWallet(userID int) (*models.Wallet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.wallet(userID)
+}
+
+// GetTransactions returns a user's ledger, newest first
+func (s *bettingService) GetTransactions(userID int) ([]models.WalletTransaction, error) {
+	if _, err := s.GetWallet(userID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTransactions(userID)
+}
+
+// GetBets returns a user's bets, newest first
+func (s *bettingService) GetBets(userID int) ([]models.Bet, error) {
+	return s.repo.GetBetsByUser(userID)
+}
+
+// PlaceBet stakes virtual currency on a selection of an unplayed match at its current odds
+func (s *bettingService) PlaceBet(user *models.User, req models.BetRequest) (*models.Bet, error) {
+	stake := roundMoney(req.Stake)
+	if stake < s.config.MinStake {
+		return nil, fmt.Errorf("%w: the stake must be at least %.2f", ErrInvalidBet, s.config.MinStake)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	odds, err := s.odds.GetMatchOdds(int(req.MatchID), nil)
+	if err != nil {
+		return nil, err
+	}
+	price, ok := findPrice(odds, req.Market, req.Selection)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q in %q is not offered", ErrInvalidBet, req.Selection, req.Market)
+	}
+	if req.Odds != 0 && math.Abs(req.Odds-price.Decimal) > 1e-9 {
+		return nil, fmt.Errorf("%w: %s is now priced at %.2f", ErrOddsChanged, req.Selection, price.Decimal)
+	}
+
+	wallet, err := s.wallet(int(user.ID))
+	if err != nil {
+		return nil, err
+	}
+	if wallet.Balance < stake {
+		return nil, fmt.Errorf("%w: the wallet holds %.2f", ErrInsufficientFunds, wallet.Balance)
+	}
+
+	wallet.Balance = roundMoney(wallet.Balance - stake)
+	bet := &models.Bet{
+		UserID:          user.ID,
+		MatchID:         req.MatchID,
+		Market:          req.Market,
+		Selection:       req.Selection,
+		Stake:           stake,
+		Odds:            price.Decimal,
+		PotentialReturn: roundMoney(stake * price.Decimal),
+		Status:          models.BetPending,
+	}
+	entry := &models.WalletTransaction{
+		UserID:      user.ID,
+		Type:        models.TransactionStake,
+		Amount:      -stake,
+		Balance:     wallet.Balance,
+		Description: fmt.Sprintf("%s v %s, %s: %s at %.2f", odds.HomeTeam, odds.AwayTeam, req.Market, req.Selection, price.Decimal),
+	}
+	if err := s.repo.SaveBet(wallet, bet, entry); err != nil {
+		return nil, err
+	}
+	return bet, nil
+}
+
+// HandleEvent settles bets when a week is played, resettles them when a result is edited, and voids the open
+// ones when the league is reset. Every event also settles any pending bet on a played match, so a settlement
+// that failed is retried by the next one.
+func (s *bettingService) HandleEvent(event eventbus.Event) {
+	var err error
+	switch event.Type {
+	case eventbus.WeekPlayed:
+		err = s.settlePending()
+	case eventbus.MatchResultEdited:
+		if err = s.settleMatches(event.Matches); err == nil {
+			err = s.settlePending()
+		}
+	case eventbus.LeagueReset:
+		err = s.voidOpenBets()
+	}
+	if err != nil {
+		log.Printf("Failed to settle bets after %s: %v", event.Type, err)
+	}
+}
+
+// settlePending settles every pending bet whose match has been played
+func (s *bettingService) settlePending() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bets, err := s.repo.GetPendingBets()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range bets {
+		if bets[i].Match == nil || !bets[i].Match.IsPlayed {
+			continue
+		}
+		if err := s.settleBet(&bets[i], bets[i].Match, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// settleMatches settles every bet on the given played matches. A bet already settled whose outcome changes has
+// its payout paid or taken back, so the ledger always matches the latest results.
+func (s *bettingService) settleMatches(matches []models.Match) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for i := range matches {
+		if !matches[i].IsPlayed {
+			continue
+		}
+
+		bets, err := s.repo.GetBetsByMatch(int(matches[i].ID))
+		if err != nil {
+			return err
+		}
+		for j := range bets {
+			if bets[j].Final {
+				continue
+			}
+			if err := s.settleBet(&bets[j], &matches[i], now); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// settleBet settles a bet on a match's result, paying out a new winner or taking back the payout of a bet that no
+// longer wins. Nothing is stored when the outcome is unchanged, so settling twice is harmless.
+func (s *bettingService) settleBet(bet *models.Bet, match *models.Match, now time.Time) error {
+	won, err := s.odds.SelectionWon(bet.Market, bet.Selection, match.HomeTeamScore, match.AwayTeamScore)
+	if err != nil {
+		return err
+	}
+	status := models.BetLost
+	if won {
+		status = models.BetWon
+	}
+	if status == bet.Status {
+		return nil
+	}
+
+	wallet, err := s.wallet(int(bet.UserID))
+	if err != nil {
+		return err
+	}
+
+	var entry *models.WalletTransaction
+	switch {
+	case status == models.BetWon:
+		bet.Payout = bet.PotentialReturn
+		entry = &models.WalletTransaction{Type: models.TransactionPayout, Amount: bet.Payout, Description: fmt.Sprintf("Bet %d won", bet.ID)}
+	case bet.Status == models.BetWon:
+		entry = &models.WalletTransaction{Type: models.TransactionPayoutReversal, Amount: -bet.Payout, Description: fmt.Sprintf("Bet %d lost after a result edit", bet.ID)}
+		bet.Payout = 0
+	}
+	if entry != nil {
+		wallet.Balance = roundMoney(wallet.Balance + entry.Amount)
+		entry.UserID = bet.UserID
+		entry.Balance = wallet.Balance
+	}
+
+	bet.Status = status
+	bet.SettledAt = &now
+	return s.repo.SaveBet(wallet, bet, entry)
+}
+
+// voidOpenBets refunds the stake of every pending bet and makes every bet final, since a reset wipes out the
+// results they were placed on. Wallets and ledgers are kept.
+func (s *bettingService) voidOpenBets() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bets, err := s.repo.GetPendingBets()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range bets {
+		bet := &bets[i]
+		wallet, err := s.wallet(int(bet.UserID))
+		if err != nil {
+			return err
+		}
+
+		wallet.Balance = roundMoney(wallet.Balance + bet.Stake)
+		entry := &models.WalletTransaction{
+			UserID:      bet.UserID,
+			Type:        models.TransactionRefund,
+			Amount:      bet.Stake,
+			Balance:     wallet.Balance,
+			Description: fmt.Sprintf("Bet %d void after the league was reset", bet.ID),
+		}
+		bet.Status = models.BetVoid
+		bet.Payout = bet.Stake
+		bet.Final = true
+		bet.SettledAt = &now
+		if err := s.repo.SaveBet(wallet, bet, entry); err != nil {
+			return err
+		}
+	}
+	return s.repo.FinalizeBets()
+}
+
+// wallet returns a user's wallet, opening it with the starting balance and its ledger entry on first use
+func (s *bettingService) wallet(userID int) (*models.Wallet, error) {
+	wallet, err := s.repo.GetWallet(userID)
+	if err != gorm.ErrRecordNotFound {
+		return wallet, err
+	}
+
+	wallet = &models.Wallet{UserID: uint(userID), Balance: s.config.StartingBalance}
+	entry := &models.WalletTransaction{
+		UserID:      uint(userID),
+		Type:        models.TransactionGrant,
+		Amount:      s.config.StartingBalance,
+		Balance:     s.config.StartingBalance,
+		Description: "Opening balance",
+	}
+	if err := s.repo.CreateWallet(wallet, entry); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// findPrice returns the priced selection of a market
+func findPrice(odds *models.MatchOdds, market, selection string) (models.OddsPrice, bool) {
+	for _, m := range odds.Markets {
+		if m.Name != market {
+			continue
+		}
+		for _, price := range m.Selections {
+			if price.Selection == selection {
+				return price, true
+			}
+		}
+	}
+	return models.OddsPrice{}, false
+}
+
+// roundMoney rounds an amount of virtual currency to the cent
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
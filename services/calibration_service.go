@@ -0,0 +1,287 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"insider-league/eventbus"
+	"insider-league/helpers"
+	"insider-league/models"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInsufficientResults is returned when there are too few results to fit team strengths
+var ErrInsufficientResults = errors.New("no played matches available to calibrate team strengths")
+
+// ErrInvalidResultsFile is returned when an uploaded results file cannot be parsed
+var ErrInvalidResultsFile = errors.New("invalid results file")
+
+const (
+	// calibrationMaxIterations bounds the number of fitting passes
+	calibrationMaxIterations = 500
+
+	// calibrationTolerance is the largest parameter change accepted as converged
+	calibrationTolerance = 1e-6
+
+	// calibrationBaseRating is the attack and defence rating given to an average team
+	calibrationBaseRating = 80
+)
+
+// CalibrationService defines the interface for fitting team strengths to results
+type CalibrationService interface {
+	CalibrateFromMatches(apply bool) (*models.CalibrationResult, error)
+	CalibrateFromCSV(reader io.Reader, apply bool) (*models.CalibrationResult, error)
+}
+
+// calibrationService implements CalibrationService interface
+type calibrationService struct {
+	teamService  TeamService
+	matchService MatchService
+	bus          *eventbus.Bus
+	lock         *LeagueLock
+}
+
+// NewCalibrationService creates a new instance of calibrationService
+func NewCalibrationService(teamService TeamService, matchService MatchService, bus *eventbus.Bus, lock *LeagueLock) CalibrationService {
+	return &calibrationService{
+		teamService:  teamService,
+		matchService: matchService,
+		bus:          bus,
+		lock:         lock,
+	}
+}
+
+// CalibrateFromMatches fits team strengths to the played matches in the database
+func (s *calibrationService) CalibrateFromMatches(apply bool) (*models.CalibrationResult, error) {
+	// Applying changes team strengths, so it must not overlap a week being played or a result being edited
+	if apply {
+		if !s.lock.TryLock() {
+			return nil, ErrLeagueBusy
+		}
+		defer s.lock.Unlock()
+	}
+
+	matches, err := s.matchService.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []models.MatchResult
+	for _, match := range matches {
+		if !match.IsPlayed {
+			continue
+		}
+		results = append(results, models.MatchResult{
+			HomeTeamID: match.HomeTeamID,
+			AwayTeamID: match.AwayTeamID,
+			HomeGoals:  match.HomeTeamScore,
+			AwayGoals:  match.AwayTeamScore,
+		})
+	}
+
+	return s.calibrate(results, apply)
+}
+
+// CalibrateFromCSV fits team strengths to results read from a CSV file.
+// The file must have a header row with home_team, away_team, home_goals and away_goals columns,
+// where team names match existing teams.
+func (s *calibrationService) CalibrateFromCSV(reader io.Reader, apply bool) (*models.CalibrationResult, error) {
+	if apply {
+		if !s.lock.TryLock() {
+			return nil, ErrLeagueBusy
+		}
+		defer s.lock.Unlock()
+	}
+
+	teams, err := s.teamService.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	teamIDs := make(map[string]uint, len(teams))
+	for _, team := range teams {
+		teamIDs[strings.ToLower(strings.TrimSpace(team.Name))] = team.ID
+	}
+
+	rows, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResultsFile, err)
+	}
+	if len(rows) < 2 {
+		return nil, fmt.Errorf("%w: no results found", ErrInvalidResultsFile)
+	}
+
+	// Locate the required columns from the header row
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"home_team", "away_team", "home_goals", "away_goals"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing %s column", ErrInvalidResultsFile, required)
+		}
+	}
+
+	results := make([]models.MatchResult, 0, len(rows)-1)
+	for line, row := range rows[1:] {
+		lineNumber := line + 2
+
+		homeID, ok := teamIDs[strings.ToLower(strings.TrimSpace(row[columns["home_team"]]))]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown home team on line %d", ErrInvalidResultsFile, lineNumber)
+		}
+		awayID, ok := teamIDs[strings.ToLower(strings.TrimSpace(row[columns["away_team"]]))]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown away team on line %d", ErrInvalidResultsFile, lineNumber)
+		}
+
+		homeGoals, err := strconv.Atoi(strings.TrimSpace(row[columns["home_goals"]]))
+		if err != nil || homeGoals < 0 {
+			return nil, fmt.Errorf("%w: invalid home goals on line %d", ErrInvalidResultsFile, lineNumber)
+		}
+		awayGoals, err := strconv.Atoi(strings.TrimSpace(row[columns["away_goals"]]))
+		if err != nil || awayGoals < 0 {
+			return nil, fmt.Errorf("%w: invalid away goals on line %d", ErrInvalidResultsFile, lineNumber)
+		}
+
+		results = append(results, models.MatchResult{
+			HomeTeamID: homeID,
+			AwayTeamID: awayID,
+			HomeGoals:  homeGoals,
+			AwayGoals:  awayGoals,
+		})
+	}
+
+	return s.calibrate(results, apply)
+}
+
+// calibrate fits the Poisson model, builds diagnostics and optionally stores the new strengths
+func (s *calibrationService) calibrate(results []models.MatchResult, apply bool) (*models.CalibrationResult, error) {
+	if len(results) == 0 {
+		return nil, ErrInsufficientResults
+	}
+
+	teams, err := s.teamService.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	fit := helpers.FitPoissonRatings(results, calibrationMaxIterations, calibrationTolerance)
+
+	result := &models.CalibrationResult{
+		BaseRate:      fit.BaseRate,
+		HomeAdvantage: fit.HomeAdvantage,
+		Diagnostics: models.CalibrationDiagnostics{
+			Matches:    len(results),
+			Iterations: fit.Iterations,
+			Converged:  fit.Converged,
+		},
+	}
+
+	// Goodness of fit against a model that predicts the average goal rate for everyone
+	fittedByID := make(map[uint]*models.FittedTeam)
+	totalGoals := 0
+	for _, r := range results {
+		totalGoals += r.HomeGoals + r.AwayGoals
+	}
+	nullRate := math.Max(float64(totalGoals)/float64(2*len(results)), 1e-9)
+
+	for i := range teams {
+		if _, ok := fit.Attack[teams[i].ID]; !ok {
+			continue
+		}
+		fittedByID[teams[i].ID] = &models.FittedTeam{
+			TeamID:           teams[i].ID,
+			TeamName:         teams[i].Name,
+			Attack:           fit.Attack[teams[i].ID],
+			Defence:          fit.Defence[teams[i].ID],
+			AttackRating:     fittedRating(fit.Attack[teams[i].ID]),
+			DefenceRating:    fittedRating(fit.Defence[teams[i].ID]),
+			PreviousStrength: teams[i].Strength,
+		}
+		fittedByID[teams[i].ID].Strength = (fittedByID[teams[i].ID].AttackRating + fittedByID[teams[i].ID].DefenceRating + 1) / 2
+	}
+
+	for _, r := range results {
+		expectedHome, expectedAway := fit.ExpectedGoals(r.HomeTeamID, r.AwayTeamID)
+
+		diagnostics := &result.Diagnostics
+		diagnostics.LogLikelihood += helpers.PoissonLogLikelihood(r.HomeGoals, expectedHome) + helpers.PoissonLogLikelihood(r.AwayGoals, expectedAway)
+		diagnostics.NullLogLikelihood += helpers.PoissonLogLikelihood(r.HomeGoals, nullRate) + helpers.PoissonLogLikelihood(r.AwayGoals, nullRate)
+		diagnostics.Deviance += poissonDeviance(r.HomeGoals, expectedHome) + poissonDeviance(r.AwayGoals, expectedAway)
+		diagnostics.MeanAbsoluteError += math.Abs(float64(r.HomeGoals)-expectedHome) + math.Abs(float64(r.AwayGoals)-expectedAway)
+
+		if home, ok := fittedByID[r.HomeTeamID]; ok {
+			home.GoalsFor += r.HomeGoals
+			home.GoalsAgainst += r.AwayGoals
+			home.ExpectedFor += expectedHome
+			home.ExpectedAgainst += expectedAway
+		}
+		if away, ok := fittedByID[r.AwayTeamID]; ok {
+			away.GoalsFor += r.AwayGoals
+			away.GoalsAgainst += r.HomeGoals
+			away.ExpectedFor += expectedAway
+			away.ExpectedAgainst += expectedHome
+		}
+	}
+	result.Diagnostics.MeanAbsoluteError /= float64(2 * len(results))
+	if result.Diagnostics.NullLogLikelihood != 0 {
+		result.Diagnostics.PseudoRSquared = 1 - result.Diagnostics.LogLikelihood/result.Diagnostics.NullLogLikelihood
+	}
+
+	// Keep the league's team order in the response
+	for i := range teams {
+		if fitted, ok := fittedByID[teams[i].ID]; ok {
+			result.Teams = append(result.Teams, *fitted)
+		}
+	}
+
+	if !apply {
+		return result, nil
+	}
+
+	// Store the fitted strength as both the current strength and the baseline. Only the ratings are written, so
+	// the table is untouched. The fitted base rate and home advantage are reported but not applied, since the
+	// simulator keeps its own configured goal rate and home advantage.
+	for i := range teams {
+		fitted, ok := fittedByID[teams[i].ID]
+		if !ok {
+			continue
+		}
+		teams[i].Attack = fitted.AttackRating
+		teams[i].Defence = fitted.DefenceRating
+		teams[i].Strength = fitted.Strength
+		teams[i].BaseStrength = fitted.Strength
+		if err := s.teamService.SaveRatings(&teams[i]); err != nil {
+			return nil, err
+		}
+	}
+	result.Applied = true
+
+	// Announce the new strengths once for the whole league
+	leagueTable, err := s.teamService.GetTeamRankings()
+	if err != nil {
+		return nil, err
+	}
+	s.bus.Publish(eventbus.Event{Type: eventbus.TeamChanged, LeagueTable: leagueTable})
+
+	return result, nil
+}
+
+// fittedRating maps a fitted log-rate parameter onto the simulator's 1-100 rating scale
+func fittedRating(parameter float64) int {
+	rating := math.Round(calibrationBaseRating + helpers.RatingScale*parameter)
+	return int(math.Max(1, math.Min(100, rating)))
+}
+
+// poissonDeviance returns the deviance contribution of a single observed goal count
+func poissonDeviance(goals int, expected float64) float64 {
+	observed := float64(goals)
+	if goals == 0 {
+		return 2 * expected
+	}
+	return 2 * (observed*math.Log(observed/expected) - (observed - expected))
+}
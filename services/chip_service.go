@@ -0,0 +1,239 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"insider-league/eventbus"
+	"insider-league/models"
+	"insider-league/repository"
+	"log"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidChip is returned when an unknown chip is played
+	ErrInvalidChip = errors.New("unknown chip")
+
+	// ErrChipUnavailable is returned when a chip has already been used up for the season or half-season
+	ErrChipUnavailable = errors.New("chip has already been used")
+
+	// ErrChipAlreadyActive is returned when a chip is played for a week that already has one
+	ErrChipAlreadyActive = errors.New("only one chip can be played per gameweek")
+)
+
+// ChipService defines the interface for fantasy chip operations
+type ChipService interface {
+	GetInventory(managerID int) (*models.ChipInventory, error)
+	Activate(managerID, week int, chip string) (*models.ChipActivation, error)
+	HandleEvent(event eventbus.Event)
+}
+
+// chipService implements ChipService interface
+type chipService struct {
+	repo        repository.ChipRepository
+	managerRepo repository.ManagerRepository
+	squadRepo   repository.FantasySquadRepository
+	lineupRepo  repository.LineupRepository
+	matchRepo   repository.MatchRepository
+	weeks       *WeekStatus
+}
+
+// NewChipService creates a new instance of chipService
+func NewChipService(repo repository.ChipRepository, managerRepo repository.ManagerRepository, squadRepo repository.FantasySquadRepository, lineupRepo repository.LineupRepository, matchRepo repository.MatchRepository, weeks *WeekStatus) ChipService {
+	return &chipService{
+		repo:        repo,
+		managerRepo: managerRepo,
+		squadRepo:   squadRepo,
+		lineupRepo:  lineupRepo,
+		matchRepo:   matchRepo,
+		weeks:       weeks,
+	}
+}
+
+// GetInventory lists which chips an existing manager can still play and which one is active for the open gameweek
+func (s *chipService) GetInventory(managerID int) (*models.ChipInventory, error) {
+	if _, err := s.managerRepo.GetByID(managerID); err != nil {
+		return nil, err
+	}
+
+	deadline, err := openGameweek(s.matchRepo, s.weeks)
+	if err != nil {
+		return nil, err
+	}
+	activations, err := s.repo.GetByManager(managerID)
+	if err != nil {
+		return nil, err
+	}
+	halfway, err := s.halfway()
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &models.ChipInventory{Week: deadline.Week, Chips: []models.ChipStatus{}}
+	for _, chip := range models.Chips {
+		status := models.ChipStatus{Chip: chip, UsedWeeks: []int{}, Available: deadline.Open}
+		for _, activation := range activations {
+			if activation.Chip == chip {
+				status.UsedWeeks = append(status.UsedWeeks, activation.Week)
+			}
+		}
+		if deadline.Open && chipUsed(chip, deadline.Week, status.UsedWeeks, halfway) {
+			status.Available = false
+		}
+		inventory.Chips = append(inventory.Chips, status)
+	}
+	for _, activation := range activations {
+		if activation.Week == deadline.Week {
+			inventory.Active = activation.Chip
+		}
+	}
+	return inventory, nil
+}
+
+// Activate plays a chip for the open gameweek. A free hit remembers the current squad and bank so they can be
+// restored once the week is played.
+func (s *chipService) Activate(managerID, week int, chip string) (*models.ChipActivation, error) {
+	manager, err := s.managerRepo.GetByID(managerID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(models.Chips, chip) {
+		return nil, fmt.Errorf("%w %q; choose one of %v", ErrInvalidChip, chip, models.Chips)
+	}
+
+	week, err = deadlineWeek(s.matchRepo, s.weeks, week)
+	if err != nil {
+		return nil, err
+	}
+
+	squad, err := s.squadRepo.GetByManager(managerID)
+	if err != nil {
+		return nil, err
+	}
+
+	activations, err := s.repo.GetByManager(managerID)
+	if err != nil {
+		return nil, err
+	}
+	var usedWeeks []int
+	for _, activation := range activations {
+		if activation.Week == week {
+			return nil, fmt.Errorf("%w: %s is already active for gameweek %d", ErrChipAlreadyActive, activation.Chip, week)
+		}
+		if activation.Chip == chip {
+			usedWeeks = append(usedWeeks, activation.Week)
+		}
+	}
+	halfway, err := s.halfway()
+	if err != nil {
+		return nil, err
+	}
+	if chipUsed(chip, week, usedWeeks, halfway) {
+		if chip == models.ChipWildcard {
+			return nil, fmt.Errorf("%w: the wildcard can be played once in each half of the season", ErrChipUnavailable)
+		}
+		return nil, fmt.Errorf("%w: %s can be played once a season", ErrChipUnavailable, chip)
+	}
+
+	activation := &models.ChipActivation{ManagerID: manager.ID, Week: week, Chip: chip}
+	if chip == models.ChipFreeHit {
+		for _, squadPlayer := range squad.Players {
+			activation.SavedPlayers = append(activation.SavedPlayers, models.FantasySquadPlayer{
+				PlayerID:      squadPlayer.PlayerID,
+				PurchasePrice: squadPlayer.PurchasePrice,
+			})
+		}
+		activation.SavedBank = manager.Bank
+	}
+	if err := s.repo.Create(activation); err != nil {
+		return nil, err
+	}
+	return activation, nil
+}
+
+// HandleEvent restores free hit squads once their week is played and clears chips on reset.
+// It is meant to be subscribed to the event bus after the fantasy scoring, so the free hit squad is scored first.
+func (s *chipService) HandleEvent(event eventbus.Event) {
+	var err error
+	switch event.Type {
+	case eventbus.WeekPlayed:
+		err = s.revertFreeHits(event.Week)
+	case eventbus.LeagueReset:
+		err = s.repo.DeleteAll()
+	}
+	if err != nil {
+		log.Printf("Failed to update fantasy chips after %s: %v", event.Type, err)
+	}
+}
+
+// revertFreeHits puts back the squad, bank and lineup every free hit manager had before the week
+func (s *chipService) revertFreeHits(week int) error {
+	activations, err := s.repo.GetByWeek(week)
+	if err != nil {
+		return err
+	}
+
+	for _, activation := range activations {
+		if activation.Chip != models.ChipFreeHit {
+			continue
+		}
+
+		squad, err := s.squadRepo.GetByManager(int(activation.ManagerID))
+		if err != nil {
+			return err
+		}
+		squad.Players = activation.SavedPlayers
+		if err := s.squadRepo.ReplacePlayers(squad); err != nil {
+			return err
+		}
+
+		if err := s.managerRepo.UpdateBank(activation.ManagerID, activation.SavedBank); err != nil {
+			return err
+		}
+
+		// The week's picks are frozen, so dropping its lineup brings back the one set before the free hit
+		if err := s.lineupRepo.Delete(int(activation.ManagerID), week); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// halfway returns the last week of the first half of the season
+func (s *chipService) halfway() (int, error) {
+	weeks, err := seasonLength(s.matchRepo)
+	if err != nil {
+		return 0, err
+	}
+	return (weeks + 1) / 2, nil
+}
+
+// chipUsed reports whether a chip has been used up for the given week: the wildcard within the same
+// half of the season, any other chip at any point in the season
+func chipUsed(chip string, week int, usedWeeks []int, halfway int) bool {
+	if chip != models.ChipWildcard {
+		return len(usedWeeks) > 0
+	}
+	return slices.ContainsFunc(usedWeeks, func(used int) bool {
+		return (used <= halfway) == (week <= halfway)
+	})
+}
+
+// freeTransfersChip reports whether a chip makes every transfer of its week free
+func freeTransfersChip(chip string) bool {
+	return chip == models.ChipWildcard || chip == models.ChipFreeHit
+}
+
+// activeChip returns the chip a manager played for a week, or an empty string
+func activeChip(repo repository.ChipRepository, managerID, week int) (string, error) {
+	activation, err := repo.GetByManagerWeek(managerID, week)
+	if err == gorm.ErrRecordNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return activation.Chip, nil
+}
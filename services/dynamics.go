@@ -0,0 +1,109 @@
+package services
+
+import (
+	"insider-league/helpers"
+	"insider-league/models"
+	"math"
+	"math/rand"
+)
+
+// DynamicsConfig holds the parameters of the optional weekly strength drift
+type DynamicsConfig struct {
+	Enabled bool
+
+	// ResultWeight is the strength gained for a win (and lost for a defeat)
+	ResultWeight float64
+
+	// FormWeight scales how far recent points per game above or below 1.5 move strength
+	FormWeight float64
+
+	// FormMatches is the number of recent matches considered as form
+	FormMatches int
+
+	// ShockStdDev is the standard deviation of the random weekly shock
+	ShockStdDev float64
+
+	// RegressionRate is the fraction of the gap to the baseline closed every week
+	RegressionRate float64
+}
+
+// DefaultDynamicsConfig returns the default strength dynamics parameters with the mode disabled
+func DefaultDynamicsConfig() DynamicsConfig {
+	return DynamicsConfig{
+		Enabled:        false,
+		ResultWeight:   1.5,
+		FormWeight:     1.0,
+		FormMatches:    5,
+		ShockStdDev:    1.0,
+		RegressionRate: 0.2,
+	}
+}
+
+// applyStrengthDynamics drifts the strength of every team that played in the given week
+func (s *leagueService) applyStrengthDynamics(weekMatches []models.Match) error {
+	// Form is computed over the whole season, including the week just played
+	allMatches, err := s.matchService.GetAll()
+	if err != nil {
+		return err
+	}
+
+	for i := range weekMatches {
+		match := &weekMatches[i]
+
+		homeTeam := &match.HomeTeam
+		awayTeam := &match.AwayTeam
+
+		homeForm := helpers.RecentForm(allMatches, homeTeam.ID, s.config.Dynamics.FormMatches)
+		awayForm := helpers.RecentForm(allMatches, awayTeam.ID, s.config.Dynamics.FormMatches)
+
+		applyStrengthDrift(homeTeam, driftStrength(homeTeam, match.HomeTeamScore-match.AwayTeamScore, homeForm, s.config.Dynamics))
+		applyStrengthDrift(awayTeam, driftStrength(awayTeam, match.AwayTeamScore-match.HomeTeamScore, awayForm, s.config.Dynamics))
+
+		if err := s.teamService.Save(homeTeam); err != nil {
+			return err
+		}
+		if err := s.teamService.Save(awayTeam); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyStrengthDrift moves attack and defence by the same amount so the overall strength reaches the target
+func applyStrengthDrift(team *models.Team, target int) {
+	delta := target - team.Strength
+	team.Attack = clampRating(team.AttackRating() + delta)
+	team.Defence = clampRating(team.DefenceRating() + delta)
+	team.NormalizeRatings()
+}
+
+// clampRating keeps a rating within the range the simulator expects
+func clampRating(rating int) int {
+	return max(1, min(100, rating))
+}
+
+// driftStrength returns a team's new strength after a match with the given goal margin
+func driftStrength(team *models.Team, margin int, form float64, config DynamicsConfig) int {
+	strength := float64(team.Strength)
+
+	// Teams created before dynamics existed regress towards their current strength
+	baseline := float64(team.BaseStrength)
+	if team.BaseStrength == 0 {
+		baseline = strength
+	}
+
+	delta := 0.0
+	switch {
+	case margin > 0:
+		delta += config.ResultWeight
+	case margin < 0:
+		delta -= config.ResultWeight
+	}
+	delta += config.FormWeight * (form - 1.5)
+	delta += rand.NormFloat64() * config.ShockStdDev
+	delta -= config.RegressionRate * (strength - baseline)
+
+	// Keep strength within the range the simulator expects
+	return int(math.Max(1, math.Min(100, math.Round(strength+delta))))
+}
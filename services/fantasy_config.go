@@ -0,0 +1,93 @@
+package services
+
+import "insider-league/models"
+
+// FantasyConfig holds the squad-building rules of the fantasy game
+type FantasyConfig struct {
+	// SquadSize is the number of players every squad must have
+	SquadSize int
+
+	// Budget is the amount in millions a manager may spend on the initial squad
+	Budget float64
+
+	// MaxPerClub caps how many players may come from a single club
+	MaxPerClub int
+
+	// PositionQuotas is the exact number of players required per position
+	PositionQuotas map[string]int
+
+	// FreeTransfers is the free transfers a manager gets each gameweek; unused ones roll over up to MaxFreeTransfers
+	FreeTransfers    int
+	MaxFreeTransfers int
+
+	// TransferHit is the points deducted for every transfer beyond the free ones
+	TransferHit int
+
+	// StartingSize is the number of starters in a lineup, whose positions must stay within the formation limits
+	StartingSize     int
+	FormationMinimum map[string]int
+	FormationMaximum map[string]int
+
+	// CaptainMultiplier is how many times the captain's points count, TripleCaptainMultiplier with the triple captain chip
+	CaptainMultiplier       int
+	TripleCaptainMultiplier int
+
+	// PriceStep is how far a price moves in one change, never below PriceFloor or above PriceCeiling
+	PriceStep    float64
+	PriceFloor   float64
+	PriceCeiling float64
+
+	// PriceTransferThreshold is the share of managers whose net transfers in or out move a price
+	PriceTransferThreshold float64
+
+	// PriceFormWeeks is how many recent gameweeks form averages over; form of at least PriceRiseForm
+	// points a week moves a price up and form of at most PriceFallForm moves it down
+	PriceFormWeeks int
+	PriceRiseForm  float64
+	PriceFallForm  float64
+
+	// SellingProfitShare is the part of a price rise since purchase a manager keeps when selling
+	SellingProfitShare float64
+}
+
+// DefaultFantasyConfig returns the standard fantasy rules.
+// With only four clubs in the league, the per-club cap is raised to 5 so a 15-player squad stays possible.
+func DefaultFantasyConfig() FantasyConfig {
+	return FantasyConfig{
+		SquadSize:  15,
+		Budget:     100.0,
+		MaxPerClub: 5,
+		PositionQuotas: map[string]int{
+			models.PositionGoalkeeper: 2,
+			models.PositionDefender:   5,
+			models.PositionMidfielder: 5,
+			models.PositionForward:    3,
+		},
+		FreeTransfers:    1,
+		MaxFreeTransfers: 5,
+		TransferHit:      4,
+		StartingSize:     11,
+		FormationMinimum: map[string]int{
+			models.PositionGoalkeeper: 1,
+			models.PositionDefender:   3,
+			models.PositionMidfielder: 2,
+			models.PositionForward:    1,
+		},
+		FormationMaximum: map[string]int{
+			models.PositionGoalkeeper: 1,
+			models.PositionDefender:   5,
+			models.PositionMidfielder: 5,
+			models.PositionForward:    3,
+		},
+		CaptainMultiplier:       2,
+		TripleCaptainMultiplier: 3,
+		PriceStep:               0.1,
+		PriceFloor:              3.5,
+		PriceCeiling:            16.0,
+		PriceTransferThreshold:  0.1,
+		PriceFormWeeks:          3,
+		PriceRiseForm:           6,
+		PriceFallForm:           1,
+		SellingProfitShare:      0.5,
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"encoding/json"
+	"insider-league/models"
+	"os"
+)
+
+// FantasyScoringRules is the table that turns a player's match into fantasy points
+type FantasyScoringRules struct {
+	// AppearancePoints is awarded for playing at all, LongAppearancePoints instead from LongAppearanceMinutes on
+	AppearancePoints      int `json:"appearancePoints"`
+	LongAppearancePoints  int `json:"longAppearancePoints"`
+	LongAppearanceMinutes int `json:"longAppearanceMinutes"`
+
+	GoalPoints   map[string]int `json:"goalPoints"`
+	AssistPoints int            `json:"assistPoints"`
+
+	// CleanSheetPoints is awarded when no goal was conceded while the player was on for at least CleanSheetMinutes
+	CleanSheetPoints  map[string]int `json:"cleanSheetPoints"`
+	CleanSheetMinutes int            `json:"cleanSheetMinutes"`
+
+	// SavesPerPoint saves earn one point
+	SavesPerPoint int `json:"savesPerPoint"`
+
+	// Every GoalsConcededPerDeduction goals conceded cost GoalsConcededPoints, by position
+	GoalsConcededPerDeduction int            `json:"goalsConcededPerDeduction"`
+	GoalsConcededPoints       map[string]int `json:"goalsConcededPoints"`
+
+	YellowCardPoints int `json:"yellowCardPoints"`
+	RedCardPoints    int `json:"redCardPoints"`
+}
+
+// DefaultFantasyScoringRules returns the standard fantasy scoring table
+func DefaultFantasyScoringRules() FantasyScoringRules {
+	return FantasyScoringRules{
+		AppearancePoints:      1,
+		LongAppearancePoints:  2,
+		LongAppearanceMinutes: 60,
+		GoalPoints: map[string]int{
+			models.PositionGoalkeeper: 10,
+			models.PositionDefender:   6,
+			models.PositionMidfielder: 5,
+			models.PositionForward:    4,
+		},
+		AssistPoints: 3,
+		CleanSheetPoints: map[string]int{
+			models.PositionGoalkeeper: 4,
+			models.PositionDefender:   4,
+			models.PositionMidfielder: 1,
+		},
+		CleanSheetMinutes:         60,
+		SavesPerPoint:             3,
+		GoalsConcededPerDeduction: 2,
+		GoalsConcededPoints: map[string]int{
+			models.PositionGoalkeeper: -1,
+			models.PositionDefender:   -1,
+		},
+		YellowCardPoints: -1,
+		RedCardPoints:    -3,
+	}
+}
+
+// LoadFantasyScoringRules reads a JSON rules file; fields it leaves out keep their default values
+func LoadFantasyScoringRules(path string) (FantasyScoringRules, error) {
+	rules := DefaultFantasyScoringRules()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return rules, err
+	}
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return rules, err
+	}
+	return rules, nil
+}
+
+// Score marks the clean sheet and works out the fantasy points of a player's match
+func (r FantasyScoringRules) Score(record *models.PlayerGameweek) {
+	record.CleanSheet = record.Minutes >= r.CleanSheetMinutes && record.GoalsConceded == 0
+
+	points := 0
+	if record.Minutes > 0 {
+		points += r.AppearancePoints
+		if record.Minutes >= r.LongAppearanceMinutes {
+			points += r.LongAppearancePoints - r.AppearancePoints
+		}
+	}
+
+	points += record.Goals * r.GoalPoints[record.Position]
+	points += record.Assists * r.AssistPoints
+	if record.CleanSheet {
+		points += r.CleanSheetPoints[record.Position]
+	}
+	if r.SavesPerPoint > 0 {
+		points += record.Saves / r.SavesPerPoint
+	}
+	if r.GoalsConcededPerDeduction > 0 {
+		points += record.GoalsConceded / r.GoalsConcededPerDeduction * r.GoalsConcededPoints[record.Position]
+	}
+	points += record.YellowCards * r.YellowCardPoints
+	points += record.RedCards * r.RedCardPoints
+
+	record.Points = points
+}
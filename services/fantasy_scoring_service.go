@@ -0,0 +1,370 @@
+package services
+
+import (
+	"insider-league/eventbus"
+	"insider-league/helpers"
+	"insider-league/models"
+	"insider-league/repository"
+	"log"
+	"slices"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// FantasyScoringService defines the interface for gameweek fantasy scoring
+type FantasyScoringService interface {
+	GetRules() FantasyScoringRules
+	ScoreMatches(matches []models.Match) error
+	RescoreMatches(matches []models.Match) error
+	ClearAll() error
+	GetPlayerGameweeks(week int) ([]models.PlayerGameweek, error)
+	GetManagerGameweeks(managerID int) ([]models.ManagerGameweek, error)
+	GetManagerGameweek(managerID, week int) (*models.ManagerGameweek, error)
+	HandleEvent(event eventbus.Event)
+}
+
+// fantasyScoringService implements FantasyScoringService interface
+type fantasyScoringService struct {
+	repo         repository.FantasyPointsRepository
+	managerRepo  repository.ManagerRepository
+	squadRepo    repository.FantasySquadRepository
+	playerRepo   repository.PlayerRepository
+	eventRepo    repository.MatchEventRepository
+	transferRepo repository.TransferRepository
+	lineupRepo   repository.LineupRepository
+	chipRepo     repository.ChipRepository
+	config       FantasyConfig
+	rules        FantasyScoringRules
+}
+
+// NewFantasyScoringService creates a new instance of fantasyScoringService
+func NewFantasyScoringService(repo repository.FantasyPointsRepository, managerRepo repository.ManagerRepository, squadRepo repository.FantasySquadRepository, playerRepo repository.PlayerRepository, eventRepo repository.MatchEventRepository, transferRepo repository.TransferRepository, lineupRepo repository.LineupRepository, chipRepo repository.ChipRepository, config FantasyConfig, rules FantasyScoringRules) FantasyScoringService {
+	return &fantasyScoringService{
+		repo:         repo,
+		managerRepo:  managerRepo,
+		squadRepo:    squadRepo,
+		playerRepo:   playerRepo,
+		eventRepo:    eventRepo,
+		transferRepo: transferRepo,
+		lineupRepo:   lineupRepo,
+		chipRepo:     chipRepo,
+		config:       config,
+		rules:        rules,
+	}
+}
+
+// GetRules returns the scoring table in use
+func (s *fantasyScoringService) GetRules() FantasyScoringRules {
+	return s.rules
+}
+
+// HandleEvent scores played weeks, rescores edited results and clears scores on reset.
+// It is meant to be subscribed to the event bus.
+func (s *fantasyScoringService) HandleEvent(event eventbus.Event) {
+	var err error
+	switch event.Type {
+	case eventbus.WeekPlayed:
+		err = s.ScoreMatches(event.Matches)
+	case eventbus.MatchResultEdited:
+		err = s.RescoreMatches(event.Matches)
+	case eventbus.LeagueReset:
+		err = s.ClearAll()
+	}
+	if err != nil {
+		log.Printf("Failed to update fantasy scores after %s: %v", event.Type, err)
+	}
+}
+
+// ScoreMatches rebuilds the player performances of the given played matches and scores every manager for their
+// weeks, freezing each manager's picks from the squad and lineup in force
+func (s *fantasyScoringService) ScoreMatches(matches []models.Match) error {
+	return s.scoreMatches(matches, true)
+}
+
+// RescoreMatches rebuilds the player performances of edited matches and rescores the picks frozen when their weeks
+// were played. Managers without picks for a week were not playing then, so they are left out.
+func (s *fantasyScoringService) RescoreMatches(matches []models.Match) error {
+	return s.scoreMatches(matches, false)
+}
+
+// scoreMatches scores the given played matches and then the managers of their weeks
+func (s *fantasyScoringService) scoreMatches(matches []models.Match, freeze bool) error {
+	var weeks []int
+	for i := range matches {
+		if !matches[i].IsPlayed {
+			continue
+		}
+		if err := s.scoreMatch(&matches[i]); err != nil {
+			return err
+		}
+		if !slices.Contains(weeks, matches[i].Week) {
+			weeks = append(weeks, matches[i].Week)
+		}
+	}
+
+	for _, week := range weeks {
+		if err := s.scoreManagers(week, freeze); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scoreMatch replays a match's stored timeline against both squads and stores each player's points
+func (s *fantasyScoringService) scoreMatch(match *models.Match) error {
+	events, err := s.eventRepo.GetByMatch(int(match.ID))
+	if err != nil {
+		return err
+	}
+
+	homeSquad, err := s.playerRepo.GetByTeam(int(match.HomeTeamID))
+	if err != nil {
+		return err
+	}
+	awaySquad, err := s.playerRepo.GetByTeam(int(match.AwayTeamID))
+	if err != nil {
+		return err
+	}
+
+	records := helpers.BuildPlayerPerformances(match, events, homeSquad, awaySquad)
+	for i := range records {
+		s.rules.Score(&records[i])
+	}
+
+	return s.repo.ReplaceMatchPerformances(int(match.ID), records)
+}
+
+// scoreManagers totals every manager's team for a week less any transfer hits. When freeze is set, managers
+// without picks for the week have them frozen from the squad and the lineup in force; otherwise they are skipped.
+func (s *fantasyScoringService) scoreManagers(week int, freeze bool) error {
+	performances, err := s.repo.GetPlayerGameweeks(week)
+	if err != nil {
+		return err
+	}
+	points := make(map[uint]int)
+	minutes := make(map[uint]int)
+	for _, performance := range performances {
+		points[performance.PlayerID] += performance.Points
+		minutes[performance.PlayerID] += performance.Minutes
+	}
+
+	managers, err := s.managerRepo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	for i := range managers {
+		manager := &managers[i]
+
+		picks, err := s.repo.GetPicks(int(manager.ID), week)
+		if err != nil {
+			return err
+		}
+		if len(picks) == 0 {
+			if !freeze {
+				continue
+			}
+			picks, err = s.freezePicks(manager.ID, week)
+			if err == gorm.ErrRecordNotFound {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		chip, err := activeChip(s.chipRepo, int(manager.ID), week)
+		if err != nil {
+			return err
+		}
+
+		total := applyLineup(picks, points, minutes, chip, s.config)
+		if err := s.repo.SavePicks(picks); err != nil {
+			return err
+		}
+
+		// Extra transfers made ahead of the week come off its score, unless a wildcard or free hit covered them
+		cost := 0
+		if !freeTransfersChip(chip) {
+			cost, err = s.transferRepo.GetCost(int(manager.ID), week)
+			if err != nil {
+				return err
+			}
+		}
+		if err := s.repo.SaveManagerGameweek(&models.ManagerGameweek{ManagerID: manager.ID, Week: week, Points: total - cost, TransferCost: cost, Chip: chip}); err != nil {
+			return err
+		}
+
+		if err := s.updateTotal(manager); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// freezePicks turns a manager's squad and lineup for a week into picks, returning gorm.ErrRecordNotFound without a squad
+func (s *fantasyScoringService) freezePicks(managerID uint, week int) ([]models.FantasyPick, error) {
+	squad, err := s.squadRepo.GetByManager(int(managerID))
+	if err != nil {
+		return nil, err
+	}
+	saved, err := s.lineupRepo.GetLatest(int(managerID), week)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+	lineup := resolveLineup(squad, saved, s.config)
+
+	players := make(map[uint]models.Player)
+	for _, squadPlayer := range squad.Players {
+		players[squadPlayer.PlayerID] = squadPlayer.Player
+	}
+
+	var picks []models.FantasyPick
+	for i, id := range slices.Concat(lineup.Starters, lineup.Bench) {
+		picks = append(picks, models.FantasyPick{
+			ManagerID:     managerID,
+			Week:          week,
+			PlayerID:      id,
+			Player:        players[id],
+			Slot:          i + 1,
+			IsCaptain:     id == lineup.CaptainID,
+			IsViceCaptain: id == lineup.ViceCaptainID,
+		})
+	}
+	return picks, nil
+}
+
+// applyLineup works out each pick's points and multiplier and returns the team's total. Starters who did not
+// play are replaced by the first bench player who did and keeps the formation legal, and the vice-captain takes
+// the armband when the captain did not play. A bench boost counts the whole bench instead of substituting,
+// and a triple captain raises the captain's multiplier.
+func applyLineup(picks []models.FantasyPick, points, minutes map[uint]int, chip string, config FantasyConfig) int {
+	sort.Slice(picks, func(i, j int) bool {
+		return picks[i].Slot < picks[j].Slot
+	})
+
+	formation := make(map[string]int)
+	for i := range picks {
+		pick := &picks[i]
+		pick.Points = points[pick.PlayerID]
+		pick.AutoSubbed = false
+		pick.Multiplier = 0
+		if pick.Slot <= config.StartingSize || chip == models.ChipBenchBoost {
+			pick.Multiplier = 1
+			formation[pick.Player.Position]++
+		}
+	}
+
+	for i := range picks {
+		out := &picks[i]
+		if chip == models.ChipBenchBoost || out.Slot > config.StartingSize || minutes[out.PlayerID] > 0 {
+			continue
+		}
+		for j := range picks {
+			in := &picks[j]
+			if in.Slot <= config.StartingSize || in.AutoSubbed || minutes[in.PlayerID] == 0 {
+				continue
+			}
+			if !canSubstitute(formation, out.Player.Position, in.Player.Position, config) {
+				continue
+			}
+			formation[out.Player.Position]--
+			formation[in.Player.Position]++
+			out.Multiplier, in.Multiplier = 0, 1
+			out.AutoSubbed, in.AutoSubbed = true, true
+			break
+		}
+	}
+
+	captainMultiplier := config.CaptainMultiplier
+	if chip == models.ChipTripleCaptain {
+		captainMultiplier = config.TripleCaptainMultiplier
+	}
+	captain := slices.IndexFunc(picks, func(pick models.FantasyPick) bool { return pick.IsCaptain })
+	vice := slices.IndexFunc(picks, func(pick models.FantasyPick) bool { return pick.IsViceCaptain })
+	switch {
+	case captain >= 0 && minutes[picks[captain].PlayerID] > 0:
+		picks[captain].Multiplier = captainMultiplier
+	case vice >= 0 && minutes[picks[vice].PlayerID] > 0 && picks[vice].Multiplier > 0:
+		picks[vice].Multiplier = captainMultiplier
+	}
+
+	total := 0
+	for _, pick := range picks {
+		total += pick.Points * pick.Multiplier
+	}
+	return total
+}
+
+// canSubstitute reports whether swapping a starter for a bench player keeps the formation legal.
+// Goalkeepers can only be replaced by goalkeepers.
+func canSubstitute(formation map[string]int, out, in string, config FantasyConfig) bool {
+	if (out == models.PositionGoalkeeper) != (in == models.PositionGoalkeeper) {
+		return false
+	}
+	if out == in {
+		return true
+	}
+	return formation[out]-1 >= config.FormationMinimum[out] && formation[in]+1 <= config.FormationMaximum[in]
+}
+
+// updateTotal recomputes a manager's season total from the stored gameweeks
+func (s *fantasyScoringService) updateTotal(manager *models.Manager) error {
+	gameweeks, err := s.repo.GetManagerGameweeks(int(manager.ID))
+	if err != nil {
+		return err
+	}
+
+	manager.TotalPoints = 0
+	for _, gameweek := range gameweeks {
+		manager.TotalPoints += gameweek.Points
+	}
+	return s.managerRepo.UpdateTotalPoints(manager.ID, manager.TotalPoints)
+}
+
+// ClearAll removes every fantasy score and zeroes the managers' totals
+func (s *fantasyScoringService) ClearAll() error {
+	if err := s.repo.DeleteAll(); err != nil {
+		return err
+	}
+
+	managers, err := s.managerRepo.GetAll()
+	if err != nil {
+		return err
+	}
+	for _, manager := range managers {
+		if err := s.managerRepo.UpdateTotalPoints(manager.ID, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetPlayerGameweeks retrieves every player's points for a week
+func (s *fantasyScoringService) GetPlayerGameweeks(week int) ([]models.PlayerGameweek, error) {
+	return s.repo.GetPlayerGameweeks(week)
+}
+
+// GetManagerGameweeks retrieves an existing manager's score for every scored week
+func (s *fantasyScoringService) GetManagerGameweeks(managerID int) ([]models.ManagerGameweek, error) {
+	// Make sure the manager exists so unknown IDs surface as not found
+	if _, err := s.managerRepo.GetByID(managerID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetManagerGameweeks(managerID)
+}
+
+// GetManagerGameweek retrieves a manager's score for a week with the points of every pick
+func (s *fantasyScoringService) GetManagerGameweek(managerID, week int) (*models.ManagerGameweek, error) {
+	gameweek, err := s.repo.GetManagerGameweek(managerID, week)
+	if err != nil {
+		return nil, err
+	}
+
+	gameweek.Picks, err = s.repo.GetPicks(managerID, week)
+	if err != nil {
+		return nil, err
+	}
+	return gameweek, nil
+}
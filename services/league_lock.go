@@ -0,0 +1,25 @@
+package services
+
+import "sync"
+
+// LeagueLock serialises the operations that change results or team strengths, such as playing weeks, editing
+// a result, resetting the league and applying a calibration. Nothing waits for it; a busy league returns
+// ErrLeagueBusy.
+type LeagueLock struct {
+	mu sync.Mutex
+}
+
+// NewLeagueLock creates a LeagueLock that no operation holds
+func NewLeagueLock() *LeagueLock {
+	return &LeagueLock{}
+}
+
+// TryLock takes the lock if no other league operation holds it and reports whether it did
+func (l *LeagueLock) TryLock() bool {
+	return l.mu.TryLock()
+}
+
+// Unlock releases the lock
+func (l *LeagueLock) Unlock() {
+	l.mu.Unlock()
+}
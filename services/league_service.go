@@ -1,30 +1,51 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"insider-league/eventbus"
 	"insider-league/helpers"
 	"insider-league/models"
+	"math"
 )
 
+// ErrLeagueBusy is returned when another operation is already changing the league
+var ErrLeagueBusy = errors.New("another league operation is in progress")
+
 // LeagueService defines the interface for league-related operations
 type LeagueService interface {
 	GetLeagueTable() ([]models.Team, error)
 	PlayWeeks(playAll bool) ([]models.Team, []models.Match, []models.Prediction, error)
+	PlayNextWeekLive(speed float64, emit func(models.LiveUpdate) error) error
 	GetWeekResults(week int) ([]models.Match, error)
 	EditMatchResult(matchID int, homeGoals, awayGoals int) (*models.Match, []models.Team, error)
 	ResetLeague() error
+	GetProjections() ([]models.Prediction, error)
 }
 
 // leagueService implements the LeagueService interface
 type leagueService struct {
-	teamService  TeamService
-	matchService MatchService
+	teamService   TeamService
+	matchService  MatchService
+	ratingService RatingService
+	eventService  MatchEventService
+	bus           *eventbus.Bus
+	weeks         *WeekStatus
+	lock          *LeagueLock
+	config        SimulationConfig
 }
 
 // NewLeagueService creates a new instance of leagueService
-func NewLeagueService(teamService TeamService, matchService MatchService) LeagueService {
+func NewLeagueService(teamService TeamService, matchService MatchService, ratingService RatingService, eventService MatchEventService, bus *eventbus.Bus, weeks *WeekStatus, lock *LeagueLock, config SimulationConfig) LeagueService {
 	return &leagueService{
-		teamService:  teamService,
-		matchService: matchService,
+		teamService:   teamService,
+		matchService:  matchService,
+		ratingService: ratingService,
+		eventService:  eventService,
+		bus:           bus,
+		weeks:         weeks,
+		lock:          lock,
+		config:        config,
 	}
 }
 
@@ -37,6 +58,11 @@ func (s *leagueService) GetLeagueTable() ([]models.Team, error) {
 // If playAll is false, it plays only the next unplayed week
 // If playAll is true, it plays all remaining unplayed weeks
 func (s *leagueService) PlayWeeks(playAll bool) ([]models.Team, []models.Match, []models.Prediction, error) {
+	if !s.lock.TryLock() {
+		return nil, nil, nil, ErrLeagueBusy
+	}
+	defer s.lock.Unlock()
+
 	// Get all unplayed weeks sorted
 	unplayedWeeks, err := s.matchService.GetUnplayedWeeks()
 	if err != nil {
@@ -52,7 +78,16 @@ func (s *leagueService) PlayWeeks(playAll bool) ([]models.Team, []models.Match,
 		return leagueTable, []models.Match{}, []models.Prediction{}, nil
 	}
 
+	// Close everything that locks at kick-off for the weeks about to be played
+	if playAll {
+		s.weeks.Start(unplayedWeeks[0], unplayedWeeks[len(unplayedWeeks)-1])
+	} else {
+		s.weeks.Start(unplayedWeeks[0], unplayedWeeks[0])
+	}
+	defer s.weeks.Finish()
+
 	var allMatches []models.Match
+	var leagueTable []models.Team
 	var currentWeek int
 
 	// Loop through unplayed weeks
@@ -68,28 +103,26 @@ func (s *leagueService) PlayWeeks(playAll bool) ([]models.Team, []models.Match,
 		// Simulate each match in the week
 		for i := range weekMatches {
 			match := &weekMatches[i]
+			s.simulateMatch(match)
 
-			// Use the preloaded teams
-			homeTeam := &match.HomeTeam
-			awayTeam := &match.AwayTeam
-
-			// Simulate match score
-			homeGoals, awayGoals := helpers.SimulateMatchScore(homeTeam.Strength, awayTeam.Strength)
-
-			// Update match result
-			match.HomeTeamScore = homeGoals
-			match.AwayTeamScore = awayGoals
-			match.IsPlayed = true
-
-			// Update match in database
-			if err := s.matchService.Update(match); err != nil {
+			if err := s.recordMatchResult(match, nil); err != nil {
 				return nil, nil, nil, err
 			}
+		}
 
-			// Update team statistics
-			if err := s.teamService.UpdateTeamStats(homeTeam, awayTeam, homeGoals, awayGoals, false); err != nil {
-				return nil, nil, nil, err
-			}
+		// Apply end-of-week effects such as strength dynamics
+		if err := s.finishWeek(weekMatches); err != nil {
+			return nil, nil, nil, err
+		}
+
+		// Get updated league table and notify subscribers
+		leagueTable, err = s.teamService.GetTeamRankings()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		s.bus.Publish(eventbus.Event{Type: eventbus.WeekPlayed, Week: week, Matches: weekMatches, LeagueTable: leagueTable})
+		if week == unplayedWeeks[len(unplayedWeeks)-1] {
+			s.bus.Publish(eventbus.Event{Type: eventbus.SeasonFinished, Week: week, LeagueTable: leagueTable})
 		}
 
 		// Add week matches to all matches
@@ -101,19 +134,138 @@ func (s *leagueService) PlayWeeks(playAll bool) ([]models.Team, []models.Match,
 		}
 	}
 
-	// Get updated league table
-	leagueTable, err := s.teamService.GetTeamRankings()
+	// Calculate predictions if we're at week 4 or later
+	predictions, err := s.predictionsForWeek(leagueTable, currentWeek)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	// Calculate predictions if we're at week 4 or later
-	var predictions []models.Prediction
-	if currentWeek >= 4 {
-		predictions = helpers.CalculatePredictions(leagueTable, currentWeek)
+	return leagueTable, allMatches, predictions, nil
+}
+
+// simulateMatch draws a score for a match from the attack-versus-defence matchups of its preloaded teams
+// and records what it was simulated with. Nothing is persisted.
+func (s *leagueService) simulateMatch(match *models.Match) {
+	homeTeam := &match.HomeTeam
+	awayTeam := &match.AwayTeam
+
+	homeExpected, awayExpected := helpers.ExpectedGoals(homeTeam.AttackRating(), homeTeam.DefenceRating(), awayTeam.AttackRating(), awayTeam.DefenceRating())
+	homeGoals, awayGoals := helpers.SimulateMatchScore(homeExpected, awayExpected)
+
+	match.HomeTeamScore = homeGoals
+	match.AwayTeamScore = awayGoals
+	match.IsPlayed = true
+	match.HomeStrength = homeTeam.Strength
+	match.AwayStrength = awayTeam.Strength
+	match.HomeExpectedGoals = homeExpected
+	match.AwayExpectedGoals = awayExpected
+}
+
+// recordMatchResult persists a simulated match with its timeline, team statistics and ratings.
+// If events is nil, the whole timeline is drawn at once in events mode, while score mode draws the goals and
+// fills in the rest of the timeline around them.
+func (s *leagueService) recordMatchResult(match *models.Match, events []models.MatchEvent) error {
+	homeTeam := &match.HomeTeam
+	awayTeam := &match.AwayTeam
+
+	// Update match in database
+	if err := s.matchService.Update(match); err != nil {
+		return err
 	}
 
-	return leagueTable, allMatches, predictions, nil
+	// Store the match timeline
+	if events != nil {
+		if err := s.eventService.SaveForMatch(int(match.ID), events); err != nil {
+			return err
+		}
+	} else if s.config.Mode == SimulationModeEvents {
+		if _, err := s.eventService.GenerateForMatch(match); err != nil {
+			return err
+		}
+	} else if _, err := s.eventService.GenerateGoalsForMatch(match); err != nil {
+		return err
+	}
+
+	// Update team statistics
+	if err := s.teamService.UpdateTeamStats(homeTeam, awayTeam, match.HomeTeamScore, match.AwayTeamScore, false); err != nil {
+		return err
+	}
+
+	// Update Elo ratings
+	return s.ratingService.ApplyMatchResult(match, homeTeam, awayTeam)
+}
+
+// finishWeek applies the effects that follow a completed week
+func (s *leagueService) finishWeek(weekMatches []models.Match) error {
+	// Let team strengths evolve based on this week's results
+	if s.config.Dynamics.Enabled {
+		return s.applyStrengthDynamics(weekMatches)
+	}
+	return nil
+}
+
+// predictionsForWeek calculates predictions from week 4 onwards
+func (s *leagueService) predictionsForWeek(leagueTable []models.Team, week int) ([]models.Prediction, error) {
+	if week < 4 {
+		return nil, nil
+	}
+	return s.projectSeason(leagueTable)
+}
+
+// GetProjections simulates the rest of the season from the current table and returns every team's chance of
+// finishing in each position and zone
+func (s *leagueService) GetProjections() ([]models.Prediction, error) {
+	leagueTable, err := s.teamService.GetTeamRankings()
+	if err != nil {
+		return nil, err
+	}
+	return s.projectSeason(leagueTable)
+}
+
+// projectSeason simulates the unplayed matches from the given table and turns the finishing positions into
+// predictions, in table order
+func (s *leagueService) projectSeason(leagueTable []models.Team) ([]models.Prediction, error) {
+	matches, err := s.matchService.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var remaining []models.Match
+	for _, match := range matches {
+		if !match.IsPlayed {
+			remaining = append(remaining, match)
+		}
+	}
+
+	positions := helpers.SimulateSeason(leagueTable, remaining, s.config.Projection.Iterations)
+	predictions := make([]models.Prediction, len(leagueTable))
+	for i, team := range leagueTable {
+		zones := make(map[string]float64, len(s.config.Projection.Zones))
+		for _, zone := range s.config.Projection.Zones {
+			zones[zone.Name] = roundProbability(helpers.ZoneProbability(positions[i], zone))
+		}
+		for position := range positions[i] {
+			positions[i][position] = roundProbability(positions[i][position])
+		}
+
+		title := 0.0
+		if len(positions[i]) > 0 {
+			title = positions[i][0]
+		}
+		predictions[i] = models.Prediction{
+			TeamID:           team.ID,
+			TeamName:         team.Name,
+			Chance:           fmt.Sprintf("%.1f%%", title*100),
+			TitleProbability: title,
+			Positions:        positions[i],
+			Zones:            zones,
+		}
+	}
+	return predictions, nil
+}
+
+// roundProbability rounds a probability to four decimal places
+func roundProbability(probability float64) float64 {
+	return math.Round(probability*10000) / 10000
 }
 
 // GetWeekResults retrieves the results for a specific week
@@ -128,6 +280,11 @@ func (s *leagueService) GetWeekResults(week int) ([]models.Match, error) {
 
 // EditMatchResult updates a match result and recalculates team statistics
 func (s *leagueService) EditMatchResult(matchID int, homeGoals, awayGoals int) (*models.Match, []models.Team, error) {
+	if !s.lock.TryLock() {
+		return nil, nil, ErrLeagueBusy
+	}
+	defer s.lock.Unlock()
+
 	// Get match with preloaded teams
 	match, err := s.matchService.GetByID(matchID)
 	if err != nil {
@@ -159,22 +316,40 @@ func (s *leagueService) EditMatchResult(matchID int, homeGoals, awayGoals int) (
 		return nil, nil, err
 	}
 
+	// Keep the timeline consistent with the edited score. Whatever the mode, only the goals are redrawn, so the
+	// shots, cards and substitutions fantasy scoring was based on stay the same.
+	if _, err := s.eventService.GenerateGoalsForMatch(match); err != nil {
+		return nil, nil, err
+	}
+
 	// Update team statistics with new result
 	if err := s.teamService.UpdateTeamStats(homeTeam, awayTeam, homeGoals, awayGoals, false); err != nil {
 		return nil, nil, err
 	}
 
+	// Replay Elo ratings since an earlier result has changed
+	if err := s.ratingService.Rebuild(); err != nil {
+		return nil, nil, err
+	}
+
 	// Get updated league table
 	leagueTable, err := s.teamService.GetTeamRankings()
 	if err != nil {
 		return nil, nil, err
 	}
 
+	s.bus.Publish(eventbus.Event{Type: eventbus.MatchResultEdited, Week: match.Week, Matches: []models.Match{*match}, LeagueTable: leagueTable})
+
 	return match, leagueTable, nil
 }
 
 // ResetLeague resets all match results and team statistics
 func (s *leagueService) ResetLeague() error {
+	if !s.lock.TryLock() {
+		return ErrLeagueBusy
+	}
+	defer s.lock.Unlock()
+
 	// Reset all matches
 	matches, err := s.matchService.GetAll()
 	if err != nil {
@@ -190,6 +365,11 @@ func (s *leagueService) ResetLeague() error {
 		}
 	}
 
+	// Clear all match timelines
+	if err := s.eventService.DeleteAll(); err != nil {
+		return err
+	}
+
 	// Reset all teams
 	teams, err := s.teamService.GetAll()
 	if err != nil {
@@ -197,6 +377,14 @@ func (s *leagueService) ResetLeague() error {
 	}
 
 	for _, team := range teams {
+		// Undo any strength drift from the previous season, shifting attack and defence alike
+		if team.BaseStrength > 0 {
+			offset := team.BaseStrength - team.Strength
+			team.Attack = team.AttackRating() + offset
+			team.Defence = team.DefenceRating() + offset
+			team.Strength = team.BaseStrength
+		}
+
 		team.Stats = models.Stats{
 			Points:         0,
 			GoalsFor:       0,
@@ -206,10 +394,21 @@ func (s *leagueService) ResetLeague() error {
 			Draws:          0,
 			Losses:         0,
 		}
-		if err := s.teamService.Update(&team); err != nil {
+		if err := s.teamService.Save(&team); err != nil {
 			return err
 		}
 	}
 
+	// Reset Elo ratings and clear their history
+	if err := s.ratingService.Rebuild(); err != nil {
+		return err
+	}
+
+	leagueTable, err := s.teamService.GetTeamRankings()
+	if err != nil {
+		return err
+	}
+
+	s.bus.Publish(eventbus.Event{Type: eventbus.LeagueReset, LeagueTable: leagueTable})
 	return nil
 }
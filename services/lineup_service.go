@@ -0,0 +1,237 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"insider-league/eventbus"
+	"insider-league/models"
+	"insider-league/repository"
+	"log"
+	"slices"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidLineup is returned when a lineup does not fit the squad or the formation rules
+var ErrInvalidLineup = errors.New("invalid lineup")
+
+// LineupService defines the interface for fantasy lineup operations
+type LineupService interface {
+	GetLineup(managerID, week int) (*models.Lineup, error)
+	SetLineup(managerID int, lineup *models.Lineup) (*models.Lineup, error)
+	HandleEvent(event eventbus.Event)
+}
+
+// lineupService implements LineupService interface
+type lineupService struct {
+	repo        repository.LineupRepository
+	managerRepo repository.ManagerRepository
+	squadRepo   repository.FantasySquadRepository
+	matchRepo   repository.MatchRepository
+	weeks       *WeekStatus
+	config      FantasyConfig
+}
+
+// NewLineupService creates a new instance of lineupService
+func NewLineupService(repo repository.LineupRepository, managerRepo repository.ManagerRepository, squadRepo repository.FantasySquadRepository, matchRepo repository.MatchRepository, weeks *WeekStatus, config FantasyConfig) LineupService {
+	return &lineupService{
+		repo:        repo,
+		managerRepo: managerRepo,
+		squadRepo:   squadRepo,
+		matchRepo:   matchRepo,
+		weeks:       weeks,
+		config:      config,
+	}
+}
+
+// GetLineup returns the lineup a manager's squad would line up with in a week, 0 meaning the open gameweek.
+// That is the last lineup set up to that week, or a default one when none was set or it no longer fits the squad.
+func (s *lineupService) GetLineup(managerID, week int) (*models.Lineup, error) {
+	if _, err := s.managerRepo.GetByID(managerID); err != nil {
+		return nil, err
+	}
+
+	if week == 0 {
+		deadline, err := openGameweek(s.matchRepo, s.weeks)
+		if err != nil {
+			return nil, err
+		}
+		if deadline.Week == 0 {
+			return nil, ErrSeasonOver
+		}
+		week = deadline.Week
+	}
+
+	squad, err := s.squadRepo.GetByManager(managerID)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := s.repo.GetLatest(managerID, week)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	lineup := resolveLineup(squad, saved, s.config)
+	lineup.ManagerID = uint(managerID)
+	lineup.Week = week
+	return lineup, nil
+}
+
+// SetLineup saves a manager's starters, bench order, captain and vice-captain for the open gameweek
+func (s *lineupService) SetLineup(managerID int, lineup *models.Lineup) (*models.Lineup, error) {
+	manager, err := s.managerRepo.GetByID(managerID)
+	if err != nil {
+		return nil, err
+	}
+
+	week, err := deadlineWeek(s.matchRepo, s.weeks, lineup.Week)
+	if err != nil {
+		return nil, err
+	}
+
+	squad, err := s.squadRepo.GetByManager(managerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateLineup(lineup, squad, s.config); err != nil {
+		return nil, err
+	}
+
+	lineup.ID = 0
+	lineup.ManagerID = manager.ID
+	lineup.Week = week
+	if err := s.repo.Save(lineup); err != nil {
+		return nil, err
+	}
+	return lineup, nil
+}
+
+// HandleEvent clears every lineup when the league is reset.
+// It is meant to be subscribed to the event bus.
+func (s *lineupService) HandleEvent(event eventbus.Event) {
+	if event.Type != eventbus.LeagueReset {
+		return
+	}
+	if err := s.repo.DeleteAll(); err != nil {
+		log.Printf("Failed to clear fantasy lineups after %s: %v", event.Type, err)
+	}
+}
+
+// resolveLineup returns the saved lineup when it still fits the squad, otherwise the default one
+func resolveLineup(squad *models.FantasySquad, saved *models.Lineup, config FantasyConfig) *models.Lineup {
+	if saved != nil && validateLineup(saved, squad, config) == nil {
+		return saved
+	}
+	return defaultLineup(squad, config)
+}
+
+// validateLineup checks that a lineup uses every squad player once, fields a legal formation
+// and has a captain and a different vice-captain among the starters
+func validateLineup(lineup *models.Lineup, squad *models.FantasySquad, config FantasyConfig) error {
+	if len(lineup.Starters) != config.StartingSize {
+		return fmt.Errorf("%w: %d starters are needed, got %d", ErrInvalidLineup, config.StartingSize, len(lineup.Starters))
+	}
+	if len(lineup.Starters)+len(lineup.Bench) != len(squad.Players) {
+		return fmt.Errorf("%w: every one of the %d squad players must start or be on the bench", ErrInvalidLineup, len(squad.Players))
+	}
+
+	positions := make(map[uint]string)
+	for _, squadPlayer := range squad.Players {
+		positions[squadPlayer.PlayerID] = squadPlayer.Player.Position
+	}
+
+	seen := make(map[uint]bool)
+	for _, id := range slices.Concat(lineup.Starters, lineup.Bench) {
+		if _, ok := positions[id]; !ok {
+			return fmt.Errorf("%w: player %d is not in the squad", ErrInvalidLineup, id)
+		}
+		if seen[id] {
+			return fmt.Errorf("%w: player %d is picked more than once", ErrInvalidLineup, id)
+		}
+		seen[id] = true
+	}
+
+	formation := make(map[string]int)
+	for _, id := range lineup.Starters {
+		formation[positions[id]]++
+	}
+	for _, position := range models.Positions {
+		if formation[position] < config.FormationMinimum[position] || formation[position] > config.FormationMaximum[position] {
+			return fmt.Errorf("%w: %d to %d %s must start, got %d", ErrInvalidLineup,
+				config.FormationMinimum[position], config.FormationMaximum[position], position, formation[position])
+		}
+	}
+
+	if !slices.Contains(lineup.Starters, lineup.CaptainID) || !slices.Contains(lineup.Starters, lineup.ViceCaptainID) {
+		return fmt.Errorf("%w: the captain and vice-captain must both start", ErrInvalidLineup)
+	}
+	if lineup.CaptainID == lineup.ViceCaptainID {
+		return fmt.Errorf("%w: the captain and vice-captain must be different players", ErrInvalidLineup)
+	}
+	return nil
+}
+
+// defaultLineup starts the best-rated players in a legal formation, benches the rest with the
+// goalkeeper first and captains the two best-rated starters
+func defaultLineup(squad *models.FantasySquad, config FantasyConfig) *models.Lineup {
+	ranked := make([]models.Player, len(squad.Players))
+	for i, squadPlayer := range squad.Players {
+		ranked[i] = squadPlayer.Player
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Rating > ranked[j].Rating
+	})
+
+	starting := make(map[uint]bool)
+	formation := make(map[string]int)
+	start := func(player models.Player) {
+		starting[player.ID] = true
+		formation[player.Position]++
+	}
+
+	// Meet every position's minimum first, then fill the team with the best of the rest
+	for _, player := range ranked {
+		if formation[player.Position] < config.FormationMinimum[player.Position] {
+			start(player)
+		}
+	}
+	for _, player := range ranked {
+		if !starting[player.ID] && len(starting) < config.StartingSize && formation[player.Position] < config.FormationMaximum[player.Position] {
+			start(player)
+		}
+	}
+
+	lineup := &models.Lineup{Starters: []uint{}, Bench: []uint{}}
+	for _, position := range models.Positions {
+		for _, player := range ranked {
+			if player.Position == position && starting[player.ID] {
+				lineup.Starters = append(lineup.Starters, player.ID)
+			}
+		}
+	}
+	for _, player := range ranked {
+		if !starting[player.ID] && player.Position == models.PositionGoalkeeper {
+			lineup.Bench = append(lineup.Bench, player.ID)
+		}
+	}
+	for _, player := range ranked {
+		if !starting[player.ID] && player.Position != models.PositionGoalkeeper {
+			lineup.Bench = append(lineup.Bench, player.ID)
+		}
+	}
+
+	var captains []uint
+	for _, player := range ranked {
+		if starting[player.ID] && len(captains) < 2 {
+			captains = append(captains, player.ID)
+		}
+	}
+	if len(captains) > 0 {
+		lineup.CaptainID = captains[0]
+	}
+	if len(captains) > 1 {
+		lineup.ViceCaptainID = captains[1]
+	}
+	return lineup
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"insider-league/eventbus"
+	"insider-league/helpers"
+	"insider-league/models"
+	"sort"
+	"time"
+)
+
+// DefaultLiveSpeed plays one match minute per real second
+const DefaultLiveSpeed = 60.0
+
+// liveEvent links an event in the merged week timeline to its match
+type liveEvent struct {
+	matchIndex int
+	event      models.MatchEvent
+}
+
+// liveWeek is a week simulated at kick-off and replayed live. It is stored once the replay ends, which fills in
+// the final table and predictions.
+type liveWeek struct {
+	week        int
+	matches     []models.Match
+	timelines   [][]models.MatchEvent
+	baseTable   []models.Team
+	leagueTable []models.Team
+	predictions []models.Prediction
+}
+
+// PlayNextWeekLive plays the next unplayed week and replays it in accelerated real time.
+// The week is simulated at kick-off and its gameweek closes straight away, so predictions, bets and squad changes
+// close as it starts, while the league stays locked until the replay ends. Every match event is passed to emit as
+// it happens together with the live scores, and goals also carry a provisional league table. The results are
+// stored and announced only after the final minute, or as soon as emit fails (for example because the client
+// disconnected), so nothing sees the week's results before full time.
+func (s *leagueService) PlayNextWeekLive(speed float64, emit func(models.LiveUpdate) error) error {
+	if !s.lock.TryLock() {
+		return ErrLeagueBusy
+	}
+	defer s.lock.Unlock()
+
+	// Get all unplayed weeks sorted
+	unplayedWeeks, err := s.matchService.GetUnplayedWeeks()
+	if err != nil {
+		return err
+	}
+
+	// If no unplayed weeks were left, report the current league table as final
+	if len(unplayedWeeks) == 0 {
+		leagueTable, err := s.teamService.GetTeamRankings()
+		if err != nil {
+			return err
+		}
+		return emit(models.LiveUpdate{Type: models.LiveFullTime, Minute: 90, Matches: []models.Match{}, LeagueTable: leagueTable})
+	}
+
+	week := unplayedWeeks[0]
+	s.weeks.Start(week, week)
+	defer s.weeks.Finish()
+
+	played, err := s.kickOffLiveWeek(week)
+	if err != nil {
+		return err
+	}
+
+	// A replay cut short by the client still stores the week, so the results never depend on the connection
+	replayErr := replayWeek(played, speed, emit)
+	if err := s.storeLiveWeek(played, len(unplayedWeeks) == 1); err != nil {
+		return err
+	}
+	if replayErr != nil {
+		return replayErr
+	}
+
+	return emit(models.LiveUpdate{
+		Type:        models.LiveFullTime,
+		Minute:      90,
+		Matches:     played.matches,
+		LeagueTable: played.leagueTable,
+		Predictions: played.predictions,
+	})
+}
+
+// kickOffLiveWeek simulates every result and timeline of a week without storing anything
+func (s *leagueService) kickOffLiveWeek(week int) (*liveWeek, error) {
+	weekMatches, err := s.matchService.GetByWeek(week)
+	if err != nil {
+		return nil, err
+	}
+
+	// The provisional table starts from the standings before kick-off
+	baseTable, err := s.teamService.GetTeamRankings()
+	if err != nil {
+		return nil, err
+	}
+
+	timelines := make([][]models.MatchEvent, len(weekMatches))
+	for i := range weekMatches {
+		s.simulateMatch(&weekMatches[i])
+		timelines[i], err = s.eventService.SimulateForMatch(&weekMatches[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &liveWeek{
+		week:      week,
+		matches:   weekMatches,
+		timelines: timelines,
+		baseTable: baseTable,
+	}, nil
+}
+
+// storeLiveWeek stores a replayed week exactly as it was played out and announces it like a normal play
+func (s *leagueService) storeLiveWeek(played *liveWeek, lastWeek bool) error {
+	for i := range played.matches {
+		if err := s.recordMatchResult(&played.matches[i], played.timelines[i]); err != nil {
+			return err
+		}
+	}
+	if err := s.finishWeek(played.matches); err != nil {
+		return err
+	}
+
+	leagueTable, err := s.teamService.GetTeamRankings()
+	if err != nil {
+		return err
+	}
+	played.leagueTable = leagueTable
+
+	s.bus.Publish(eventbus.Event{Type: eventbus.WeekPlayed, Week: played.week, Matches: played.matches, LeagueTable: leagueTable})
+	if lastWeek {
+		s.bus.Publish(eventbus.Event{Type: eventbus.SeasonFinished, Week: played.week, LeagueTable: leagueTable})
+	}
+
+	played.predictions, err = s.predictionsForWeek(leagueTable, played.week)
+	return err
+}
+
+// replayWeek streams a simulated week minute by minute from kick-off to the final minute, starting from the
+// table before kick-off. Full time is announced once the week is stored.
+func replayWeek(played *liveWeek, speed float64, emit func(models.LiveUpdate) error) error {
+	// Half-time and full-time are announced once for the whole week
+	var timeline []liveEvent
+	for i, events := range played.timelines {
+		for _, event := range events {
+			if event.Type != models.EventHalfTime && event.Type != models.EventFullTime {
+				timeline = append(timeline, liveEvent{matchIndex: i, event: event})
+			}
+		}
+	}
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].event.Minute < timeline[j].event.Minute
+	})
+
+	// Live copies of the matches start goalless
+	live := make([]models.Match, len(played.matches))
+	copy(live, played.matches)
+	for i := range live {
+		live[i].HomeTeamScore = 0
+		live[i].AwayTeamScore = 0
+		live[i].IsPlayed = false
+	}
+
+	// Nothing has been played at kick-off, so the table is the one before the week
+	if err := emit(models.LiveUpdate{Type: models.LiveKickoff, Matches: live, LeagueTable: played.baseTable}); err != nil {
+		return err
+	}
+
+	minuteDuration := time.Duration(float64(time.Minute) / speed)
+	next := 0
+	for minute := 1; minute <= 90; minute++ {
+		time.Sleep(minuteDuration)
+
+		for ; next < len(timeline) && timeline[next].event.Minute == minute; next++ {
+			item := timeline[next]
+			update := models.LiveUpdate{Type: models.LiveEvent, Minute: minute, Event: &item.event, Matches: live}
+
+			// Goals move the live score and the provisional table
+			if item.event.Type == models.EventGoal {
+				live[item.matchIndex].HomeTeamScore = item.event.HomeScore
+				live[item.matchIndex].AwayTeamScore = item.event.AwayScore
+				update.LeagueTable = liveLeagueTable(played.baseTable, live)
+			}
+
+			if err := emit(update); err != nil {
+				return err
+			}
+		}
+
+		if minute == 45 {
+			if err := emit(models.LiveUpdate{Type: models.LiveHalfTime, Minute: minute, Matches: live, LeagueTable: liveLeagueTable(played.baseTable, live)}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// liveLeagueTable returns the standings as if the live scores were final
+func liveLeagueTable(baseTable []models.Team, live []models.Match) []models.Team {
+	table := make([]models.Team, len(baseTable))
+	copy(table, baseTable)
+
+	teamsByID := make(map[uint]*models.Team, len(table))
+	for i := range table {
+		teamsByID[table[i].ID] = &table[i]
+	}
+
+	for _, match := range live {
+		homeTeam, homeOK := teamsByID[match.HomeTeamID]
+		awayTeam, awayOK := teamsByID[match.AwayTeamID]
+		if homeOK && awayOK {
+			helpers.ApplyResultToStats(homeTeam, awayTeam, match.HomeTeamScore, match.AwayTeamScore, false)
+		}
+	}
+
+	helpers.RankTeams(table)
+	return table
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"insider-league/models"
+	"insider-league/repository"
+	"math"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidManager is returned when a manager has no name or team name
+	ErrInvalidManager = errors.New("manager needs a name and a team name")
+
+	// ErrSquadExists is returned when a manager who already has a squad tries to pick another
+	ErrSquadExists = errors.New("manager already has a squad; use transfers to change it")
+)
+
+// SquadValidationError is returned when a squad breaks the fantasy rules
+type SquadValidationError struct {
+	Validation models.SquadValidation
+}
+
+// Error implements the error interface
+func (e *SquadValidationError) Error() string {
+	return fmt.Sprintf("squad is invalid: %v", e.Validation.Errors)
+}
+
+// ManagerService defines the interface for fantasy manager and squad operations
+type ManagerService interface {
+	Create(manager *models.Manager) error
+	GetAll() ([]models.Manager, error)
+	GetByID(id int) (*models.Manager, error)
+	Authorize(user *models.User, managerID int) error
+	Delete(id int) error
+	GetSquad(managerID int) (*models.FantasySquad, error)
+	CreateSquad(managerID int, playerIDs []uint) (*models.FantasySquad, error)
+	ValidatePlayers(playerIDs []uint) (*models.SquadValidation, error)
+	ValidateSquad(managerID int) (*models.SquadValidation, error)
+}
+
+// managerService implements ManagerService interface
+type managerService struct {
+	repo       repository.ManagerRepository
+	squadRepo  repository.FantasySquadRepository
+	playerRepo repository.PlayerRepository
+	config     FantasyConfig
+}
+
+// NewManagerService creates a new instance of managerService
+func NewManagerService(repo repository.ManagerRepository, squadRepo repository.FantasySquadRepository, playerRepo repository.PlayerRepository, config FantasyConfig) ManagerService {
+	return &managerService{
+		repo:       repo,
+		squadRepo:  squadRepo,
+		playerRepo: playerRepo,
+		config:     config,
+	}
+}
+
+// Create registers a manager with the full budget in the bank and a gameweek's free transfers
+func (s *managerService) Create(manager *models.Manager) error {
+	if manager.Name == "" || manager.TeamName == "" {
+		return ErrInvalidManager
+	}
+	manager.Bank = s.config.Budget
+	manager.FreeTransfers = s.config.FreeTransfers
+	return s.repo.Create(manager)
+}
+
+// GetAll retrieves all managers using the repository
+func (s *managerService) GetAll() ([]models.Manager, error) {
+	return s.repo.GetAll()
+}
+
+// GetByID retrieves a manager by its ID using the repository
+func (s *managerService) GetByID(id int) (*models.Manager, error) {
+	return s.repo.GetByID(id)
+}
+
+// Authorize checks that a user may change a manager, returning ErrNotManagerOwner when it belongs to another account
+func (s *managerService) Authorize(user *models.User, managerID int) error {
+	manager, err := s.repo.GetByID(managerID)
+	if err != nil {
+		return err
+	}
+	if !CanManage(user, manager) {
+		return ErrNotManagerOwner
+	}
+	return nil
+}
+
+// Delete removes a manager using the repository
+func (s *managerService) Delete(id int) error {
+	return s.repo.Delete(id)
+}
+
+// GetSquad retrieves the squad of an existing manager with what each player would sell for
+func (s *managerService) GetSquad(managerID int) (*models.FantasySquad, error) {
+	// Make sure the manager exists so unknown IDs surface as not found
+	if _, err := s.repo.GetByID(managerID); err != nil {
+		return nil, err
+	}
+
+	squad, err := s.squadRepo.GetByManager(managerID)
+	if err != nil {
+		return nil, err
+	}
+	setSellingPrices(squad, s.config)
+	return squad, nil
+}
+
+// CreateSquad picks a manager's initial squad at current prices and moves the change into the bank
+func (s *managerService) CreateSquad(managerID int, playerIDs []uint) (*models.FantasySquad, error) {
+	manager, err := s.repo.GetByID(managerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.squadRepo.GetByManager(managerID); err == nil {
+		return nil, ErrSquadExists
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	players, validation, err := s.checkPlayers(playerIDs)
+	if err != nil {
+		return nil, err
+	}
+	if !validation.Valid {
+		return nil, &SquadValidationError{Validation: *validation}
+	}
+
+	squad := &models.FantasySquad{ManagerID: manager.ID}
+	for _, player := range players {
+		squad.Players = append(squad.Players, models.FantasySquadPlayer{
+			PlayerID:      player.ID,
+			Player:        player,
+			PurchasePrice: player.Price,
+		})
+	}
+	manager.Bank = validation.Remaining
+	if err := s.squadRepo.Create(squad, manager); err != nil {
+		return nil, err
+	}
+	setSellingPrices(squad, s.config)
+
+	return squad, nil
+}
+
+// ValidatePlayers checks a prospective squad against the rules and current prices without saving it
+func (s *managerService) ValidatePlayers(playerIDs []uint) (*models.SquadValidation, error) {
+	_, validation, err := s.checkPlayers(playerIDs)
+	return validation, err
+}
+
+// ValidateSquad checks a manager's saved squad against the rules at current prices. The budget is the bank plus
+// the squad's current value, so price changes alone never put a squad over it.
+func (s *managerService) ValidateSquad(managerID int) (*models.SquadValidation, error) {
+	manager, err := s.repo.GetByID(managerID)
+	if err != nil {
+		return nil, err
+	}
+	squad, err := s.squadRepo.GetByManager(managerID)
+	if err != nil {
+		return nil, err
+	}
+
+	playerIDs := make([]uint, len(squad.Players))
+	budget := manager.Bank
+	for i, squadPlayer := range squad.Players {
+		playerIDs[i] = squadPlayer.PlayerID
+		budget += squadPlayer.Player.Price
+	}
+
+	players, err := s.playerRepo.GetByIDs(playerIDs)
+	if err != nil {
+		return nil, err
+	}
+	validation := validateSquad(playerIDs, players, s.config, budget)
+	return &validation, nil
+}
+
+// checkPlayers loads the chosen players and validates them as a squad
+func (s *managerService) checkPlayers(playerIDs []uint) ([]models.Player, *models.SquadValidation, error) {
+	players, err := s.playerRepo.GetByIDs(playerIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validation := validateSquad(playerIDs, players, s.config, s.config.Budget)
+	return players, &validation, nil
+}
+
+// validateSquad checks squad size, duplicates, unknown players, position quotas, the per-club cap and that the
+// squad costs no more than the budget at current prices
+func validateSquad(playerIDs []uint, players []models.Player, config FantasyConfig, budget float64) models.SquadValidation {
+	validation := models.SquadValidation{Errors: []string{}, Budget: math.Round(budget*10) / 10}
+
+	if len(playerIDs) != config.SquadSize {
+		validation.Errors = append(validation.Errors, fmt.Sprintf("squad must have %d players, got %d", config.SquadSize, len(playerIDs)))
+	}
+
+	known := make(map[uint]bool)
+	for _, player := range players {
+		known[player.ID] = true
+	}
+
+	seen := make(map[uint]bool)
+	for _, id := range playerIDs {
+		switch {
+		case seen[id]:
+			validation.Errors = append(validation.Errors, fmt.Sprintf("player %d is picked more than once", id))
+		case !known[id]:
+			validation.Errors = append(validation.Errors, fmt.Sprintf("player %d does not exist", id))
+		}
+		seen[id] = true
+	}
+
+	positions := make(map[string]int)
+	clubs := make(map[uint]int)
+	for _, player := range players {
+		positions[player.Position]++
+		clubs[player.TeamID]++
+		validation.Cost += player.Price
+	}
+
+	for _, position := range models.Positions {
+		if want := config.PositionQuotas[position]; positions[position] != want {
+			validation.Errors = append(validation.Errors, fmt.Sprintf("squad must have %d %s, got %d", want, position, positions[position]))
+		}
+	}
+
+	teamIDs := make([]uint, 0, len(clubs))
+	for teamID := range clubs {
+		teamIDs = append(teamIDs, teamID)
+	}
+	slices.Sort(teamIDs)
+	for _, teamID := range teamIDs {
+		if clubs[teamID] > config.MaxPerClub {
+			validation.Errors = append(validation.Errors, fmt.Sprintf("at most %d players may come from team %d, got %d", config.MaxPerClub, teamID, clubs[teamID]))
+		}
+	}
+
+	// Prices are in tenths of a million, so round away floating point noise
+	validation.Cost = math.Round(validation.Cost*10) / 10
+	validation.Remaining = math.Round((budget-validation.Cost)*10) / 10
+	if validation.Remaining < 0 {
+		validation.Errors = append(validation.Errors, fmt.Sprintf("squad costs %.1fm, over the %.1fm budget", validation.Cost, validation.Budget))
+	}
+
+	validation.Valid = len(validation.Errors) == 0
+	return validation
+}
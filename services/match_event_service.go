@@ -0,0 +1,139 @@
+package services
+
+import (
+	"insider-league/helpers"
+	"insider-league/models"
+	"insider-league/repository"
+	"slices"
+)
+
+// DefaultLeaderboardSize is how many players the scorer and assist leaderboards return by default
+const DefaultLeaderboardSize = 10
+
+// MatchEventService defines the interface for match timeline operations
+type MatchEventService interface {
+	GetByMatch(matchID int) ([]models.MatchEvent, error)
+	SimulateForMatch(match *models.Match) ([]models.MatchEvent, error)
+	GenerateForMatch(match *models.Match) ([]models.MatchEvent, error)
+	GenerateGoalsForMatch(match *models.Match) ([]models.MatchEvent, error)
+	SaveForMatch(matchID int, events []models.MatchEvent) error
+	DeleteByMatch(matchID int) error
+	DeleteAll() error
+	GetTopScorers(limit int) ([]models.PlayerLeader, error)
+	GetTopAssisters(limit int) ([]models.PlayerLeader, error)
+}
+
+// matchEventService implements MatchEventService interface
+type matchEventService struct {
+	repo       repository.MatchEventRepository
+	matchRepo  repository.MatchRepository
+	playerRepo repository.PlayerRepository
+}
+
+// NewMatchEventService creates a new instance of matchEventService
+func NewMatchEventService(repo repository.MatchEventRepository, matchRepo repository.MatchRepository, playerRepo repository.PlayerRepository) MatchEventService {
+	return &matchEventService{
+		repo:       repo,
+		matchRepo:  matchRepo,
+		playerRepo: playerRepo,
+	}
+}
+
+// GetByMatch retrieves the timeline of an existing match
+func (s *matchEventService) GetByMatch(matchID int) ([]models.MatchEvent, error) {
+	// Make sure the match exists so unknown IDs surface as not found
+	if _, err := s.matchRepo.GetByID(matchID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByMatch(matchID)
+}
+
+// SimulateForMatch builds a full timeline for a match with scorers and assisters, without storing it
+func (s *matchEventService) SimulateForMatch(match *models.Match) ([]models.MatchEvent, error) {
+	events := helpers.SimulateMatchEvents(match)
+	if err := s.attributeGoals(match, events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GenerateForMatch replaces a match's timeline with one consistent with its current score
+func (s *matchEventService) GenerateForMatch(match *models.Match) ([]models.MatchEvent, error) {
+	events, err := s.SimulateForMatch(match)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.SaveForMatch(int(match.ID), events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GenerateGoalsForMatch replaces a match's goals with ones consistent with its current score, credited to
+// scorers and assisters. The shots, cards and substitutions already stored are kept, so an edited result only
+// changes who scored; a match without them, such as one just played in score mode, has them simulated around
+// its goals and stored with them, since fantasy scoring reads the whole timeline.
+func (s *matchEventService) GenerateGoalsForMatch(match *models.Match) ([]models.MatchEvent, error) {
+	stored, err := s.repo.GetByMatch(int(match.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	goals := helpers.SimulateGoalEvents(match)
+	if err := s.attributeGoals(match, goals); err != nil {
+		return nil, err
+	}
+	events := slices.DeleteFunc(stored, func(event models.MatchEvent) bool { return event.Type == models.EventGoal })
+	if !slices.ContainsFunc(events, func(event models.MatchEvent) bool { return event.Type == models.EventFullTime }) {
+		events = append(events, helpers.SimulateSupportingEvents(match)...)
+	}
+	events = append(events, goals...)
+	helpers.OrderTimeline(match, events)
+
+	if err := s.SaveForMatch(int(match.ID), events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// SaveForMatch replaces a match's timeline with an already simulated one
+func (s *matchEventService) SaveForMatch(matchID int, events []models.MatchEvent) error {
+	return s.repo.ReplaceByMatch(matchID, events)
+}
+
+// DeleteByMatch removes the timeline of a match
+func (s *matchEventService) DeleteByMatch(matchID int) error {
+	return s.repo.DeleteByMatch(matchID)
+}
+
+// DeleteAll removes every stored timeline
+func (s *matchEventService) DeleteAll() error {
+	return s.repo.DeleteAll()
+}
+
+// GetTopScorers retrieves the players with the most goals this season
+func (s *matchEventService) GetTopScorers(limit int) ([]models.PlayerLeader, error) {
+	return s.repo.GetTopScorers(limit)
+}
+
+// GetTopAssisters retrieves the players with the most assists this season
+func (s *matchEventService) GetTopAssisters(limit int) ([]models.PlayerLeader, error) {
+	return s.repo.GetTopAssisters(limit)
+}
+
+// attributeGoals credits the goals of a timeline to players from both squads
+func (s *matchEventService) attributeGoals(match *models.Match, events []models.MatchEvent) error {
+	homeSquad, err := s.playerRepo.GetByTeam(int(match.HomeTeamID))
+	if err != nil {
+		return err
+	}
+	awaySquad, err := s.playerRepo.GetByTeam(int(match.AwayTeamID))
+	if err != nil {
+		return err
+	}
+
+	helpers.AttributeGoals(events, match, homeSquad, awaySquad)
+	return nil
+}
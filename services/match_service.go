@@ -12,19 +12,22 @@ type MatchService interface {
 	GetByID(id int) (*models.Match, error)
 	GetByWeek(week int) ([]models.Match, error)
 	GetUnplayedWeeks() ([]int, error)
+	GetHeadToHead(teamID, otherTeamID int) (*models.HeadToHead, error)
 	Update(match *models.Match) error
 	Delete(id int) error
 }
 
 // matchService implements MatchService interface
 type matchService struct {
-	repo repository.MatchRepository
+	repo     repository.MatchRepository
+	teamRepo repository.TeamRepository
 }
 
 // NewMatchService creates a new instance of matchService
-func NewMatchService(repo repository.MatchRepository) MatchService {
+func NewMatchService(repo repository.MatchRepository, teamRepo repository.TeamRepository) MatchService {
 	return &matchService{
-		repo: repo,
+		repo:     repo,
+		teamRepo: teamRepo,
 	}
 }
 
@@ -53,6 +56,67 @@ func (s *matchService) GetUnplayedWeeks() ([]int, error) {
 	return s.repo.GetUnplayedWeeks()
 }
 
+// GetHeadToHead aggregates all played meetings between two teams from the first team's perspective
+func (s *matchService) GetHeadToHead(teamID, otherTeamID int) (*models.HeadToHead, error) {
+	// Make sure both teams exist so unknown IDs surface as not found
+	for _, id := range []int{teamID, otherTeamID} {
+		if _, err := s.teamRepo.GetByID(id); err != nil {
+			return nil, err
+		}
+	}
+
+	matches, err := s.repo.GetPlayedBetween(teamID, otherTeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &models.HeadToHead{
+		TeamID:      uint(teamID),
+		OtherTeamID: uint(otherTeamID),
+		Meetings:    matches,
+	}
+
+	biggestWinMargin, biggestLossMargin, highestTotal := 0, 0, -1
+	for i := range matches {
+		match := &matches[i]
+
+		// Orient the score so that goalsFor always belongs to the requested team
+		goalsFor, goalsAgainst := match.HomeTeamScore, match.AwayTeamScore
+		if match.AwayTeamID == uint(teamID) {
+			goalsFor, goalsAgainst = goalsAgainst, goalsFor
+		}
+
+		record.Played++
+		record.GoalsFor += goalsFor
+		record.GoalsAgainst += goalsAgainst
+
+		margin := goalsFor - goalsAgainst
+		switch {
+		case margin > 0:
+			record.Wins++
+			if margin > biggestWinMargin {
+				biggestWinMargin = margin
+				record.BiggestWin = match
+			}
+		case margin < 0:
+			record.Losses++
+			if -margin > biggestLossMargin {
+				biggestLossMargin = -margin
+				record.BiggestLoss = match
+			}
+		default:
+			record.Draws++
+		}
+
+		if total := goalsFor + goalsAgainst; total > highestTotal {
+			highestTotal = total
+			record.HighestScore = match
+		}
+	}
+
+	return record, nil
+}
+
 // Update modifies an existing match using the repository
 func (s *matchService) Update(match *models.Match) error {
 	return s.repo.Update(match)
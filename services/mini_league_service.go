@@ -0,0 +1,352 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"insider-league/eventbus"
+	"insider-league/helpers"
+	"insider-league/models"
+	"insider-league/repository"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidMiniLeague is returned when a mini-league has no name, an unknown type or an unusable start week
+	ErrInvalidMiniLeague = errors.New("invalid mini-league")
+
+	// ErrAlreadyMember is returned when a manager joins a mini-league they are already in
+	ErrAlreadyMember = errors.New("manager is already in this mini-league")
+
+	// ErrMiniLeagueClosed is returned when joining a head-to-head mini-league whose fixtures have started
+	ErrMiniLeagueClosed = errors.New("head-to-head fixtures have started; the mini-league is closed to new members")
+)
+
+// MiniLeagueService defines the interface for fantasy mini-league operations
+type MiniLeagueService interface {
+	Create(user *models.User, league *models.MiniLeague) error
+	Join(user *models.User, code string, managerID int) (*models.MiniLeague, error)
+	GetByID(id int) (*models.MiniLeague, error)
+	GetByManager(managerID int) ([]models.MiniLeague, error)
+	GetStandings(id int) ([]models.MiniLeagueStanding, error)
+	GetFixtures(id int) ([]models.HeadToHeadFixture, error)
+	HandleEvent(event eventbus.Event)
+}
+
+// miniLeagueService implements MiniLeagueService interface
+type miniLeagueService struct {
+	repo        repository.MiniLeagueRepository
+	managerRepo repository.ManagerRepository
+	pointsRepo  repository.FantasyPointsRepository
+	matchRepo   repository.MatchRepository
+	weeks       *WeekStatus
+}
+
+// NewMiniLeagueService creates a new instance of miniLeagueService
+func NewMiniLeagueService(repo repository.MiniLeagueRepository, managerRepo repository.ManagerRepository, pointsRepo repository.FantasyPointsRepository, matchRepo repository.MatchRepository, weeks *WeekStatus) MiniLeagueService {
+	return &miniLeagueService{
+		repo:        repo,
+		managerRepo: managerRepo,
+		pointsRepo:  pointsRepo,
+		matchRepo:   matchRepo,
+		weeks:       weeks,
+	}
+}
+
+// Create sets up a mini-league with a fresh join code and its creator as the first member.
+// Classic leagues count from week 1 by default; head-to-head leagues start from the open gameweek at the earliest.
+func (s *miniLeagueService) Create(user *models.User, league *models.MiniLeague) error {
+	if strings.TrimSpace(league.Name) == "" {
+		return fmt.Errorf("%w: a name is needed", ErrInvalidMiniLeague)
+	}
+	if league.Type == "" {
+		league.Type = models.MiniLeagueClassic
+	}
+	if league.Type != models.MiniLeagueClassic && league.Type != models.MiniLeagueHeadToHead {
+		return fmt.Errorf("%w: type must be %q or %q", ErrInvalidMiniLeague, models.MiniLeagueClassic, models.MiniLeagueHeadToHead)
+	}
+
+	creator, err := s.managerRepo.GetByID(int(league.CreatorManagerID))
+	if err != nil {
+		return err
+	}
+	if !CanManage(user, creator) {
+		return ErrNotManagerOwner
+	}
+
+	if league.Type == models.MiniLeagueHeadToHead {
+		week, err := deadlineWeek(s.matchRepo, s.weeks, 0)
+		if err != nil {
+			return err
+		}
+		if league.StartWeek == 0 {
+			league.StartWeek = week
+		}
+		if league.StartWeek < week {
+			return fmt.Errorf("%w: head-to-head fixtures cannot start before gameweek %d", ErrInvalidMiniLeague, week)
+		}
+	} else if league.StartWeek == 0 {
+		league.StartWeek = 1
+	}
+	if league.StartWeek < 1 {
+		return fmt.Errorf("%w: the start week must be at least 1", ErrInvalidMiniLeague)
+	}
+
+	// Draw join codes until one is free
+	for {
+		code, err := randomHex(4)
+		if err != nil {
+			return err
+		}
+		league.Code = strings.ToUpper(code)
+		if _, err := s.repo.GetByCode(league.Code); err == gorm.ErrRecordNotFound {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	league.ID = 0
+	league.Members = []models.MiniLeagueMember{{ManagerID: creator.ID, Manager: *creator}}
+	return s.repo.Create(league)
+}
+
+// Join adds a manager to the mini-league with the given code
+func (s *miniLeagueService) Join(user *models.User, code string, managerID int) (*models.MiniLeague, error) {
+	league, err := s.repo.GetByCode(strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, err
+	}
+	manager, err := s.managerRepo.GetByID(managerID)
+	if err != nil {
+		return nil, err
+	}
+	if !CanManage(user, manager) {
+		return nil, ErrNotManagerOwner
+	}
+
+	if slices.ContainsFunc(league.Members, func(member models.MiniLeagueMember) bool { return member.ManagerID == manager.ID }) {
+		return nil, ErrAlreadyMember
+	}
+
+	// Head-to-head fixtures are drawn once the start week is played, so later joiners would have no fixtures
+	if league.Type == models.MiniLeagueHeadToHead {
+		deadline, err := openGameweek(s.matchRepo, s.weeks)
+		if err != nil {
+			return nil, err
+		}
+		if deadline.Week == 0 || deadline.Week > league.StartWeek {
+			return nil, ErrMiniLeagueClosed
+		}
+		if !deadline.Open {
+			return nil, ErrWeekInProgress
+		}
+	}
+
+	if err := s.repo.AddMember(&models.MiniLeagueMember{LeagueID: league.ID, ManagerID: manager.ID}); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(int(league.ID))
+}
+
+// GetByID retrieves a mini-league with its members
+func (s *miniLeagueService) GetByID(id int) (*models.MiniLeague, error) {
+	return s.repo.GetByID(id)
+}
+
+// GetByManager retrieves the mini-leagues an existing manager belongs to
+func (s *miniLeagueService) GetByManager(managerID int) ([]models.MiniLeague, error) {
+	// Make sure the manager exists so unknown IDs surface as not found
+	if _, err := s.managerRepo.GetByID(managerID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByManager(managerID)
+}
+
+// GetFixtures retrieves the fixtures of a head-to-head mini-league, empty until its start week is played
+func (s *miniLeagueService) GetFixtures(id int) ([]models.HeadToHeadFixture, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetFixtures(id)
+}
+
+// GetStandings ranks a mini-league's members. Classic leagues rank by fantasy points since the start week;
+// head-to-head leagues award 3 points for a win and 1 for a draw in each gameweek matchup, then rank by those
+// points and break ties on fantasy points.
+func (s *miniLeagueService) GetStandings(id int) ([]models.MiniLeagueStanding, error) {
+	league, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	standings := make([]models.MiniLeagueStanding, len(league.Members))
+	rows := make(map[uint]*models.MiniLeagueStanding)
+	for i, member := range league.Members {
+		gameweeks, err := s.pointsRepo.GetManagerGameweeks(int(member.ManagerID))
+		if err != nil {
+			return nil, err
+		}
+
+		standings[i] = models.MiniLeagueStanding{ManagerID: member.ManagerID, Name: member.Manager.Name, TeamName: member.Manager.TeamName}
+		for _, gameweek := range gameweeks {
+			if gameweek.Week >= league.StartWeek {
+				standings[i].Total += gameweek.Points
+			}
+		}
+		rows[member.ManagerID] = &standings[i]
+	}
+
+	if league.Type == models.MiniLeagueHeadToHead {
+		fixtures, err := s.repo.GetFixtures(id)
+		if err != nil {
+			return nil, err
+		}
+		for _, fixture := range fixtures {
+			home, away := rows[fixture.HomeManagerID], rows[fixture.AwayManagerID]
+			if !fixture.IsPlayed || home == nil || away == nil {
+				continue
+			}
+			recordHeadToHead(home, fixture.HomePoints, fixture.AwayPoints)
+			recordHeadToHead(away, fixture.AwayPoints, fixture.HomePoints)
+		}
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Points != standings[j].Points {
+			return standings[i].Points > standings[j].Points
+		}
+		return standings[i].Total > standings[j].Total
+	})
+
+	// Managers level on both share a rank
+	for i := range standings {
+		standings[i].Rank = i + 1
+		if i > 0 && standings[i].Points == standings[i-1].Points && standings[i].Total == standings[i-1].Total {
+			standings[i].Rank = standings[i-1].Rank
+		}
+	}
+	return standings, nil
+}
+
+// recordHeadToHead adds one matchup to a manager's head-to-head record
+func recordHeadToHead(row *models.MiniLeagueStanding, scored, conceded int) {
+	row.Played++
+	switch {
+	case scored > conceded:
+		row.Won++
+		row.Points += 3
+	case scored == conceded:
+		row.Drawn++
+		row.Points++
+	default:
+		row.Lost++
+	}
+}
+
+// HandleEvent settles head-to-head matchups as weeks are played or rescored and restarts the leagues on reset.
+// It is meant to be subscribed to the event bus after the fantasy scoring.
+func (s *miniLeagueService) HandleEvent(event eventbus.Event) {
+	var err error
+	switch event.Type {
+	case eventbus.WeekPlayed, eventbus.MatchResultEdited:
+		err = s.settleWeek(event.Week)
+	case eventbus.LeagueReset:
+		err = s.repo.ResetSeason()
+	}
+	if err != nil {
+		log.Printf("Failed to update mini-leagues after %s: %v", event.Type, err)
+	}
+}
+
+// settleWeek draws the fixtures of head-to-head leagues whose start week has come and
+// records each matchup of the week from the managers' gameweek scores
+func (s *miniLeagueService) settleWeek(week int) error {
+	leagues, err := s.repo.GetByType(models.MiniLeagueHeadToHead)
+	if err != nil {
+		return err
+	}
+
+	for _, league := range leagues {
+		if league.StartWeek > week {
+			continue
+		}
+
+		fixtures, err := s.repo.GetFixtures(int(league.ID))
+		if err != nil {
+			return err
+		}
+		if len(fixtures) == 0 {
+			lastWeek, err := seasonLength(s.matchRepo)
+			if err != nil {
+				return err
+			}
+			fixtures = headToHeadFixtures(&league, lastWeek)
+			if err := s.repo.CreateFixtures(fixtures); err != nil {
+				return err
+			}
+		}
+
+		var settled []models.HeadToHeadFixture
+		for _, fixture := range fixtures {
+			if fixture.Week != week {
+				continue
+			}
+			if fixture.HomePoints, err = s.gameweekPoints(fixture.HomeManagerID, week); err != nil {
+				return err
+			}
+			if fixture.AwayPoints, err = s.gameweekPoints(fixture.AwayManagerID, week); err != nil {
+				return err
+			}
+			fixture.IsPlayed = true
+			settled = append(settled, fixture)
+		}
+		if err := s.repo.SaveFixtures(settled); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gameweekPoints returns a manager's score for a week, 0 when nothing was scored
+func (s *miniLeagueService) gameweekPoints(managerID uint, week int) (int, error) {
+	gameweek, err := s.pointsRepo.GetManagerGameweek(int(managerID), week)
+	if err == gorm.ErrRecordNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return gameweek.Points, nil
+}
+
+// headToHeadFixtures schedules a league's members round-robin from its start week to the end of the season.
+// Once everyone has met, the rounds repeat with home and away swapped.
+func headToHeadFixtures(league *models.MiniLeague, lastWeek int) []models.HeadToHeadFixture {
+	rounds := helpers.RoundRobin(len(league.Members))
+	if len(rounds) == 0 {
+		return nil
+	}
+
+	var fixtures []models.HeadToHeadFixture
+	for week := league.StartWeek; week <= lastWeek; week++ {
+		index := week - league.StartWeek
+		for _, pair := range rounds[index%len(rounds)] {
+			home, away := league.Members[pair[0]].ManagerID, league.Members[pair[1]].ManagerID
+			if (index/len(rounds))%2 == 1 {
+				home, away = away, home
+			}
+			fixtures = append(fixtures, models.HeadToHeadFixture{
+				LeagueID:      league.ID,
+				Week:          week,
+				HomeManagerID: home,
+				AwayManagerID: away,
+			})
+		}
+	}
+	return fixtures
+}
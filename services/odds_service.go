@@ -0,0 +1,217 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"insider-league/helpers"
+	"insider-league/models"
+	"insider-league/repository"
+	"math"
+	"sort"
+)
+
+// Odds market names
+const (
+	MarketMatchResult  = "1X2"
+	MarketOverUnder    = "Over/Under 2.5"
+	MarketCorrectScore = "Correct Score"
+)
+
+// Decimal prices are kept between these, however likely or unlikely a selection is
+const (
+	minDecimalOdds = 1.01
+	maxDecimalOdds = 1001.0
+)
+
+// resultGridGoals is how many goals per side are summed for the match result market; the rest is negligible
+const resultGridGoals = 15
+
+var (
+	// ErrMatchPlayed is returned when asking for the odds of a match that has already been played
+	ErrMatchPlayed = errors.New("odds are only offered for unplayed matches")
+
+	// ErrInvalidMargin is returned when a bookmaker margin is negative or too large to price
+	ErrInvalidMargin = errors.New("invalid margin")
+
+	// ErrUnknownSelection is returned when a market or selection is not one that is priced
+	ErrUnknownSelection = errors.New("unknown market or selection")
+)
+
+// OddsConfig holds the bookmaker settings used to price matches
+type OddsConfig struct {
+	// Margin is added to every market, so its implied probabilities sum to 1 + Margin
+	Margin float64
+
+	// CorrectScoreMaxGoals is the most goals per side priced individually in the correct score market;
+	// every other score is grouped as "Any other score"
+	CorrectScoreMaxGoals int
+}
+
+// DefaultOddsConfig returns the bookmaker settings used when nothing is overridden
+func DefaultOddsConfig() OddsConfig {
+	return OddsConfig{
+		Margin:               0.05,
+		CorrectScoreMaxGoals: 5,
+	}
+}
+
+// OddsService defines the interface for pricing unplayed matches
+type OddsService interface {
+	GetMatchOdds(matchID int, margin *float64) (*models.MatchOdds, error)
+	SelectionWon(market, selection string, homeGoals, awayGoals int) (bool, error)
+}
+
+// oddsService implements OddsService interface
+type oddsService struct {
+	matchRepo repository.MatchRepository
+	weeks     *WeekStatus
+	config    OddsConfig
+}
+
+// NewOddsService creates a new instance of oddsService
+func NewOddsService(matchRepo repository.MatchRepository, weeks *WeekStatus, config OddsConfig) OddsService {
+	return &oddsService{
+		matchRepo: matchRepo,
+		weeks:     weeks,
+		config:    config,
+	}
+}
+
+// GetMatchOdds prices a match that is neither played nor being played from the expected goals the simulator would play it with. Scores follow
+// the same independent Poisson draws as SimulateMatchScore, so the probabilities are exact rather than sampled.
+// A nil margin uses the configured one.
+func (s *oddsService) GetMatchOdds(matchID int, margin *float64) (*models.MatchOdds, error) {
+	m := s.config.Margin
+	if margin != nil {
+		m = *margin
+	}
+	if m < 0 || m >= 1 {
+		return nil, fmt.Errorf("%w: the margin must be at least 0 and below 1", ErrInvalidMargin)
+	}
+
+	match, err := s.matchRepo.GetByID(matchID)
+	if err != nil {
+		return nil, err
+	}
+	if match.IsPlayed {
+		return nil, ErrMatchPlayed
+	}
+	if s.weeks.Playing(match.Week) {
+		return nil, ErrWeekInProgress
+	}
+
+	homeTeam := &match.HomeTeam
+	awayTeam := &match.AwayTeam
+	homeExpected, awayExpected := helpers.ExpectedGoals(homeTeam.AttackRating(), homeTeam.DefenceRating(), awayTeam.AttackRating(), awayTeam.DefenceRating())
+
+	return &models.MatchOdds{
+		MatchID:           match.ID,
+		Week:              match.Week,
+		HomeTeam:          homeTeam.Name,
+		AwayTeam:          awayTeam.Name,
+		HomeExpectedGoals: math.Round(homeExpected*100) / 100,
+		AwayExpectedGoals: math.Round(awayExpected*100) / 100,
+		Margin:            m,
+		Markets: []models.OddsMarket{
+			matchResultMarket(homeExpected, awayExpected, m),
+			overUnderMarket(homeExpected, awayExpected, m),
+			correctScoreMarket(homeExpected, awayExpected, s.config.CorrectScoreMaxGoals, m),
+		},
+	}, nil
+}
+
+// SelectionWon reports whether a selection of a market won with the given final score
+func (s *oddsService) SelectionWon(market, selection string, homeGoals, awayGoals int) (bool, error) {
+	switch market {
+	case MarketMatchResult:
+		outcomes := map[string]int{"Home": 1, "Draw": 0, "Away": -1}
+		if outcome, ok := outcomes[selection]; ok {
+			return compareGoals(homeGoals, awayGoals) == outcome, nil
+		}
+	case MarketOverUnder:
+		switch selection {
+		case "Over 2.5":
+			return homeGoals+awayGoals > 2, nil
+		case "Under 2.5":
+			return homeGoals+awayGoals <= 2, nil
+		}
+	case MarketCorrectScore:
+		maxGoals := s.config.CorrectScoreMaxGoals
+		if selection == "Any other score" {
+			return homeGoals > maxGoals || awayGoals > maxGoals, nil
+		}
+		var home, away int
+		if _, err := fmt.Sscanf(selection, "%d-%d", &home, &away); err == nil && fmt.Sprintf("%d-%d", home, away) == selection &&
+			home >= 0 && away >= 0 && home <= maxGoals && away <= maxGoals {
+			return home == homeGoals && away == awayGoals, nil
+		}
+	}
+	return false, fmt.Errorf("%w: %q in %q", ErrUnknownSelection, selection, market)
+}
+
+// matchResultMarket prices the home win, draw and away win
+func matchResultMarket(homeExpected, awayExpected, margin float64) models.OddsMarket {
+	var home, draw, away float64
+	for h, row := range helpers.ScoreProbabilities(homeExpected, awayExpected, resultGridGoals) {
+		for a, probability := range row {
+			switch {
+			case h > a:
+				home += probability
+			case h < a:
+				away += probability
+			default:
+				draw += probability
+			}
+		}
+	}
+
+	total := home + draw + away
+	return oddsMarket(MarketMatchResult, margin, []models.OddsPrice{
+		{Selection: "Home", Probability: home / total},
+		{Selection: "Draw", Probability: draw / total},
+		{Selection: "Away", Probability: away / total},
+	})
+}
+
+// overUnderMarket prices fewer or more than 2.5 goals in the match
+func overUnderMarket(homeExpected, awayExpected, margin float64) models.OddsMarket {
+	under := helpers.TotalGoalsAtMost(2, homeExpected, awayExpected)
+	return oddsMarket(MarketOverUnder, margin, []models.OddsPrice{
+		{Selection: "Over 2.5", Probability: 1 - under},
+		{Selection: "Under 2.5", Probability: under},
+	})
+}
+
+// correctScoreMarket prices every scoreline up to maxGoals a side, likeliest first, and groups the rest
+func correctScoreMarket(homeExpected, awayExpected float64, maxGoals int, margin float64) models.OddsMarket {
+	var selections []models.OddsPrice
+	covered := 0.0
+	for h, row := range helpers.ScoreProbabilities(homeExpected, awayExpected, maxGoals) {
+		for a, probability := range row {
+			selections = append(selections, models.OddsPrice{Selection: fmt.Sprintf("%d-%d", h, a), Probability: probability})
+			covered += probability
+		}
+	}
+	sort.SliceStable(selections, func(i, j int) bool {
+		return selections[i].Probability > selections[j].Probability
+	})
+	if other := 1 - covered; other > 0 {
+		selections = append(selections, models.OddsPrice{Selection: "Any other score", Probability: other})
+	}
+
+	return oddsMarket(MarketCorrectScore, margin, selections)
+}
+
+// oddsMarket prices each selection of a market with the margin in decimal, fractional and American formats
+func oddsMarket(name string, margin float64, selections []models.OddsPrice) models.OddsMarket {
+	for i := range selections {
+		decimal := math.Min(math.Max(helpers.DecimalOdds(selections[i].Probability, margin), minDecimalOdds), maxDecimalOdds)
+		decimal = math.Round(decimal*100) / 100
+
+		selections[i].Probability = math.Round(selections[i].Probability*10000) / 10000
+		selections[i].Decimal = decimal
+		selections[i].Fractional = helpers.FractionalOdds(decimal)
+		selections[i].American = helpers.AmericanOdds(decimal)
+	}
+	return models.OddsMarket{Name: name, Overround: math.Round((1+margin)*10000) / 10000, Selections: selections}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"errors"
+	"insider-league/models"
+	"insider-league/repository"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidPlayer is returned when a player's name, position, rating, shirt number or price is invalid
+	ErrInvalidPlayer = errors.New("player needs a name, a position of GK, DEF, MID or FWD, a rating between 0 and 100, a shirt number between 1 and 99 and a non-negative price")
+
+	// ErrPlayerTeamNotFound is returned when a player is assigned to a team that does not exist
+	ErrPlayerTeamNotFound = errors.New("player's team does not exist")
+
+	// ErrShirtNumberTaken is returned when another player in the squad already wears the shirt number
+	ErrShirtNumberTaken = errors.New("shirt number is already taken in this squad")
+)
+
+// PlayerService defines the interface for player business logic operations
+type PlayerService interface {
+	Create(player *models.Player) error
+	GetAll() ([]models.Player, error)
+	GetByID(id int) (*models.Player, error)
+	GetByTeam(teamID int) ([]models.Player, error)
+	Update(player *models.Player) error
+	Delete(id int) error
+}
+
+// playerService implements PlayerService interface
+type playerService struct {
+	repo     repository.PlayerRepository
+	teamRepo repository.TeamRepository
+}
+
+// NewPlayerService creates a new instance of playerService
+func NewPlayerService(repo repository.PlayerRepository, teamRepo repository.TeamRepository) PlayerService {
+	return &playerService{
+		repo:     repo,
+		teamRepo: teamRepo,
+	}
+}
+
+// Create validates a player and adds it to its team's squad
+func (s *playerService) Create(player *models.Player) error {
+	if err := s.validate(player); err != nil {
+		return err
+	}
+	return s.repo.Create(player)
+}
+
+// GetAll retrieves all players using the repository
+func (s *playerService) GetAll() ([]models.Player, error) {
+	return s.repo.GetAll()
+}
+
+// GetByID retrieves a player by its ID using the repository
+func (s *playerService) GetByID(id int) (*models.Player, error) {
+	return s.repo.GetByID(id)
+}
+
+// GetByTeam retrieves the squad of an existing team
+func (s *playerService) GetByTeam(teamID int) ([]models.Player, error) {
+	// Make sure the team exists so unknown IDs surface as not found
+	if _, err := s.teamRepo.GetByID(teamID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByTeam(teamID)
+}
+
+// Update validates and modifies an existing player
+func (s *playerService) Update(player *models.Player) error {
+	if _, err := s.repo.GetByID(int(player.ID)); err != nil {
+		return err
+	}
+	if err := s.validate(player); err != nil {
+		return err
+	}
+	return s.repo.Update(player)
+}
+
+// Delete removes a player using the repository
+func (s *playerService) Delete(id int) error {
+	return s.repo.Delete(id)
+}
+
+// validate checks the player's fields, that its team exists and that the shirt number is free
+func (s *playerService) validate(player *models.Player) error {
+	if player.Name == "" ||
+		!slices.Contains(models.Positions, player.Position) ||
+		player.Rating < 0 || player.Rating > 100 ||
+		player.ShirtNumber < 1 || player.ShirtNumber > 99 ||
+		player.Price < 0 {
+		return ErrInvalidPlayer
+	}
+
+	if _, err := s.teamRepo.GetByID(int(player.TeamID)); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrPlayerTeamNotFound
+		}
+		return err
+	}
+
+	squad, err := s.repo.GetByTeam(int(player.TeamID))
+	if err != nil {
+		return err
+	}
+	for _, teammate := range squad {
+		if teammate.ID != player.ID && teammate.ShirtNumber == player.ShirtNumber {
+			return ErrShirtNumberTaken
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"insider-league/eventbus"
+	"insider-league/models"
+	"insider-league/repository"
+	"log"
+	"math"
+)
+
+// PriceService defines the interface for fantasy player price operations
+type PriceService interface {
+	GetHistory(playerID int) ([]models.PriceChange, error)
+	GetChanges(week int) ([]models.PriceChange, error)
+	UpdatePrices(week int) ([]models.PriceChange, error)
+	HandleEvent(event eventbus.Event)
+}
+
+// priceService implements PriceService interface
+type priceService struct {
+	repo         repository.PriceRepository
+	playerRepo   repository.PlayerRepository
+	managerRepo  repository.ManagerRepository
+	transferRepo repository.TransferRepository
+	pointsRepo   repository.FantasyPointsRepository
+	config       FantasyConfig
+}
+
+// NewPriceService creates a new instance of priceService
+func NewPriceService(repo repository.PriceRepository, playerRepo repository.PlayerRepository, managerRepo repository.ManagerRepository, transferRepo repository.TransferRepository, pointsRepo repository.FantasyPointsRepository, config FantasyConfig) PriceService {
+	return &priceService{
+		repo:         repo,
+		playerRepo:   playerRepo,
+		managerRepo:  managerRepo,
+		transferRepo: transferRepo,
+		pointsRepo:   pointsRepo,
+		config:       config,
+	}
+}
+
+// GetHistory retrieves an existing player's price changes, oldest first
+func (s *priceService) GetHistory(playerID int) ([]models.PriceChange, error) {
+	// Make sure the player exists so unknown IDs surface as not found
+	if _, err := s.playerRepo.GetByID(playerID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByPlayer(playerID)
+}
+
+// GetChanges retrieves the price changes made after a gameweek
+func (s *priceService) GetChanges(week int) ([]models.PriceChange, error) {
+	return s.repo.GetByWeek(week)
+}
+
+// HandleEvent moves prices after every played week and restores the starting prices on reset.
+// It is meant to be subscribed to the event bus after the fantasy scoring, so the week's points count towards form.
+func (s *priceService) HandleEvent(event eventbus.Event) {
+	var err error
+	switch event.Type {
+	case eventbus.WeekPlayed:
+		_, err = s.UpdatePrices(event.Week)
+	case eventbus.LeagueReset:
+		err = s.resetPrices()
+	}
+	if err != nil {
+		log.Printf("Failed to update fantasy prices after %s: %v", event.Type, err)
+	}
+}
+
+// UpdatePrices moves every player's price one step for each signal after a gameweek: net transfers in or out by
+// enough of the managers, and good or poor form over the recent weeks. The signals cancel out when they disagree.
+func (s *priceService) UpdatePrices(week int) ([]models.PriceChange, error) {
+	players, err := s.playerRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	managers, err := s.managerRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	transfers, err := s.transferRepo.GetByWeek(week)
+	if err != nil {
+		return nil, err
+	}
+	net := make(map[uint]int)
+	for _, transfer := range transfers {
+		net[transfer.PlayerInID]++
+		net[transfer.PlayerOutID]--
+	}
+
+	// Average each player's points over the weeks of the form window he has a record for
+	points := make(map[uint]int)
+	weeks := make(map[uint]int)
+	for formWeek := max(1, week-s.config.PriceFormWeeks+1); formWeek <= week; formWeek++ {
+		performances, err := s.pointsRepo.GetPlayerGameweeks(formWeek)
+		if err != nil {
+			return nil, err
+		}
+		counted := make(map[uint]bool)
+		for _, performance := range performances {
+			points[performance.PlayerID] += performance.Points
+			if !counted[performance.PlayerID] {
+				counted[performance.PlayerID] = true
+				weeks[performance.PlayerID]++
+			}
+		}
+	}
+
+	changes := []models.PriceChange{}
+	for i := range players {
+		player := &players[i]
+
+		change := models.PriceChange{PlayerID: player.ID, Week: week, OldPrice: player.Price, NetTransfers: net[player.ID]}
+		signal := 0
+		if len(managers) > 0 {
+			share := float64(change.NetTransfers) / float64(len(managers))
+			switch {
+			case share >= s.config.PriceTransferThreshold:
+				signal++
+			case share <= -s.config.PriceTransferThreshold:
+				signal--
+			}
+		}
+		if weeks[player.ID] > 0 {
+			change.Form = math.Round(float64(points[player.ID])/float64(weeks[player.ID])*10) / 10
+			switch {
+			case change.Form >= s.config.PriceRiseForm:
+				signal++
+			case change.Form <= s.config.PriceFallForm:
+				signal--
+			}
+		}
+
+		// A move never takes a price past the limits, nor pulls one that is already beyond them further in
+		target := math.Round((player.Price+float64(signal)*s.config.PriceStep)*10) / 10
+		switch {
+		case signal > 0:
+			change.NewPrice = max(player.Price, min(target, s.config.PriceCeiling))
+		case signal < 0:
+			change.NewPrice = min(player.Price, max(target, s.config.PriceFloor))
+		default:
+			continue
+		}
+		if change.NewPrice == player.Price {
+			continue
+		}
+
+		player.Price = change.NewPrice
+		if err := s.playerRepo.Update(player); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	if err := s.repo.Create(changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// resetPrices puts every changed player back on the price he had before his first change and clears the history
+func (s *priceService) resetPrices() error {
+	changes, err := s.repo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	restored := make(map[uint]bool)
+	for _, change := range changes {
+		if restored[change.PlayerID] {
+			continue
+		}
+		restored[change.PlayerID] = true
+
+		player, err := s.playerRepo.GetByID(int(change.PlayerID))
+		if err != nil {
+			return err
+		}
+		player.Price = change.OldPrice
+		if err := s.playerRepo.Update(player); err != nil {
+			return err
+		}
+	}
+	return s.repo.DeleteAll()
+}
+
+// sellingPrice returns what a player raises when sold: the current price after a fall, otherwise the purchase
+// price plus the kept share of the rise, rounded down to the nearest tenth of a million
+func sellingPrice(purchasePrice, currentPrice float64, config FantasyConfig) float64 {
+	if currentPrice <= purchasePrice {
+		return currentPrice
+	}
+	profit := math.Floor((currentPrice-purchasePrice)*config.SellingProfitShare*10+1e-9) / 10
+	return math.Round((purchasePrice+profit)*10) / 10
+}
+
+// setSellingPrices fills in what every player in a squad would currently sell for
+func setSellingPrices(squad *models.FantasySquad, config FantasyConfig) {
+	for i := range squad.Players {
+		squadPlayer := &squad.Players[i]
+		squadPlayer.SellingPrice = sellingPrice(squadPlayer.PurchasePrice, squadPlayer.Player.Price, config)
+	}
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"insider-league/helpers"
+	"insider-league/models"
+	"insider-league/repository"
+	"sort"
+)
+
+// EloConfig holds the tunable parameters of the Elo rating system
+type EloConfig struct {
+	KFactor       float64
+	HomeAdvantage float64
+	InitialRating float64
+}
+
+// DefaultEloConfig returns the default Elo parameters
+func DefaultEloConfig() EloConfig {
+	return EloConfig{
+		KFactor:       20,
+		HomeAdvantage: 100,
+		InitialRating: 1500,
+	}
+}
+
+// RatingService defines the interface for Elo rating operations
+type RatingService interface {
+	GetHistory(teamID int) ([]models.TeamRating, error)
+	GetPowerRankings() ([]models.PowerRanking, error)
+	ApplyMatchResult(match *models.Match, homeTeam, awayTeam *models.Team) error
+	Rebuild() error
+}
+
+// ratingService implements RatingService interface
+type ratingService struct {
+	repo      repository.RatingRepository
+	teamRepo  repository.TeamRepository
+	matchRepo repository.MatchRepository
+	config    EloConfig
+}
+
+// NewRatingService creates a new instance of ratingService
+func NewRatingService(repo repository.RatingRepository, teamRepo repository.TeamRepository, matchRepo repository.MatchRepository, config EloConfig) RatingService {
+	return &ratingService{
+		repo:      repo,
+		teamRepo:  teamRepo,
+		matchRepo: matchRepo,
+		config:    config,
+	}
+}
+
+// GetHistory retrieves the rating history of a team
+func (s *ratingService) GetHistory(teamID int) ([]models.TeamRating, error) {
+	// Make sure the team exists so unknown IDs surface as not found
+	if _, err := s.teamRepo.GetByID(teamID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByTeam(teamID)
+}
+
+// GetPowerRankings retrieves all teams ordered by their current Elo rating
+func (s *ratingService) GetPowerRankings() ([]models.PowerRanking, error) {
+	teams, err := s.teamRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(teams, func(i, j int) bool {
+		return teams[i].Rating > teams[j].Rating
+	})
+
+	rankings := make([]models.PowerRanking, len(teams))
+	for i, team := range teams {
+		rankings[i] = models.PowerRanking{
+			Position: i + 1,
+			TeamID:   team.ID,
+			TeamName: team.Name,
+			Rating:   team.Rating,
+		}
+	}
+
+	return rankings, nil
+}
+
+// ApplyMatchResult updates both teams' ratings after a match and records the change
+func (s *ratingService) ApplyMatchResult(match *models.Match, homeTeam, awayTeam *models.Team) error {
+	history := s.rateMatch(match, homeTeam, awayTeam)
+
+	if err := s.teamRepo.Update(homeTeam); err != nil {
+		return err
+	}
+	if err := s.teamRepo.Update(awayTeam); err != nil {
+		return err
+	}
+
+	return s.repo.Create(history)
+}
+
+// Rebuild recalculates every rating from scratch by replaying all played matches in order.
+// It is used when a past result is edited or the league is reset.
+func (s *ratingService) Rebuild() error {
+	teams, err := s.teamRepo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	matches, err := s.matchRepo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	// Start every team from the initial rating
+	teamsByID := make(map[uint]*models.Team, len(teams))
+	for i := range teams {
+		teams[i].Rating = s.config.InitialRating
+		teamsByID[teams[i].ID] = &teams[i]
+	}
+
+	// Replay played matches in chronological order
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Week != matches[j].Week {
+			return matches[i].Week < matches[j].Week
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	var history []models.TeamRating
+	for i := range matches {
+		match := &matches[i]
+		if !match.IsPlayed {
+			continue
+		}
+
+		homeTeam, homeOK := teamsByID[match.HomeTeamID]
+		awayTeam, awayOK := teamsByID[match.AwayTeamID]
+		if !homeOK || !awayOK {
+			continue
+		}
+
+		history = append(history, s.rateMatch(match, homeTeam, awayTeam)...)
+	}
+
+	// Replace the stored history and ratings
+	if err := s.repo.DeleteAll(); err != nil {
+		return err
+	}
+	for i := range teams {
+		if err := s.teamRepo.Update(&teams[i]); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.Create(history)
+}
+
+// rateMatch adjusts both teams' ratings in memory and returns the history entries for the match
+func (s *ratingService) rateMatch(match *models.Match, homeTeam, awayTeam *models.Team) []models.TeamRating {
+	homeBefore, awayBefore := homeTeam.Rating, awayTeam.Rating
+	change := helpers.EloChange(homeBefore, awayBefore, match.HomeTeamScore, match.AwayTeamScore, s.config.KFactor, s.config.HomeAdvantage)
+
+	homeTeam.Rating = homeBefore + change
+	awayTeam.Rating = awayBefore - change
+
+	return []models.TeamRating{
+		{
+			TeamID:       homeTeam.ID,
+			MatchID:      match.ID,
+			Week:         match.Week,
+			RatingBefore: homeBefore,
+			RatingAfter:  homeTeam.Rating,
+			Change:       change,
+		},
+		{
+			TeamID:       awayTeam.ID,
+			MatchID:      match.ID,
+			Week:         match.Week,
+			RatingBefore: awayBefore,
+			RatingAfter:  awayTeam.Rating,
+			Change:       -change,
+		},
+	}
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"insider-league/eventbus"
+	"insider-league/models"
+	"insider-league/repository"
+	"log"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// Points awarded for a prediction once its match is played
+const (
+	ExactScorePoints     = 3
+	CorrectOutcomePoints = 1
+)
+
+var (
+	// ErrInvalidPrediction is returned when a prediction has negative goals
+	ErrInvalidPrediction = errors.New("invalid prediction")
+
+	// ErrPredictionLocked is returned when predicting a match whose week has already been played
+	ErrPredictionLocked = errors.New("predictions for this match are locked because its week has been played")
+)
+
+// ScorePredictionService defines the interface for the score prediction game
+type ScorePredictionService interface {
+	Predict(user *models.User, req models.ScorePredictionRequest) (*models.ScorePrediction, error)
+	GetByUser(userID int) ([]models.ScorePrediction, error)
+	GetByWeek(week int) ([]models.ScorePrediction, error)
+	GetLeaderboard() ([]models.PredictionLeaderboardEntry, error)
+	HandleEvent(event eventbus.Event)
+}
+
+// scorePredictionService implements ScorePredictionService interface
+type scorePredictionService struct {
+	repo      repository.ScorePredictionRepository
+	matchRepo repository.MatchRepository
+	userRepo  repository.UserRepository
+	weeks     *WeekStatus
+}
+
+// NewScorePredictionService creates a new instance of scorePredictionService
+func NewScorePredictionService(repo repository.ScorePredictionRepository, matchRepo repository.MatchRepository, userRepo repository.UserRepository, weeks *WeekStatus) ScorePredictionService {
+	return &scorePredictionService{
+		repo:      repo,
+		matchRepo: matchRepo,
+		userRepo:  userRepo,
+		weeks:     weeks,
+	}
+}
+
+// Predict records a user's scoreline for a match, replacing any earlier prediction until its week starts playing
+func (s *scorePredictionService) Predict(user *models.User, req models.ScorePredictionRequest) (*models.ScorePrediction, error) {
+	if req.HomeGoals < 0 || req.AwayGoals < 0 {
+		return nil, fmt.Errorf("%w: goals cannot be negative", ErrInvalidPrediction)
+	}
+
+	match, err := s.matchRepo.GetByID(int(req.MatchID))
+	if err != nil {
+		return nil, err
+	}
+	if match.IsPlayed {
+		return nil, ErrPredictionLocked
+	}
+	if s.weeks.Playing(match.Week) {
+		return nil, ErrWeekInProgress
+	}
+
+	prediction, err := s.repo.GetByUserAndMatch(int(user.ID), int(match.ID))
+	if err == gorm.ErrRecordNotFound {
+		prediction = &models.ScorePrediction{UserID: user.ID, MatchID: match.ID, Week: match.Week, HomeGoals: req.HomeGoals, AwayGoals: req.AwayGoals}
+		if err := s.repo.Create(prediction); err != nil {
+			return nil, err
+		}
+		return prediction, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	prediction.Week = match.Week
+	prediction.HomeGoals = req.HomeGoals
+	prediction.AwayGoals = req.AwayGoals
+	if err := s.repo.Update(prediction); err != nil {
+		return nil, err
+	}
+	return prediction, nil
+}
+
+// GetByUser returns a user's predictions with their matches
+func (s *scorePredictionService) GetByUser(userID int) ([]models.ScorePrediction, error) {
+	return s.repo.GetByUser(userID)
+}
+
+// GetByWeek returns everyone's predictions for the played matches of a week; predictions for matches still to
+// be played stay hidden so they cannot be copied
+func (s *scorePredictionService) GetByWeek(week int) ([]models.ScorePrediction, error) {
+	predictions, err := s.repo.GetByWeek(week)
+	if err != nil {
+		return nil, err
+	}
+
+	locked := []models.ScorePrediction{}
+	for _, prediction := range predictions {
+		if prediction.Match != nil && prediction.Match.IsPlayed {
+			locked = append(locked, prediction)
+		}
+	}
+	return locked, nil
+}
+
+// GetLeaderboard ranks every user who has predicted by their points over the season, then by exact scores
+func (s *scorePredictionService) GetLeaderboard() ([]models.PredictionLeaderboardEntry, error) {
+	predictions, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.userRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uint]string, len(users))
+	for _, user := range users {
+		names[user.ID] = user.Name
+	}
+
+	entries := make(map[uint]*models.PredictionLeaderboardEntry)
+	for _, prediction := range predictions {
+		entry, ok := entries[prediction.UserID]
+		if !ok {
+			entry = &models.PredictionLeaderboardEntry{UserID: prediction.UserID, Name: names[prediction.UserID]}
+			entries[prediction.UserID] = entry
+		}
+		entry.Predictions++
+		if prediction.Points == nil {
+			continue
+		}
+		entry.Points += *prediction.Points
+		switch *prediction.Points {
+		case ExactScorePoints:
+			entry.ExactScores++
+		case CorrectOutcomePoints:
+			entry.CorrectOutcomes++
+		}
+	}
+
+	leaderboard := make([]models.PredictionLeaderboardEntry, 0, len(entries))
+	for _, entry := range entries {
+		leaderboard = append(leaderboard, *entry)
+	}
+	sort.Slice(leaderboard, func(i, j int) bool {
+		if leaderboard[i].Points != leaderboard[j].Points {
+			return leaderboard[i].Points > leaderboard[j].Points
+		}
+		if leaderboard[i].ExactScores != leaderboard[j].ExactScores {
+			return leaderboard[i].ExactScores > leaderboard[j].ExactScores
+		}
+		return leaderboard[i].UserID < leaderboard[j].UserID
+	})
+
+	// Users level on points and exact scores share a rank
+	for i := range leaderboard {
+		leaderboard[i].Rank = i + 1
+		if i > 0 && leaderboard[i].Points == leaderboard[i-1].Points && leaderboard[i].ExactScores == leaderboard[i-1].ExactScores {
+			leaderboard[i].Rank = leaderboard[i-1].Rank
+		}
+	}
+	return leaderboard, nil
+}
+
+// HandleEvent scores predictions when a week is played or a result is edited, and clears them when the league is reset
+func (s *scorePredictionService) HandleEvent(event eventbus.Event) {
+	var err error
+	switch event.Type {
+	case eventbus.WeekPlayed, eventbus.MatchResultEdited:
+		err = s.scoreMatches(event.Matches)
+	case eventbus.LeagueReset:
+		err = s.repo.DeleteAll()
+	}
+	if err != nil {
+		log.Printf("Failed to update score predictions after %s: %v", event.Type, err)
+	}
+}
+
+// scoreMatches awards points to every prediction of the given played matches, rescoring any already scored
+func (s *scorePredictionService) scoreMatches(matches []models.Match) error {
+	for _, match := range matches {
+		if !match.IsPlayed {
+			continue
+		}
+
+		predictions, err := s.repo.GetByMatch(int(match.ID))
+		if err != nil {
+			return err
+		}
+		for i := range predictions {
+			points := predictionPoints(&predictions[i], &match)
+			predictions[i].Points = &points
+			if err := s.repo.Update(&predictions[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// predictionPoints scores a prediction against a played match: the exact score, the right outcome, or nothing
+func predictionPoints(prediction *models.ScorePrediction, match *models.Match) int {
+	if prediction.HomeGoals == match.HomeTeamScore && prediction.AwayGoals == match.AwayTeamScore {
+		return ExactScorePoints
+	}
+	if compareGoals(prediction.HomeGoals, prediction.AwayGoals) == compareGoals(match.HomeTeamScore, match.AwayTeamScore) {
+		return CorrectOutcomePoints
+	}
+	return 0
+}
+
+// compareGoals returns 1 for a home win, -1 for an away win and 0 for a draw
+func compareGoals(home, away int) int {
+	switch {
+	case home > away:
+		return 1
+	case home < away:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,51 @@
+package services
+
+import "insider-league/models"
+
+// Simulation modes
+const (
+	// SimulationModeScore only produces final scorelines
+	SimulationModeScore = "score"
+
+	// SimulationModeEvents also produces a minute-by-minute timeline for every match
+	SimulationModeEvents = "events"
+)
+
+// SimulationConfig groups the optional modes of the league simulation
+type SimulationConfig struct {
+	Mode       string
+	Dynamics   DynamicsConfig
+	Projection ProjectionConfig
+}
+
+// ProjectionConfig holds the parameters of the season projections behind the predictions
+type ProjectionConfig struct {
+	// Iterations is how many times the rest of the season is simulated
+	Iterations int
+
+	// Zones are the ranges of positions whose probabilities are reported alongside each position
+	Zones []models.Zone
+}
+
+// DefaultSimulationConfig returns the default simulation configuration
+func DefaultSimulationConfig() SimulationConfig {
+	return SimulationConfig{
+		Mode:       SimulationModeScore,
+		Dynamics:   DefaultDynamicsConfig(),
+		Projection: DefaultProjectionConfig(),
+	}
+}
+
+// DefaultProjectionConfig returns the default projection parameters with Premier League zones. Zones are clipped
+// to the size of the league.
+func DefaultProjectionConfig() ProjectionConfig {
+	return ProjectionConfig{
+		Iterations: 10000,
+		Zones: []models.Zone{
+			{Name: "title", From: 1, To: 1},
+			{Name: "top_four", From: 1, To: 4},
+			{Name: "european_places", From: 1, To: 7},
+			{Name: "relegation", From: -3, To: -1},
+		},
+	}
+}
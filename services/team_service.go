@@ -1,17 +1,24 @@
 package services
 
 import (
+	"errors"
+	"insider-league/eventbus"
+	"insider-league/helpers"
 	"insider-league/models"
 	"insider-league/repository"
-	"sort"
 )
 
+// ErrInvalidRatings is returned when a team's attack, defence or strength is outside 0-100
+var ErrInvalidRatings = errors.New("attack, defence and strength must be between 0 and 100")
+
 // TeamService defines the interface for team business logic operations
 type TeamService interface {
 	Create(team *models.Team) error
 	GetAll() ([]models.Team, error)
 	GetByID(id int) (*models.Team, error)
 	Update(team *models.Team) error
+	Save(team *models.Team) error
+	SaveRatings(team *models.Team) error
 	Delete(id int) error
 	GetTeamRankings() ([]models.Team, error)
 	UpdateTeamStats(homeTeam, awayTeam *models.Team, homeGoals, awayGoals int, revert bool) error
@@ -20,18 +27,33 @@ type TeamService interface {
 // teamService implements TeamService interface
 type teamService struct {
 	repo repository.TeamRepository
+	bus  *eventbus.Bus
 }
 
 // NewTeamService creates a new instance of teamService
-func NewTeamService(repo repository.TeamRepository) TeamService {
+func NewTeamService(repo repository.TeamRepository, bus *eventbus.Bus) TeamService {
 	return &teamService{
 		repo: repo,
+		bus:  bus,
 	}
 }
 
 // Create adds a new team using the repository
 func (s *teamService) Create(team *models.Team) error {
-	return s.repo.Create(team)
+	if err := normalizeTeamRatings(team); err != nil {
+		return err
+	}
+
+	// The initial strength becomes the baseline that dynamic strength regresses to
+	if team.BaseStrength == 0 {
+		team.BaseStrength = team.Strength
+	}
+	if err := s.repo.Create(team); err != nil {
+		return err
+	}
+
+	s.bus.Publish(eventbus.Event{Type: eventbus.TeamChanged, Team: team})
+	return nil
 }
 
 // GetAll retrieves all teams using the repository
@@ -44,14 +66,66 @@ func (s *teamService) GetByID(id int) (*models.Team, error) {
 	return s.repo.GetByID(id)
 }
 
-// Update modifies an existing team using the repository
+// Update modifies an existing team using the repository and announces the change. The Elo rating is kept as
+// stored because only match results move it, and so is the baseline strength unless a new one is given.
 func (s *teamService) Update(team *models.Team) error {
+	if err := normalizeTeamRatings(team); err != nil {
+		return err
+	}
+	stored, err := s.repo.GetByID(int(team.ID))
+	if err != nil {
+		return err
+	}
+	team.Rating = stored.Rating
+	if team.BaseStrength == 0 {
+		team.BaseStrength = stored.BaseStrength
+	}
+
+	if err := s.repo.Update(team); err != nil {
+		return err
+	}
+
+	s.bus.Publish(eventbus.Event{Type: eventbus.TeamChanged, Team: team})
+	return nil
+}
+
+// Save stores a team changed by the league itself, such as a reset or strength dynamics.
+// Nothing is published; the operation that changed the teams announces it once.
+func (s *teamService) Save(team *models.Team) error {
+	if err := normalizeTeamRatings(team); err != nil {
+		return err
+	}
 	return s.repo.Update(team)
 }
 
+// SaveRatings stores new ratings for a team without touching its results, such as strengths fitted by
+// calibration. Like Save, nothing is published.
+func (s *teamService) SaveRatings(team *models.Team) error {
+	if err := normalizeTeamRatings(team); err != nil {
+		return err
+	}
+	return s.repo.UpdateRatings(team)
+}
+
+// normalizeTeamRatings validates the ratings and derives Strength from attack and defence
+func normalizeTeamRatings(team *models.Team) error {
+	for _, rating := range []int{team.Attack, team.Defence, team.Strength} {
+		if rating < 0 || rating > 100 {
+			return ErrInvalidRatings
+		}
+	}
+	team.NormalizeRatings()
+	return nil
+}
+
 // Delete removes a team using the repository
 func (s *teamService) Delete(id int) error {
-	return s.repo.Delete(id)
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+
+	s.bus.Publish(eventbus.Event{Type: eventbus.TeamChanged, Team: &models.Team{ID: uint(id)}})
+	return nil
 }
 
 // GetTeamRankings retrieves and sorts teams by their league position
@@ -61,23 +135,7 @@ func (s *teamService) GetTeamRankings() ([]models.Team, error) {
 		return nil, err
 	}
 
-	// Sort teams by points (descending), goal difference (descending), and goals scored (descending)
-	sort.Slice(teams, func(i, j int) bool {
-		// First compare by points
-		if teams[i].Stats.Points != teams[j].Stats.Points {
-			return teams[i].Stats.Points > teams[j].Stats.Points
-		}
-
-		// If points are equal, compare by goal difference
-		iGD := teams[i].Stats.GoalsFor - teams[i].Stats.GoalsAgainst
-		jGD := teams[j].Stats.GoalsFor - teams[j].Stats.GoalsAgainst
-		if iGD != jGD {
-			return iGD > jGD
-		}
-
-		// If goal difference is equal, compare by goals scored
-		return teams[i].Stats.GoalsFor > teams[j].Stats.GoalsFor
-	})
+	helpers.RankTeams(teams)
 
 	return teams, nil
 }
@@ -85,40 +143,7 @@ func (s *teamService) GetTeamRankings() ([]models.Team, error) {
 // UpdateTeamStats updates the statistics for both teams based on the match result
 // If revert is true, it will subtract the statistics instead of adding them
 func (s *teamService) UpdateTeamStats(homeTeam, awayTeam *models.Team, homeGoals, awayGoals int, revert bool) error {
-	// Determine the multiplier based on whether we're reverting or applying
-	multiplier := 1
-	if revert {
-		multiplier = -1
-	}
-
-	// Update points and match results
-	if homeGoals > awayGoals {
-		// Home team wins
-		homeTeam.Stats.Points += 3 * multiplier
-		homeTeam.Stats.Wins += 1 * multiplier
-		awayTeam.Stats.Losses += 1 * multiplier
-	} else if homeGoals < awayGoals {
-		// Away team wins
-		awayTeam.Stats.Points += 3 * multiplier
-		awayTeam.Stats.Wins += 1 * multiplier
-		homeTeam.Stats.Losses += 1 * multiplier
-	} else {
-		// Draw
-		homeTeam.Stats.Points += 1 * multiplier
-		awayTeam.Stats.Points += 1 * multiplier
-		homeTeam.Stats.Draws += 1 * multiplier
-		awayTeam.Stats.Draws += 1 * multiplier
-	}
-
-	// Update goal statistics
-	homeTeam.Stats.GoalsFor += homeGoals * multiplier
-	homeTeam.Stats.GoalsAgainst += awayGoals * multiplier
-	awayTeam.Stats.GoalsFor += awayGoals * multiplier
-	awayTeam.Stats.GoalsAgainst += homeGoals * multiplier
-
-	// Update goal difference
-	homeTeam.Stats.GoalDifference = homeTeam.Stats.GoalsFor - homeTeam.Stats.GoalsAgainst
-	awayTeam.Stats.GoalDifference = awayTeam.Stats.GoalsFor - awayTeam.Stats.GoalsAgainst
+	helpers.ApplyResultToStats(homeTeam, awayTeam, homeGoals, awayGoals, revert)
 
 	// Update teams in database
 	if err := s.repo.Update(homeTeam); err != nil {
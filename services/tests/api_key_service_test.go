@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// sha256Hex returns the hex SHA-256 of a key, as stored by the service
+func sha256Hex(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAPIKeyService_Create(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockAPIKeyRepository)
+	mockUserRepo := new(repomocks.MockUserRepository)
+
+	// Create API key service with mocks
+	service := services.NewAPIKeyService(mockRepo, mockUserRepo)
+
+	// Set up mock expectations
+	var stored *models.APIKey
+	mockRepo.On("Create", mock.AnythingOfType("*models.APIKey")).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*models.APIKey)
+		stored.ID = 3
+	}).Return(nil).Once()
+
+	// Call the function under test
+	issued, err := service.Create(&models.User{ID: 7}, models.APIKeyRequest{Name: " nightly job ", Scope: models.ScopeSimulate})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(issued.Key, "ilk_"))
+	assert.Len(t, issued.Key, 52)
+	assert.Equal(t, uint(3), issued.ID)
+	assert.Equal(t, uint(7), stored.UserID)
+	assert.Equal(t, "nightly job", stored.Name)
+	assert.Equal(t, models.ScopeSimulate, stored.Scope)
+	assert.Equal(t, issued.Key[:12], stored.Prefix)
+	assert.Equal(t, sha256Hex(issued.Key), stored.KeyHash)
+	assert.NotContains(t, stored.KeyHash, issued.Key)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyService_Create_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  models.APIKeyRequest
+	}{
+		{"Missing name", models.APIKeyRequest{Scope: models.ScopeReadOnly}},
+		{"Unknown scope", models.APIKeyRequest{Name: "bot", Scope: "write"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repositories
+			mockRepo := new(repomocks.MockAPIKeyRepository)
+			mockUserRepo := new(repomocks.MockUserRepository)
+
+			// Create API key service with mocks
+			service := services.NewAPIKeyService(mockRepo, mockUserRepo)
+
+			// Call the function under test
+			issued, err := service.Create(&models.User{ID: 7}, tt.req)
+
+			// Assertions
+			assert.Nil(t, issued)
+			assert.ErrorIs(t, err, services.ErrInvalidAPIKeyRequest)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	key := "ilk_0123456789abcdef0123456789abcdef0123456789abcdef"
+	revokedAt := time.Now()
+
+	tests := []struct {
+		name      string
+		stored    *models.APIKey
+		expectErr error
+	}{
+		{"Valid key", &models.APIKey{ID: 3, UserID: 7, Scope: models.ScopeReadOnly}, nil},
+		{"Revoked key", &models.APIKey{ID: 3, UserID: 7, RevokedAt: &revokedAt}, services.ErrInvalidAPIKey},
+		{"Unknown key", nil, services.ErrInvalidAPIKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repositories
+			mockRepo := new(repomocks.MockAPIKeyRepository)
+			mockUserRepo := new(repomocks.MockUserRepository)
+
+			// Create API key service with mocks
+			service := services.NewAPIKeyService(mockRepo, mockUserRepo)
+
+			// Set up mock expectations
+			if tt.stored != nil {
+				mockRepo.On("GetByHash", sha256Hex(key)).Return(tt.stored, nil).Once()
+			} else {
+				mockRepo.On("GetByHash", sha256Hex(key)).Return(nil, gorm.ErrRecordNotFound).Once()
+			}
+			if tt.expectErr == nil {
+				mockUserRepo.On("GetByID", 7).Return(&models.User{ID: 7, Role: models.RoleAdmin}, nil).Once()
+				mockRepo.On("TouchLastUsed", 3, mock.AnythingOfType("time.Time")).Return(nil).Once()
+			}
+
+			// Call the function under test
+			user, apiKey, err := service.Authenticate(key)
+
+			// Assertions
+			if tt.expectErr != nil {
+				assert.Nil(t, user)
+				assert.Nil(t, apiKey)
+				assert.ErrorIs(t, err, tt.expectErr)
+				mockRepo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, uint(7), user.ID)
+				assert.NotNil(t, apiKey.LastUsedAt)
+			}
+
+			// Verify that all expected calls were made
+			mockRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIKeyService_Revoke(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockAPIKeyRepository)
+	mockUserRepo := new(repomocks.MockUserRepository)
+
+	// Create API key service with mocks
+	service := services.NewAPIKeyService(mockRepo, mockUserRepo)
+
+	// Set up mock expectations
+	mockRepo.On("GetByID", 3).Return(&models.APIKey{ID: 3, UserID: 7}, nil).Twice()
+	mockRepo.On("Revoke", 3).Return(nil).Once()
+
+	// Call the function under test
+	err := service.Revoke(7, 3)
+
+	// Assertions
+	assert.NoError(t, err)
+
+	// Another user's key is treated as not found
+	err = service.Revoke(8, 3)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
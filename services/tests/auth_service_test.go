@@ -0,0 +1,388 @@
+package tests
+
+import (
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// testAuthConfig returns the default account settings with a fixed secret and cheap hashing
+func testAuthConfig() services.AuthConfig {
+	config := services.DefaultAuthConfig()
+	config.Secret = []byte("test-secret")
+	config.BcryptCost = bcrypt.MinCost
+	return config
+}
+
+// signedUpUser returns a user whose password is "correct horse"
+func signedUpUser(t *testing.T) *models.User {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	return &models.User{ID: 7, Email: "ada@example.com", PasswordHash: string(hash)}
+}
+
+func TestAuthService_Register(t *testing.T) {
+	// Create mock repository
+	mockRepo := new(repomocks.MockUserRepository)
+
+	// Create auth service with mock
+	service := services.NewAuthService(mockRepo, testAuthConfig())
+
+	// Set up mock expectations
+	mockRepo.On("GetByEmail", "ada@example.com").Return(nil, gorm.ErrRecordNotFound).Once()
+	mockRepo.On("Create", mock.MatchedBy(func(user *models.User) bool {
+		return user.Email == "ada@example.com" && user.Name == "Ada" && user.Role == models.RoleManager &&
+			bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("correct horse")) == nil
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.User).ID = 7
+	}).Return(nil).Once()
+	mockRepo.On("CreateRefreshToken", mock.MatchedBy(func(token *models.RefreshToken) bool {
+		return token.UserID == 7 && token.TokenID != "" && token.ExpiresAt.After(time.Now().Add(6*24*time.Hour))
+	})).Return(nil).Once()
+
+	// Call the function under test
+	tokens, err := service.Register(models.Credentials{Email: " Ada@Example.com ", Password: "correct horse", Name: "Ada"})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.AccessToken)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.Equal(t, "Bearer", tokens.TokenType)
+	assert.Equal(t, 900, tokens.ExpiresIn)
+	assert.Equal(t, uint(7), tokens.User.ID)
+
+	// The access token signs the user in
+	mockRepo.On("GetByID", 7).Return(tokens.User, nil).Once()
+	user, err := service.Authenticate(tokens.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "ada@example.com", user.Email)
+
+	// The refresh token is not an access token
+	_, err = service.Authenticate(tokens.RefreshToken)
+	assert.ErrorIs(t, err, services.ErrInvalidToken)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_Register_Rejected(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials models.Credentials
+		existing    bool
+		expectErr   error
+	}{
+		{"Invalid email", models.Credentials{Email: "not-an-email", Password: "correct horse"}, false, services.ErrInvalidUser},
+		{"Short password", models.Credentials{Email: "ada@example.com", Password: "short"}, false, services.ErrInvalidUser},
+		{"Email taken", models.Credentials{Email: "ADA@example.com", Password: "correct horse"}, true, services.ErrEmailTaken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repository
+			mockRepo := new(repomocks.MockUserRepository)
+
+			// Create auth service with mock
+			service := services.NewAuthService(mockRepo, testAuthConfig())
+
+			// Set up mock expectations
+			if tt.existing {
+				mockRepo.On("GetByEmail", "ada@example.com").Return(&models.User{ID: 1}, nil).Once()
+			}
+
+			// Call the function under test
+			tokens, err := service.Register(tt.credentials)
+
+			// Assertions
+			assert.Nil(t, tokens)
+			assert.ErrorIs(t, err, tt.expectErr)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+
+			// Verify that all expected calls were made
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthService_Login(t *testing.T) {
+	tests := []struct {
+		name      string
+		password  string
+		known     bool
+		expectErr error
+	}{
+		{"Correct password", "correct horse", true, nil},
+		{"Wrong password", "battery staple", true, services.ErrInvalidCredentials},
+		{"Unknown email", "correct horse", false, services.ErrInvalidCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repository
+			mockRepo := new(repomocks.MockUserRepository)
+
+			// Create auth service with mock
+			service := services.NewAuthService(mockRepo, testAuthConfig())
+
+			// Set up mock expectations
+			if tt.known {
+				mockRepo.On("GetByEmail", "ada@example.com").Return(signedUpUser(t), nil).Once()
+			} else {
+				mockRepo.On("GetByEmail", "ada@example.com").Return(nil, gorm.ErrRecordNotFound).Once()
+			}
+			if tt.expectErr == nil {
+				mockRepo.On("CreateRefreshToken", mock.Anything).Return(nil).Once()
+			}
+
+			// Call the function under test
+			tokens, err := service.Login(models.Credentials{Email: "ada@example.com", Password: tt.password})
+
+			// Assertions
+			if tt.expectErr != nil {
+				assert.Nil(t, tokens)
+				assert.ErrorIs(t, err, tt.expectErr)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, tokens.AccessToken)
+			}
+
+			// Verify that all expected calls were made
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthService_Refresh(t *testing.T) {
+	// Create mock repository
+	mockRepo := new(repomocks.MockUserRepository)
+
+	// Create auth service with mock
+	service := services.NewAuthService(mockRepo, testAuthConfig())
+	user := signedUpUser(t)
+
+	// Sign in to get a refresh token
+	var tokenID string
+	mockRepo.On("GetByEmail", "ada@example.com").Return(user, nil).Once()
+	mockRepo.On("CreateRefreshToken", mock.Anything).Run(func(args mock.Arguments) {
+		tokenID = args.Get(0).(*models.RefreshToken).TokenID
+	}).Return(nil).Once()
+	tokens, err := service.Login(models.Credentials{Email: "ada@example.com", Password: "correct horse"})
+	assert.NoError(t, err)
+
+	// Set up mock expectations
+	mockRepo.On("RevokeRefreshToken", tokenID).Return(nil).Once()
+	mockRepo.On("GetByID", 7).Return(user, nil).Once()
+	mockRepo.On("CreateRefreshToken", mock.MatchedBy(func(token *models.RefreshToken) bool {
+		return token.TokenID != tokenID
+	})).Return(nil).Once()
+
+	// Call the function under test
+	refreshed, err := service.Refresh(tokens.RefreshToken)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.NotEqual(t, tokens.RefreshToken, refreshed.RefreshToken)
+
+	// A revoked refresh token cannot be used again, since revoking it again changes nothing
+	mockRepo.On("RevokeRefreshToken", tokenID).Return(gorm.ErrRecordNotFound).Once()
+	_, err = service.Refresh(tokens.RefreshToken)
+	assert.ErrorIs(t, err, services.ErrInvalidToken)
+
+	// An access token is not a refresh token
+	_, err = service.Refresh(tokens.AccessToken)
+	assert.ErrorIs(t, err, services.ErrInvalidToken)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_Authenticate_Rejected(t *testing.T) {
+	// Create mock repository
+	mockRepo := new(repomocks.MockUserRepository)
+
+	// Create auth service with mock
+	service := services.NewAuthService(mockRepo, testAuthConfig())
+
+	// Tokens signed with another secret or already expired are rejected
+	other := services.DefaultAuthConfig()
+	other.Secret = []byte("another-secret")
+	other.BcryptCost = bcrypt.MinCost
+	otherService := services.NewAuthService(mockRepo, other)
+
+	expired := services.DefaultAuthConfig()
+	expired.Secret = []byte("test-secret")
+	expired.BcryptCost = bcrypt.MinCost
+	expired.AccessTTL = -time.Minute
+	expiredService := services.NewAuthService(mockRepo, expired)
+
+	// Set up mock expectations
+	mockRepo.On("GetByEmail", "ada@example.com").Return(signedUpUser(t), nil).Twice()
+	mockRepo.On("CreateRefreshToken", mock.Anything).Return(nil).Twice()
+
+	foreign, err := otherService.Login(models.Credentials{Email: "ada@example.com", Password: "correct horse"})
+	assert.NoError(t, err)
+	stale, err := expiredService.Login(models.Credentials{Email: "ada@example.com", Password: "correct horse"})
+	assert.NoError(t, err)
+
+	// Call the function under test
+	for _, token := range []string{foreign.AccessToken, stale.AccessToken, "not-a-token"} {
+		user, err := service.Authenticate(token)
+
+		// Assertions
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, services.ErrInvalidToken)
+	}
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+}
+
+func TestAuthService_Register_AdminEmail(t *testing.T) {
+	// Create mock repository
+	mockRepo := new(repomocks.MockUserRepository)
+
+	// Create auth service with mock and an admin address
+	config := testAuthConfig()
+	config.AdminEmail = "Ada@Example.com"
+	service := services.NewAuthService(mockRepo, config)
+
+	// Set up mock expectations
+	mockRepo.On("GetByEmail", "ada@example.com").Return(nil, gorm.ErrRecordNotFound).Once()
+	mockRepo.On("Create", mock.MatchedBy(func(user *models.User) bool {
+		return user.Role == models.RoleManager
+	})).Return(nil).Once()
+	mockRepo.On("CreateRefreshToken", mock.Anything).Return(nil).Once()
+
+	// Call the function under test
+	tokens, err := service.Register(models.Credentials{Email: "ada@example.com", Password: "correct horse"})
+
+	// Assertions: registering the admin address first does not make anybody an admin
+	assert.NoError(t, err)
+	assert.Equal(t, models.RoleManager, tokens.User.Role)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_SeedAdmin(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *models.User
+		password string
+		created  bool
+		promoted bool
+		err      error
+	}{
+		{"Creates a missing admin", nil, "correct horse", true, false, nil},
+		{"Promotes an account with the admin password", signedUpUser(t), "correct horse", false, true, nil},
+		{"Refuses an account with another password", signedUpUser(t), "battery staple", false, false, services.ErrInvalidCredentials},
+		{"Needs an admin password", nil, "", false, false, services.ErrInvalidUser},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repository
+			mockRepo := new(repomocks.MockUserRepository)
+
+			// Create auth service with mock and an admin account
+			config := testAuthConfig()
+			config.AdminEmail = "Ada@Example.com"
+			config.AdminPassword = tt.password
+			service := services.NewAuthService(mockRepo, config)
+
+			// Set up mock expectations
+			if tt.password != "" {
+				if tt.existing != nil {
+					mockRepo.On("GetByEmail", "ada@example.com").Return(tt.existing, nil).Once()
+				} else {
+					mockRepo.On("GetByEmail", "ada@example.com").Return(nil, gorm.ErrRecordNotFound).Once()
+				}
+			}
+			if tt.created {
+				mockRepo.On("Create", mock.MatchedBy(func(user *models.User) bool {
+					return user.Email == "ada@example.com" && user.Role == models.RoleAdmin
+				})).Return(nil).Once()
+			}
+			if tt.promoted {
+				mockRepo.On("Update", mock.MatchedBy(func(user *models.User) bool {
+					return user.ID == 7 && user.Role == models.RoleAdmin
+				})).Return(nil).Once()
+			}
+
+			// Call the function under test
+			err := service.SeedAdmin()
+
+			// Assertions
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			// Verify that all expected calls were made
+			mockRepo.AssertExpectations(t)
+			if !tt.promoted {
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAuthService_SetRole(t *testing.T) {
+	admin := &models.User{ID: 1, Role: models.RoleAdmin}
+
+	tests := []struct {
+		name      string
+		userID    int
+		role      string
+		found     bool
+		expectErr error
+	}{
+		{"Promote to commissioner", 7, models.RoleCommissioner, true, nil},
+		{"Unknown role", 7, "owner", false, services.ErrInvalidRole},
+		{"Own role", 1, models.RoleViewer, false, services.ErrOwnRole},
+		{"Unknown user", 9, models.RoleViewer, false, gorm.ErrRecordNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repository
+			mockRepo := new(repomocks.MockUserRepository)
+
+			// Create auth service with mock
+			service := services.NewAuthService(mockRepo, testAuthConfig())
+
+			// Set up mock expectations
+			if tt.found {
+				mockRepo.On("GetByID", tt.userID).Return(&models.User{ID: uint(tt.userID), Role: models.RoleManager}, nil).Once()
+				mockRepo.On("Update", &models.User{ID: uint(tt.userID), Role: tt.role}).Return(nil).Once()
+			} else if tt.expectErr == gorm.ErrRecordNotFound {
+				mockRepo.On("GetByID", tt.userID).Return(nil, gorm.ErrRecordNotFound).Once()
+			}
+
+			// Call the function under test
+			user, err := service.SetRole(admin, tt.userID, tt.role)
+
+			// Assertions
+			if tt.expectErr != nil {
+				assert.Nil(t, user)
+				assert.ErrorIs(t, err, tt.expectErr)
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.role, user.Role)
+			}
+
+			// Verify that all expected calls were made
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
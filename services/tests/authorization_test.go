@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"insider-league/models"
+	"insider-league/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasPermission(t *testing.T) {
+	tests := []struct {
+		role       string
+		permission string
+		expected   bool
+	}{
+		{models.RoleAdmin, models.PermissionResetLeague, true},
+		{models.RoleAdmin, models.PermissionDelete, true},
+		{models.RoleCommissioner, models.PermissionPlayWeeks, true},
+		{models.RoleCommissioner, models.PermissionEditResults, true},
+		{models.RoleCommissioner, models.PermissionResetLeague, false},
+		{models.RoleCommissioner, models.PermissionDelete, false},
+		{models.RoleManager, models.PermissionPlayFantasy, true},
+		{models.RoleManager, models.PermissionPlayWeeks, false},
+		{models.RoleViewer, models.PermissionPlayFantasy, false},
+		{"", models.PermissionPlayFantasy, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role+" "+tt.permission, func(t *testing.T) {
+			// Call the function under test
+			allowed := services.HasPermission(tt.role, tt.permission)
+
+			// Assertions
+			assert.Equal(t, tt.expected, allowed)
+		})
+	}
+}
+
+func TestRolesWithPermission(t *testing.T) {
+	// Call the function under test and assert the roles come most privileged first
+	assert.Equal(t, []string{models.RoleAdmin, models.RoleCommissioner}, services.RolesWithPermission(models.PermissionEditResults))
+	assert.Equal(t, []string{models.RoleAdmin}, services.RolesWithPermission(models.PermissionResetLeague))
+	assert.Equal(t, []string{models.RoleAdmin, models.RoleCommissioner, models.RoleManager}, services.RolesWithPermission(models.PermissionPlayFantasy))
+	assert.Nil(t, services.RolesWithPermission("unknown:permission"))
+}
+
+func TestScopeAllows(t *testing.T) {
+	tests := []struct {
+		scope      string
+		permission string
+		expected   bool
+	}{
+		{models.ScopeReadOnly, models.PermissionPlayWeeks, false},
+		{models.ScopeReadOnly, models.PermissionPlayFantasy, false},
+		{models.ScopeSimulate, models.PermissionPlayWeeks, true},
+		{models.ScopeSimulate, models.PermissionResetLeague, false},
+		{models.ScopeAdmin, models.PermissionResetLeague, true},
+		{models.ScopeAdmin, models.PermissionManageUsers, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope+" "+tt.permission, func(t *testing.T) {
+			// Call the function under test
+			allowed := services.ScopeAllows(tt.scope, tt.permission)
+
+			// Assertions
+			assert.Equal(t, tt.expected, allowed)
+		})
+	}
+
+	assert.Equal(t, []string{models.ScopeSimulate, models.ScopeAdmin}, services.ScopesWithPermission(models.PermissionPlayWeeks))
+}
@@ -0,0 +1,312 @@
+package tests
+
+import (
+	"insider-league/eventbus"
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// currentPrice returns today's decimal odds of a selection of the even match
+func currentPrice(t *testing.T, market, selection string) float64 {
+	matchRepo := new(repomocks.MockMatchRepository)
+	matchRepo.On("GetByID", 5).Return(evenMatch(), nil).Once()
+	odds, err := services.NewOddsService(matchRepo, services.NewWeekStatus(), services.DefaultOddsConfig()).GetMatchOdds(5, nil)
+	assert.NoError(t, err)
+	for _, price := range marketByName(t, odds, market).Selections {
+		if price.Selection == selection {
+			return price.Decimal
+		}
+	}
+	t.Fatalf("selection %q not found", selection)
+	return 0
+}
+
+func TestBettingService_GetWallet_Opens(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockBettingRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+
+	// Create betting service with mocks, pricing matches with the default odds
+	odds := services.NewOddsService(mockMatchRepo, services.NewWeekStatus(), services.DefaultOddsConfig())
+	service := services.NewBettingService(mockRepo, odds, services.DefaultBettingConfig())
+
+	// Set up mock expectations
+	mockRepo.On("GetWallet", 7).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockRepo.On("CreateWallet", &models.Wallet{UserID: 7, Balance: 1000}, &models.WalletTransaction{
+		UserID: 7, Type: models.TransactionGrant, Amount: 1000, Balance: 1000, Description: "Opening balance",
+	}).Return(nil).Once()
+
+	// Call the function under test
+	wallet, err := service.GetWallet(7)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 1000.0, wallet.Balance)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBettingService_PlaceBet(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockBettingRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+
+	// Create betting service with mocks, pricing matches with the default odds
+	odds := services.NewOddsService(mockMatchRepo, services.NewWeekStatus(), services.DefaultOddsConfig())
+	service := services.NewBettingService(mockRepo, odds, services.DefaultBettingConfig())
+	price := currentPrice(t, services.MarketMatchResult, "Home")
+
+	// Set up mock expectations
+	mockMatchRepo.On("GetByID", 5).Return(evenMatch(), nil).Once()
+	mockRepo.On("GetWallet", 7).Return(&models.Wallet{ID: 1, UserID: 7, Balance: 100}, nil).Once()
+	mockRepo.On("SaveBet", &models.Wallet{ID: 1, UserID: 7, Balance: 75}, mock.MatchedBy(func(bet *models.Bet) bool {
+		return bet.UserID == 7 && bet.MatchID == 5 && bet.Stake == 25 && bet.Odds == price && bet.Status == models.BetPending
+	}), mock.MatchedBy(func(entry *models.WalletTransaction) bool {
+		return entry.Type == models.TransactionStake && entry.Amount == -25 && entry.Balance == 75
+	})).Return(nil).Once()
+
+	// Call the function under test
+	bet, err := service.PlaceBet(&models.User{ID: 7}, models.BetRequest{MatchID: 5, Market: services.MarketMatchResult, Selection: "Home", Stake: 25, Odds: price})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.InDelta(t, 25*price, bet.PotentialReturn, 0.005)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockMatchRepo.AssertExpectations(t)
+}
+
+func TestBettingService_PlaceBet_Rejected(t *testing.T) {
+	played := evenMatch()
+	played.IsPlayed = true
+
+	tests := []struct {
+		name      string
+		match     *models.Match
+		req       models.BetRequest
+		balance   float64
+		expectErr error
+	}{
+		{"Stake too small", nil, models.BetRequest{MatchID: 5, Market: services.MarketMatchResult, Selection: "Home", Stake: 0.5}, 100, services.ErrInvalidBet},
+		{"Played match", played, models.BetRequest{MatchID: 5, Market: services.MarketMatchResult, Selection: "Home", Stake: 10}, 100, services.ErrMatchPlayed},
+		{"Unknown selection", evenMatch(), models.BetRequest{MatchID: 5, Market: services.MarketMatchResult, Selection: "Both", Stake: 10}, 100, services.ErrInvalidBet},
+		{"Odds changed", evenMatch(), models.BetRequest{MatchID: 5, Market: services.MarketMatchResult, Selection: "Home", Stake: 10, Odds: 9.99}, 100, services.ErrOddsChanged},
+		{"Insufficient funds", evenMatch(), models.BetRequest{MatchID: 5, Market: services.MarketOverUnder, Selection: "Over 2.5", Stake: 150}, 100, services.ErrInsufficientFunds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repositories
+			mockRepo := new(repomocks.MockBettingRepository)
+			mockMatchRepo := new(repomocks.MockMatchRepository)
+
+			// Create betting service with mocks, pricing matches with the default odds
+			odds := services.NewOddsService(mockMatchRepo, services.NewWeekStatus(), services.DefaultOddsConfig())
+			service := services.NewBettingService(mockRepo, odds, services.DefaultBettingConfig())
+
+			// Set up mock expectations
+			if tt.match != nil {
+				mockMatchRepo.On("GetByID", 5).Return(tt.match, nil).Once()
+			}
+			if tt.expectErr == services.ErrInsufficientFunds {
+				mockRepo.On("GetWallet", 7).Return(&models.Wallet{ID: 1, UserID: 7, Balance: tt.balance}, nil).Once()
+			}
+
+			// Call the function under test
+			bet, err := service.PlaceBet(&models.User{ID: 7}, tt.req)
+
+			// Assertions
+			assert.Nil(t, bet)
+			assert.ErrorIs(t, err, tt.expectErr)
+			mockRepo.AssertNotCalled(t, "SaveBet", mock.Anything, mock.Anything, mock.Anything)
+
+			// Verify that all expected calls were made
+			mockRepo.AssertExpectations(t)
+			mockMatchRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBettingService_PlaceBet_WeekInProgress(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockBettingRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+
+	// Create betting service with mocks while the match's week is being played
+	weeks := services.NewWeekStatus()
+	weeks.Start(3, 3)
+	odds := services.NewOddsService(mockMatchRepo, weeks, services.DefaultOddsConfig())
+	service := services.NewBettingService(mockRepo, odds, services.DefaultBettingConfig())
+
+	// Set up mock expectations
+	mockMatchRepo.On("GetByID", 5).Return(evenMatch(), nil).Once()
+
+	// Call the function under test
+	bet, err := service.PlaceBet(&models.User{ID: 7}, models.BetRequest{MatchID: 5, Market: services.MarketMatchResult, Selection: "Home", Stake: 10})
+
+	// Assertions
+	assert.Nil(t, bet)
+	assert.ErrorIs(t, err, services.ErrWeekInProgress)
+	mockRepo.AssertNotCalled(t, "SaveBet", mock.Anything, mock.Anything, mock.Anything)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockMatchRepo.AssertExpectations(t)
+}
+
+func TestBettingService_HandleEvent_Settles(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockBettingRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+
+	// Create betting service with mocks, pricing matches with the default odds
+	odds := services.NewOddsService(mockMatchRepo, services.NewWeekStatus(), services.DefaultOddsConfig())
+	service := services.NewBettingService(mockRepo, odds, services.DefaultBettingConfig())
+
+	// Every pending bet on a played match is settled, including one left over from an earlier week whose
+	// settlement failed; bets on matches still to be played wait
+	match := &models.Match{ID: 5, Week: 1, HomeTeamScore: 2, AwayTeamScore: 1, IsPlayed: true}
+	unplayed := &models.Match{ID: 9, Week: 3}
+
+	// Set up mock expectations
+	mockRepo.On("GetPendingBets").Return([]models.Bet{
+		{ID: 1, UserID: 7, MatchID: 5, Match: match, Market: services.MarketMatchResult, Selection: "Home", Stake: 10, Odds: 2.5, PotentialReturn: 25, Status: models.BetPending},
+		{ID: 2, UserID: 7, MatchID: 5, Match: match, Market: services.MarketCorrectScore, Selection: "1-1", Stake: 5, Odds: 8, PotentialReturn: 40, Status: models.BetPending},
+		{ID: 3, UserID: 7, MatchID: 9, Match: unplayed, Market: services.MarketMatchResult, Selection: "Away", Stake: 5, Odds: 3, PotentialReturn: 15, Status: models.BetPending},
+	}, nil).Once()
+	mockRepo.On("GetWallet", 7).Return(&models.Wallet{ID: 1, UserID: 7, Balance: 85}, nil).Once()
+	mockRepo.On("SaveBet", &models.Wallet{ID: 1, UserID: 7, Balance: 110}, mock.MatchedBy(func(bet *models.Bet) bool {
+		return bet.ID == 1 && bet.Status == models.BetWon && bet.Payout == 25 && bet.SettledAt != nil
+	}), mock.MatchedBy(func(entry *models.WalletTransaction) bool {
+		return entry != nil && entry.Type == models.TransactionPayout && entry.Amount == 25 && entry.Balance == 110
+	})).Return(nil).Once()
+	mockRepo.On("GetWallet", 7).Return(&models.Wallet{ID: 1, UserID: 7, Balance: 110}, nil).Once()
+	mockRepo.On("SaveBet", mock.Anything, mock.MatchedBy(func(bet *models.Bet) bool {
+		return bet.ID == 2 && bet.Status == models.BetLost && bet.Payout == 0
+	}), (*models.WalletTransaction)(nil)).Return(nil).Once()
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{Type: eventbus.WeekPlayed, Week: 2, Matches: []models.Match{}})
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "GetBetsByMatch", mock.Anything)
+}
+
+func TestBettingService_HandleEvent_Resettles(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockBettingRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+
+	// Create betting service with mocks, pricing matches with the default odds
+	odds := services.NewOddsService(mockMatchRepo, services.NewWeekStatus(), services.DefaultOddsConfig())
+	service := services.NewBettingService(mockRepo, odds, services.DefaultBettingConfig())
+
+	// The edited result turns a winning home bet into a loser and a losing draw bet into a winner
+	edited := models.Match{ID: 5, HomeTeamScore: 1, AwayTeamScore: 1, IsPlayed: true}
+
+	// Set up mock expectations
+	mockRepo.On("GetBetsByMatch", 5).Return([]models.Bet{
+		{ID: 1, UserID: 7, MatchID: 5, Market: services.MarketMatchResult, Selection: "Home", Stake: 10, Odds: 2.5, PotentialReturn: 25, Status: models.BetWon, Payout: 25},
+		{ID: 2, UserID: 8, MatchID: 5, Market: services.MarketMatchResult, Selection: "Draw", Stake: 10, Odds: 3.2, PotentialReturn: 32, Status: models.BetLost},
+		{ID: 3, UserID: 8, MatchID: 5, Market: services.MarketOverUnder, Selection: "Under 2.5", Stake: 10, Odds: 2, PotentialReturn: 20, Status: models.BetLost},
+		{ID: 4, UserID: 9, MatchID: 5, Market: services.MarketMatchResult, Selection: "Draw", Stake: 10, Odds: 3, PotentialReturn: 30, Status: models.BetLost, Final: true},
+	}, nil).Once()
+	mockRepo.On("GetPendingBets").Return([]models.Bet{}, nil).Once()
+	mockRepo.On("GetWallet", 7).Return(&models.Wallet{ID: 1, UserID: 7, Balance: 30}, nil).Once()
+	mockRepo.On("SaveBet", &models.Wallet{ID: 1, UserID: 7, Balance: 5}, mock.MatchedBy(func(bet *models.Bet) bool {
+		return bet.ID == 1 && bet.Status == models.BetLost && bet.Payout == 0
+	}), mock.MatchedBy(func(entry *models.WalletTransaction) bool {
+		return entry != nil && entry.Type == models.TransactionPayoutReversal && entry.Amount == -25 && entry.Balance == 5
+	})).Return(nil).Once()
+	mockRepo.On("GetWallet", 8).Return(&models.Wallet{ID: 2, UserID: 8, Balance: 0}, nil).Once()
+	mockRepo.On("SaveBet", &models.Wallet{ID: 2, UserID: 8, Balance: 32}, mock.MatchedBy(func(bet *models.Bet) bool {
+		return bet.ID == 2 && bet.Status == models.BetWon && bet.Payout == 32
+	}), mock.MatchedBy(func(entry *models.WalletTransaction) bool {
+		return entry != nil && entry.Type == models.TransactionPayout && entry.Amount == 32
+	})).Return(nil).Once()
+	mockRepo.On("GetWallet", 8).Return(&models.Wallet{ID: 2, UserID: 8, Balance: 32}, nil).Once()
+	mockRepo.On("SaveBet", &models.Wallet{ID: 2, UserID: 8, Balance: 52}, mock.MatchedBy(func(bet *models.Bet) bool {
+		return bet.ID == 3 && bet.Status == models.BetWon
+	}), mock.Anything).Return(nil).Once()
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{Type: eventbus.MatchResultEdited, Week: 1, Matches: []models.Match{edited}})
+
+	// Verify that all expected calls were made; the bet made final by a reset is left alone
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "GetWallet", 9)
+}
+
+func TestBettingService_HandleEvent_Reset(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockBettingRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+
+	// Create betting service with mocks, pricing matches with the default odds
+	odds := services.NewOddsService(mockMatchRepo, services.NewWeekStatus(), services.DefaultOddsConfig())
+	service := services.NewBettingService(mockRepo, odds, services.DefaultBettingConfig())
+
+	// Set up mock expectations; the open bet is refunded and every bet is made final, keeping wallets and ledgers
+	mockRepo.On("GetPendingBets").Return([]models.Bet{
+		{ID: 1, UserID: 7, MatchID: 5, Market: services.MarketMatchResult, Selection: "Home", Stake: 10, Odds: 2.5, PotentialReturn: 25, Status: models.BetPending},
+	}, nil).Once()
+	mockRepo.On("GetWallet", 7).Return(&models.Wallet{ID: 1, UserID: 7, Balance: 90}, nil).Once()
+	mockRepo.On("SaveBet", &models.Wallet{ID: 1, UserID: 7, Balance: 100}, mock.MatchedBy(func(bet *models.Bet) bool {
+		return bet.ID == 1 && bet.Status == models.BetVoid && bet.Payout == 10 && bet.Final
+	}), mock.MatchedBy(func(entry *models.WalletTransaction) bool {
+		return entry.Type == models.TransactionRefund && entry.Amount == 10 && entry.Balance == 100
+	})).Return(nil).Once()
+	mockRepo.On("FinalizeBets").Return(nil).Once()
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{Type: eventbus.LeagueReset})
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOddsService_SelectionWon(t *testing.T) {
+	service := services.NewOddsService(new(repomocks.MockMatchRepository), services.NewWeekStatus(), services.DefaultOddsConfig())
+
+	tests := []struct {
+		market    string
+		selection string
+		home      int
+		away      int
+		expected  bool
+	}{
+		{services.MarketMatchResult, "Home", 2, 1, true},
+		{services.MarketMatchResult, "Draw", 2, 1, false},
+		{services.MarketMatchResult, "Away", 0, 3, true},
+		{services.MarketOverUnder, "Over 2.5", 2, 1, true},
+		{services.MarketOverUnder, "Under 2.5", 1, 1, true},
+		{services.MarketCorrectScore, "2-1", 2, 1, true},
+		{services.MarketCorrectScore, "1-2", 2, 1, false},
+		{services.MarketCorrectScore, "Any other score", 6, 0, true},
+		{services.MarketCorrectScore, "Any other score", 5, 5, false},
+	}
+
+	for _, tt := range tests {
+		// Call the function under test
+		won, err := service.SelectionWon(tt.market, tt.selection, tt.home, tt.away)
+
+		// Assertions
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, won, "%s %s at %d-%d", tt.market, tt.selection, tt.home, tt.away)
+	}
+
+	// Selections that are not priced are rejected
+	for _, selection := range []string{"6-0", "01-1", "Both"} {
+		_, err := service.SelectionWon(services.MarketCorrectScore, selection, 6, 0)
+		assert.ErrorIs(t, err, services.ErrUnknownSelection)
+	}
+}
@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"insider-league/eventbus"
+	servicemocks "insider-league/mocks/services"
+	"insider-league/models"
+	"insider-league/services"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCalibrationService_CalibrateFromMatches(t *testing.T) {
+	// Create mock services
+	mockTeamService := new(servicemocks.MockTeamService)
+	mockMatchService := new(servicemocks.MockMatchService)
+
+	// Create calibration service with mocks
+	service := services.NewCalibrationService(mockTeamService, mockMatchService, eventbus.NewBus(), services.NewLeagueLock())
+
+	teams := []models.Team{
+		{ID: 1, Name: "Team A", Strength: 80},
+		{ID: 2, Name: "Team B", Strength: 80},
+		{ID: 3, Name: "Team C", Strength: 80},
+	}
+
+	// Team A wins everything, Team C loses everything, plus an unplayed fixture that must be ignored
+	matches := []models.Match{
+		{ID: 1, Week: 1, HomeTeamID: 1, AwayTeamID: 2, HomeTeamScore: 3, AwayTeamScore: 1, IsPlayed: true},
+		{ID: 2, Week: 2, HomeTeamID: 2, AwayTeamID: 3, HomeTeamScore: 2, AwayTeamScore: 0, IsPlayed: true},
+		{ID: 3, Week: 3, HomeTeamID: 3, AwayTeamID: 1, HomeTeamScore: 0, AwayTeamScore: 4, IsPlayed: true},
+		{ID: 4, Week: 4, HomeTeamID: 2, AwayTeamID: 1, HomeTeamScore: 1, AwayTeamScore: 2, IsPlayed: true},
+		{ID: 5, Week: 5, HomeTeamID: 1, AwayTeamID: 3, IsPlayed: false},
+	}
+
+	// Set up mock expectations
+	mockMatchService.On("GetAll").Return(matches, nil).Once()
+	mockTeamService.On("GetAll").Return(teams, nil).Once()
+
+	// Call the function under test without applying
+	result, err := service.CalibrateFromMatches(false)
+
+	// Assertions
+	assert.NoError(t, err, "CalibrateFromMatches should not return an error")
+	assert.False(t, result.Applied, "Strengths should not be stored without apply")
+	assert.Equal(t, 4, result.Diagnostics.Matches, "Only played matches should be used")
+	assert.True(t, result.Diagnostics.Converged, "Fit should converge on a connected fixture list")
+	assert.Greater(t, result.Diagnostics.LogLikelihood, result.Diagnostics.NullLogLikelihood, "Fitted model should beat the null model")
+	assert.Len(t, result.Teams, 3, "All teams with results should be fitted")
+	assert.Greater(t, result.Teams[0].Strength, result.Teams[1].Strength, "Team A should be stronger than Team B")
+	assert.Greater(t, result.Teams[1].Strength, result.Teams[2].Strength, "Team B should be stronger than Team C")
+
+	// Verify that all expected calls were made
+	mockMatchService.AssertExpectations(t)
+	mockTeamService.AssertExpectations(t)
+}
+
+func TestCalibrationService_CalibrateFromCSV(t *testing.T) {
+	// Create mock services
+	mockTeamService := new(servicemocks.MockTeamService)
+	mockMatchService := new(servicemocks.MockMatchService)
+
+	// Create calibration service with mocks
+	bus := eventbus.NewBus()
+	service := services.NewCalibrationService(mockTeamService, mockMatchService, bus, services.NewLeagueLock())
+
+	// Record the events published when the strengths are stored
+	var published []eventbus.Event
+	bus.Subscribe(func(event eventbus.Event) {
+		published = append(published, event)
+	})
+
+	teams := []models.Team{
+		{ID: 1, Name: "Arsenal", Strength: 80},
+		{ID: 2, Name: "Chelsea", Strength: 80},
+	}
+
+	csv := "home_team,away_team,home_goals,away_goals\n" +
+		"Arsenal,Chelsea,2,0\n" +
+		"chelsea,arsenal,1,1\n"
+
+	// Set up mock expectations; teams are read once to resolve names and once to fit
+	mockTeamService.On("GetAll").Return(teams, nil).Twice()
+	mockTeamService.On("SaveRatings", mock.MatchedBy(func(team *models.Team) bool {
+		return team.Strength == team.BaseStrength
+	})).Return(nil).Twice()
+	mockTeamService.On("GetTeamRankings").Return(teams, nil).Once()
+
+	// Call the function under test and apply the result
+	result, err := service.CalibrateFromCSV(strings.NewReader(csv), true)
+
+	// Assertions
+	assert.NoError(t, err, "CalibrateFromCSV should not return an error")
+	assert.True(t, result.Applied, "Strengths should be stored with apply")
+	assert.Equal(t, 2, result.Diagnostics.Matches, "Both rows should be used")
+	assert.Greater(t, result.Teams[0].Strength, result.Teams[1].Strength, "Arsenal should be fitted stronger")
+	assert.Len(t, published, 1, "Applying should announce the new strengths once")
+	assert.Equal(t, eventbus.TeamChanged, published[0].Type, "A team changed event should be published")
+
+	// Verify that all expected calls were made
+	mockTeamService.AssertExpectations(t)
+	mockMatchService.AssertExpectations(t)
+}
+
+func TestCalibrationService_CalibrateFromMatches_LeagueBusy(t *testing.T) {
+	// Create mock services
+	mockTeamService := new(servicemocks.MockTeamService)
+	mockMatchService := new(servicemocks.MockMatchService)
+
+	// Create calibration service with a lock another league operation holds
+	lock := services.NewLeagueLock()
+	lock.TryLock()
+	service := services.NewCalibrationService(mockTeamService, mockMatchService, eventbus.NewBus(), lock)
+
+	// Call the function under test
+	result, err := service.CalibrateFromMatches(true)
+
+	// Assertions
+	assert.ErrorIs(t, err, services.ErrLeagueBusy, "Applying should wait for no other league operation")
+	assert.Nil(t, result, "No result should be returned on error")
+
+	// Verify that nothing was read or stored
+	mockMatchService.AssertNotCalled(t, "GetAll")
+	mockTeamService.AssertNotCalled(t, "SaveRatings", mock.Anything)
+}
+
+func TestCalibrationService_CalibrateFromCSV_UnknownTeam(t *testing.T) {
+	// Create mock services
+	mockTeamService := new(servicemocks.MockTeamService)
+	mockMatchService := new(servicemocks.MockMatchService)
+
+	// Create calibration service with mocks
+	service := services.NewCalibrationService(mockTeamService, mockMatchService, eventbus.NewBus(), services.NewLeagueLock())
+
+	csv := "home_team,away_team,home_goals,away_goals\n" +
+		"Arsenal,Tottenham,2,0\n"
+
+	// Set up mock expectations
+	mockTeamService.On("GetAll").Return([]models.Team{{ID: 1, Name: "Arsenal"}}, nil).Once()
+
+	// Call the function under test
+	result, err := service.CalibrateFromCSV(strings.NewReader(csv), false)
+
+	// Assertions
+	assert.ErrorIs(t, err, services.ErrInvalidResultsFile, "Unknown teams should be rejected")
+	assert.Nil(t, result, "No result should be returned on error")
+
+	// Verify that all expected calls were made
+	mockTeamService.AssertExpectations(t)
+}
@@ -0,0 +1,135 @@
+package tests
+
+import (
+	"insider-league/eventbus"
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// sixWeekSeason returns one match per week for a six-week season, so the first half ends after week 3
+func sixWeekSeason() []models.Match {
+	var matches []models.Match
+	for week := range 6 {
+		matches = append(matches, models.Match{ID: uint(week + 1), Week: week + 1})
+	}
+	return matches
+}
+
+func TestChipService_Activate(t *testing.T) {
+	_, players := fantasySquadPlayers(6.0)
+
+	tests := []struct {
+		name      string
+		chip      string
+		openWeek  int
+		used      []models.ChipActivation
+		expectErr error
+	}{
+		{"Wildcard in each half", models.ChipWildcard, 4, []models.ChipActivation{{Week: 2, Chip: models.ChipWildcard}}, nil},
+		{"Second wildcard in the same half", models.ChipWildcard, 3, []models.ChipActivation{{Week: 2, Chip: models.ChipWildcard}}, services.ErrChipUnavailable},
+		{"Bench boost already used", models.ChipBenchBoost, 5, []models.ChipActivation{{Week: 1, Chip: models.ChipBenchBoost}}, services.ErrChipUnavailable},
+		{"Another chip active this week", models.ChipTripleCaptain, 4, []models.ChipActivation{{Week: 4, Chip: models.ChipBenchBoost}}, services.ErrChipAlreadyActive},
+		{"Unknown chip", "double_gameweek", 4, nil, services.ErrInvalidChip},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repositories
+			mockRepo := new(repomocks.MockChipRepository)
+			mockManagerRepo := new(repomocks.MockManagerRepository)
+			mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+			mockLineupRepo := new(repomocks.MockLineupRepository)
+			mockMatchRepo := new(repomocks.MockMatchRepository)
+
+			// Create chip service with mocks
+			service := services.NewChipService(mockRepo, mockManagerRepo, mockSquadRepo, mockLineupRepo, mockMatchRepo, services.NewWeekStatus())
+
+			// Set up mock expectations
+			mockManagerRepo.On("GetByID", 1).Return(&models.Manager{ID: 1, Bank: 2.5}, nil).Once()
+			mockMatchRepo.On("GetUnplayedWeeks").Return([]int{tt.openWeek, 6}, nil).Maybe()
+			mockMatchRepo.On("GetAll").Return(sixWeekSeason(), nil).Maybe()
+			mockSquadRepo.On("GetByManager", 1).Return(fantasySquad(1, players), nil).Maybe()
+			mockRepo.On("GetByManager", 1).Return(tt.used, nil).Maybe()
+			if tt.expectErr == nil {
+				mockRepo.On("Create", mock.MatchedBy(func(activation *models.ChipActivation) bool {
+					return activation.Week == tt.openWeek && activation.Chip == tt.chip
+				})).Return(nil).Once()
+			}
+
+			// Call the function under test
+			activation, err := service.Activate(1, 0, tt.chip)
+
+			// Assertions
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr, "The chip should be rejected")
+				assert.Nil(t, activation, "No activation should be returned")
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+			} else {
+				assert.NoError(t, err, "Activate should not return an error")
+			}
+
+			// Verify that all expected calls were made
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestChipService_FreeHitReverts(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockChipRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockLineupRepo := new(repomocks.MockLineupRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+
+	// Create chip service with mocks
+	service := services.NewChipService(mockRepo, mockManagerRepo, mockSquadRepo, mockLineupRepo, mockMatchRepo, services.NewWeekStatus())
+
+	_, players := fantasySquadPlayers(6.0)
+	squad := fantasySquad(1, players)
+	manager := &models.Manager{ID: 1, Bank: 2.5}
+
+	// Set up mock expectations for playing the free hit
+	mockManagerRepo.On("GetByID", 1).Return(manager, nil).Once()
+	mockMatchRepo.On("GetUnplayedWeeks").Return([]int{4, 5, 6}, nil).Once()
+	mockMatchRepo.On("GetAll").Return(sixWeekSeason(), nil).Once()
+	mockSquadRepo.On("GetByManager", 1).Return(squad, nil).Once()
+	mockRepo.On("GetByManager", 1).Return([]models.ChipActivation{}, nil).Once()
+	var saved *models.ChipActivation
+	mockRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(*models.ChipActivation)
+	}).Return(nil).Once()
+
+	// Call the function under test
+	_, err := service.Activate(1, 0, models.ChipFreeHit)
+
+	// Assertions
+	assert.NoError(t, err, "Activate should not return an error")
+	assert.Len(t, saved.SavedPlayers, 15, "The free hit should remember the squad")
+	assert.Equal(t, 2.5, saved.SavedBank, "The free hit should remember the bank")
+
+	// Set up mock expectations for the week being played with a different squad
+	freeHitSquad := &models.FantasySquad{ID: 1, ManagerID: 1, Players: squad.Players[:1]}
+	mockRepo.On("GetByWeek", 4).Return([]models.ChipActivation{*saved}, nil).Once()
+	mockSquadRepo.On("GetByManager", 1).Return(freeHitSquad, nil).Once()
+	mockSquadRepo.On("ReplacePlayers", mock.MatchedBy(func(squad *models.FantasySquad) bool {
+		return len(squad.Players) == 15
+	})).Return(nil).Once()
+	mockManagerRepo.On("UpdateBank", uint(1), 2.5).Return(nil).Once()
+	mockLineupRepo.On("Delete", 1, 4).Return(nil).Once()
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{Type: eventbus.WeekPlayed, Week: 4})
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockSquadRepo.AssertExpectations(t)
+	mockManagerRepo.AssertExpectations(t)
+	mockManagerRepo.AssertNotCalled(t, "Update", mock.Anything)
+	mockLineupRepo.AssertExpectations(t)
+}
@@ -0,0 +1,379 @@
+package tests
+
+import (
+	"insider-league/eventbus"
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestFantasyScoringRules_Score(t *testing.T) {
+	rules := services.DefaultFantasyScoringRules()
+
+	tests := []struct {
+		name     string
+		record   models.PlayerGameweek
+		expected int
+	}{
+		{"Forward scores twice", models.PlayerGameweek{Position: models.PositionForward, Minutes: 90, Goals: 2, GoalsConceded: 1}, 10},
+		{"Defender keeps a clean sheet and assists", models.PlayerGameweek{Position: models.PositionDefender, Minutes: 90, Assists: 1}, 9},
+		{"Goalkeeper makes saves but concedes three", models.PlayerGameweek{Position: models.PositionGoalkeeper, Minutes: 90, Saves: 7, GoalsConceded: 3}, 3},
+		{"Substitute is booked", models.PlayerGameweek{Position: models.PositionMidfielder, Minutes: 20, YellowCards: 1}, 0},
+		{"Short appearance gets no clean sheet", models.PlayerGameweek{Position: models.PositionDefender, Minutes: 45}, 1},
+		{"Sent off", models.PlayerGameweek{Position: models.PositionMidfielder, Minutes: 30, RedCards: 1}, -2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := tt.record
+			rules.Score(&record)
+			assert.Equal(t, tt.expected, record.Points, "Points should follow the rules table")
+		})
+	}
+}
+
+func TestFantasyScoringService_ScoreMatches(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockFantasyPointsRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+	mockEventRepo := new(repomocks.MockMatchEventRepository)
+	mockTransferRepo := new(repomocks.MockTransferRepository)
+	mockLineupRepo := new(repomocks.MockLineupRepository)
+	mockChipRepo := new(repomocks.MockChipRepository)
+
+	// Create fantasy scoring service with mocks
+	service := services.NewFantasyScoringService(mockRepo, mockManagerRepo, mockSquadRepo, mockPlayerRepo, mockEventRepo, mockTransferRepo, mockLineupRepo, mockChipRepo, services.DefaultFantasyConfig(), services.DefaultFantasyScoringRules())
+
+	// A 1-0 win with a full timeline, so nothing is simulated around the stored events
+	scorer, assister := uint(10), uint(11)
+	match := models.Match{ID: 1, Week: 2, HomeTeamID: 1, AwayTeamID: 2, HomeTeamScore: 1, AwayTeamScore: 0, IsPlayed: true}
+	events := []models.MatchEvent{
+		{MatchID: 1, Minute: 30, Type: models.EventGoal, TeamID: 1, PlayerID: &scorer, AssistPlayerID: &assister, HomeScore: 1},
+		{MatchID: 1, Minute: 45, Type: models.EventHalfTime, HomeScore: 1},
+		{MatchID: 1, Minute: 90, Type: models.EventFullTime, HomeScore: 1},
+	}
+	homeSquad := []models.Player{
+		{ID: 1, TeamID: 1, Position: models.PositionGoalkeeper, Rating: 80},
+		{ID: 2, TeamID: 1, Position: models.PositionDefender, Rating: 80},
+		{ID: 10, TeamID: 1, Position: models.PositionForward, Rating: 85},
+		{ID: 11, TeamID: 1, Position: models.PositionMidfielder, Rating: 82},
+	}
+	awaySquad := []models.Player{
+		{ID: 21, TeamID: 2, Position: models.PositionGoalkeeper, Rating: 80},
+	}
+
+	// Set up mock expectations
+	var stored []models.PlayerGameweek
+	mockEventRepo.On("GetByMatch", 1).Return(events, nil).Once()
+	mockPlayerRepo.On("GetByTeam", 1).Return(homeSquad, nil).Once()
+	mockPlayerRepo.On("GetByTeam", 2).Return(awaySquad, nil).Once()
+	mockRepo.On("ReplaceMatchPerformances", 1, mock.AnythingOfType("[]models.PlayerGameweek")).Run(func(args mock.Arguments) {
+		stored = args.Get(1).([]models.PlayerGameweek)
+	}).Return(nil).Once()
+
+	// The only manager has not picked a squad, so has nothing to score
+	mockRepo.On("GetPlayerGameweeks", 2).Return(stored, nil).Once()
+	mockManagerRepo.On("GetAll").Return([]models.Manager{{ID: 2}}, nil).Once()
+	mockRepo.On("GetPicks", 2, 2).Return([]models.FantasyPick{}, nil).Once()
+	mockSquadRepo.On("GetByManager", 2).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	// Call the function under test
+	err := service.ScoreMatches([]models.Match{match})
+
+	// Assertions
+	assert.NoError(t, err, "ScoreMatches should not return an error")
+
+	byPlayer := make(map[uint]models.PlayerGameweek)
+	for _, record := range stored {
+		byPlayer[record.PlayerID] = record
+	}
+	assert.Len(t, stored, 5, "Every player who appeared should have a performance")
+	assert.Equal(t, 6, byPlayer[10].Points, "Scorer: appearance plus a forward's goal")
+	assert.Equal(t, 6, byPlayer[11].Points, "Assister: appearance, assist and a midfielder's clean sheet")
+	assert.Equal(t, 6, byPlayer[1].Points, "Home goalkeeper: appearance and clean sheet")
+	assert.Equal(t, 1, byPlayer[21].GoalsConceded, "Away goalkeeper should concede the goal")
+	assert.Equal(t, 2, byPlayer[21].Points, "Away goalkeeper: appearance only")
+	assert.Equal(t, 2, byPlayer[21].Week, "Performances should carry the week")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockManagerRepo.AssertExpectations(t)
+	mockSquadRepo.AssertExpectations(t)
+	mockPlayerRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "SaveManagerGameweek", mock.Anything)
+}
+
+func TestFantasyScoringService_RescoreMatches(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockFantasyPointsRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+	mockEventRepo := new(repomocks.MockMatchEventRepository)
+	mockTransferRepo := new(repomocks.MockTransferRepository)
+	mockLineupRepo := new(repomocks.MockLineupRepository)
+	mockChipRepo := new(repomocks.MockChipRepository)
+
+	// Create fantasy scoring service with mocks
+	service := services.NewFantasyScoringService(mockRepo, mockManagerRepo, mockSquadRepo, mockPlayerRepo, mockEventRepo, mockTransferRepo, mockLineupRepo, mockChipRepo, services.DefaultFantasyConfig(), services.DefaultFantasyScoringRules())
+
+	// An edited 1-0 win; manager 1 was scored when the week was played, manager 2 joined afterwards
+	scorer := uint(10)
+	match := models.Match{ID: 1, Week: 2, HomeTeamID: 1, AwayTeamID: 2, HomeTeamScore: 1, AwayTeamScore: 0, IsPlayed: true}
+	events := []models.MatchEvent{
+		{MatchID: 1, Minute: 30, Type: models.EventGoal, TeamID: 1, PlayerID: &scorer, HomeScore: 1},
+		{MatchID: 1, Minute: 45, Type: models.EventHalfTime, HomeScore: 1},
+		{MatchID: 1, Minute: 90, Type: models.EventFullTime, HomeScore: 1},
+	}
+	picks := []models.FantasyPick{{ManagerID: 1, Week: 2, PlayerID: 10, Slot: 1, IsCaptain: true}}
+
+	// Set up mock expectations; the stored timeline is only read
+	mockEventRepo.On("GetByMatch", 1).Return(events, nil).Once()
+	mockPlayerRepo.On("GetByTeam", 1).Return([]models.Player{{ID: 10, TeamID: 1, Position: models.PositionForward, Rating: 85}}, nil).Once()
+	mockPlayerRepo.On("GetByTeam", 2).Return([]models.Player{}, nil).Once()
+	mockRepo.On("ReplaceMatchPerformances", 1, mock.AnythingOfType("[]models.PlayerGameweek")).Return(nil).Once()
+	mockRepo.On("GetPlayerGameweeks", 2).Return([]models.PlayerGameweek{{PlayerID: 10, Week: 2, Minutes: 90, Points: 6}}, nil).Once()
+	mockManagerRepo.On("GetAll").Return([]models.Manager{{ID: 1}, {ID: 2}}, nil).Once()
+	mockRepo.On("GetPicks", 1, 2).Return(picks, nil).Once()
+	mockRepo.On("GetPicks", 2, 2).Return([]models.FantasyPick{}, nil).Once()
+	mockChipRepo.On("GetByManagerWeek", 1, 2).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockRepo.On("SavePicks", mock.AnythingOfType("[]models.FantasyPick")).Return(nil).Once()
+	mockTransferRepo.On("GetCost", 1, 2).Return(0, nil).Once()
+	mockRepo.On("SaveManagerGameweek", mock.MatchedBy(func(gameweek *models.ManagerGameweek) bool {
+		return gameweek.ManagerID == 1 && gameweek.Week == 2
+	})).Return(nil).Once()
+	mockRepo.On("GetManagerGameweeks", 1).Return([]models.ManagerGameweek{{ManagerID: 1, Week: 2, Points: 12}}, nil).Once()
+	mockManagerRepo.On("UpdateTotalPoints", uint(1), 12).Return(nil).Once()
+
+	// Call the function under test
+	err := service.RescoreMatches([]models.Match{match})
+
+	// Assertions
+	assert.NoError(t, err, "RescoreMatches should not return an error")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockManagerRepo.AssertExpectations(t)
+	mockPlayerRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+	mockChipRepo.AssertExpectations(t)
+	mockTransferRepo.AssertExpectations(t)
+	mockSquadRepo.AssertNotCalled(t, "GetByManager", mock.Anything)
+	mockLineupRepo.AssertNotCalled(t, "GetLatest", mock.Anything, mock.Anything)
+	mockEventRepo.AssertNotCalled(t, "ReplaceByMatch", mock.Anything, mock.Anything)
+}
+
+func TestFantasyScoringService_ScoreMatches_Lineup(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockFantasyPointsRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+	mockEventRepo := new(repomocks.MockMatchEventRepository)
+	mockTransferRepo := new(repomocks.MockTransferRepository)
+	mockLineupRepo := new(repomocks.MockLineupRepository)
+	mockChipRepo := new(repomocks.MockChipRepository)
+
+	// Create fantasy scoring service with mocks
+	service := services.NewFantasyScoringService(mockRepo, mockManagerRepo, mockSquadRepo, mockPlayerRepo, mockEventRepo, mockTransferRepo, mockLineupRepo, mockChipRepo, services.DefaultFantasyConfig(), services.DefaultFantasyScoringRules())
+
+	// Squad: goalkeepers 1-2, defenders 3-7, midfielders 8-12, forwards 13-15
+	_, players := fantasySquadPlayers(6.0)
+	squad := fantasySquad(1, players)
+	lineup := &models.Lineup{
+		ManagerID:     1,
+		Week:          1,
+		Starters:      []uint{1, 3, 4, 5, 8, 9, 10, 11, 13, 14, 15},
+		Bench:         []uint{2, 12, 6, 7},
+		CaptainID:     13,
+		ViceCaptainID: 8,
+	}
+
+	// The captain and defender 3 did not play. The bench goalkeeper cannot replace a defender, and midfielder 12
+	// would leave only two defenders, so defender 6 comes on for 3 and midfielder 12 for the captain.
+	var performances []models.PlayerGameweek
+	for _, player := range players {
+		if player.ID == 3 || player.ID == 13 || player.ID == 7 {
+			continue
+		}
+		points := 2
+		switch player.ID {
+		case 8:
+			points = 5
+		case 6:
+			points = 3
+		case 12:
+			points = 1
+		}
+		performances = append(performances, models.PlayerGameweek{PlayerID: player.ID, Minutes: 90, Points: points})
+	}
+
+	// Set up mock expectations
+	match := models.Match{ID: 1, Week: 2, HomeTeamID: 1, AwayTeamID: 2, IsPlayed: true}
+	mockEventRepo.On("GetByMatch", 1).Return([]models.MatchEvent{{MatchID: 1, Minute: 90, Type: models.EventFullTime}}, nil).Once()
+	mockPlayerRepo.On("GetByTeam", 1).Return([]models.Player{}, nil).Once()
+	mockPlayerRepo.On("GetByTeam", 2).Return([]models.Player{}, nil).Once()
+	mockRepo.On("ReplaceMatchPerformances", 1, mock.Anything).Return(nil).Once()
+	mockRepo.On("GetPlayerGameweeks", 2).Return(performances, nil).Once()
+	mockManagerRepo.On("GetAll").Return([]models.Manager{{ID: 1}}, nil).Once()
+	mockRepo.On("GetPicks", 1, 2).Return([]models.FantasyPick{}, nil).Once()
+	mockSquadRepo.On("GetByManager", 1).Return(squad, nil).Once()
+	mockLineupRepo.On("GetLatest", 1, 2).Return(lineup, nil).Once()
+
+	var picks []models.FantasyPick
+	mockRepo.On("SavePicks", mock.AnythingOfType("[]models.FantasyPick")).Run(func(args mock.Arguments) {
+		picks = args.Get(0).([]models.FantasyPick)
+	}).Return(nil).Once()
+	mockChipRepo.On("GetByManagerWeek", 1, 2).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockTransferRepo.On("GetCost", 1, 2).Return(4, nil).Once()
+
+	// 8 starters on 2, the vice-captain's 5 doubled, and the substitutes' 3 and 1, less the hit
+	mockRepo.On("SaveManagerGameweek", &models.ManagerGameweek{ManagerID: 1, Week: 2, Points: 26, TransferCost: 4}).Return(nil).Once()
+	mockRepo.On("GetManagerGameweeks", 1).Return([]models.ManagerGameweek{{Week: 1, Points: 12}, {Week: 2, Points: 26}}, nil).Once()
+	mockManagerRepo.On("UpdateTotalPoints", uint(1), 38).Return(nil).Once()
+
+	// Call the function under test
+	err := service.ScoreMatches([]models.Match{match})
+
+	// Assertions
+	assert.NoError(t, err, "ScoreMatches should not return an error")
+	assert.Len(t, picks, 15, "Every squad player should be frozen as a pick")
+
+	byPlayer := make(map[uint]models.FantasyPick)
+	for _, pick := range picks {
+		byPlayer[pick.PlayerID] = pick
+	}
+	assert.Equal(t, 2, byPlayer[3].Slot, "Picks should keep the lineup's slots")
+	assert.True(t, byPlayer[13].IsCaptain, "The captain should be frozen")
+	assert.Equal(t, 0, byPlayer[13].Multiplier, "The captain did not play and was substituted")
+	assert.Equal(t, 2, byPlayer[8].Multiplier, "The vice-captain should take the armband")
+	assert.True(t, byPlayer[6].AutoSubbed, "Defender 6 should come on")
+	assert.Equal(t, 1, byPlayer[6].Multiplier, "Defender 6 should count once")
+	assert.True(t, byPlayer[12].AutoSubbed, "Midfielder 12 should come on for the captain")
+	assert.Equal(t, 0, byPlayer[2].Multiplier, "The bench goalkeeper should stay on the bench")
+	assert.Equal(t, 0, byPlayer[7].Multiplier, "Defender 7 should stay on the bench")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockManagerRepo.AssertExpectations(t)
+	mockSquadRepo.AssertExpectations(t)
+	mockLineupRepo.AssertExpectations(t)
+	mockTransferRepo.AssertExpectations(t)
+}
+
+func TestFantasyScoringService_HandleEvent_LeagueReset(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockFantasyPointsRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+	mockEventRepo := new(repomocks.MockMatchEventRepository)
+	mockTransferRepo := new(repomocks.MockTransferRepository)
+	mockLineupRepo := new(repomocks.MockLineupRepository)
+	mockChipRepo := new(repomocks.MockChipRepository)
+
+	// Create fantasy scoring service with mocks
+	service := services.NewFantasyScoringService(mockRepo, mockManagerRepo, mockSquadRepo, mockPlayerRepo, mockEventRepo, mockTransferRepo, mockLineupRepo, mockChipRepo, services.DefaultFantasyConfig(), services.DefaultFantasyScoringRules())
+
+	// Set up mock expectations
+	mockRepo.On("DeleteAll").Return(nil).Once()
+	mockManagerRepo.On("GetAll").Return([]models.Manager{{ID: 1, TotalPoints: 40}}, nil).Once()
+	mockManagerRepo.On("UpdateTotalPoints", uint(1), 0).Return(nil).Once()
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{Type: eventbus.LeagueReset})
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockManagerRepo.AssertExpectations(t)
+}
+
+func TestFantasyScoringService_ScoreMatches_Chips(t *testing.T) {
+	// Everyone plays and scores 2, except the captain who scores 5
+	_, players := fantasySquadPlayers(6.0)
+	var performances []models.PlayerGameweek
+	for _, player := range players {
+		points := 2
+		if player.ID == 13 {
+			points = 5
+		}
+		performances = append(performances, models.PlayerGameweek{PlayerID: player.ID, Minutes: 90, Points: points})
+	}
+	lineup := &models.Lineup{
+		Starters:      []uint{1, 3, 4, 5, 8, 9, 10, 11, 13, 14, 15},
+		Bench:         []uint{2, 12, 6, 7},
+		CaptainID:     13,
+		ViceCaptainID: 8,
+	}
+
+	tests := []struct {
+		name         string
+		chip         string
+		transferCost int
+		expected     int
+	}{
+		{"No chip", "", 4, 26},
+		{"Bench boost counts the bench", models.ChipBenchBoost, 0, 38},
+		{"Triple captain", models.ChipTripleCaptain, 0, 35},
+		{"Wildcard waives the transfer hit", models.ChipWildcard, 0, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repositories
+			mockRepo := new(repomocks.MockFantasyPointsRepository)
+			mockManagerRepo := new(repomocks.MockManagerRepository)
+			mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+			mockPlayerRepo := new(repomocks.MockPlayerRepository)
+			mockEventRepo := new(repomocks.MockMatchEventRepository)
+			mockTransferRepo := new(repomocks.MockTransferRepository)
+			mockLineupRepo := new(repomocks.MockLineupRepository)
+			mockChipRepo := new(repomocks.MockChipRepository)
+
+			// Create fantasy scoring service with mocks
+			service := services.NewFantasyScoringService(mockRepo, mockManagerRepo, mockSquadRepo, mockPlayerRepo, mockEventRepo, mockTransferRepo, mockLineupRepo, mockChipRepo, services.DefaultFantasyConfig(), services.DefaultFantasyScoringRules())
+
+			// Set up mock expectations
+			match := models.Match{ID: 1, Week: 2, HomeTeamID: 1, AwayTeamID: 2, IsPlayed: true}
+			mockEventRepo.On("GetByMatch", 1).Return([]models.MatchEvent{{MatchID: 1, Minute: 90, Type: models.EventFullTime}}, nil).Once()
+			mockPlayerRepo.On("GetByTeam", mock.Anything).Return([]models.Player{}, nil).Twice()
+			mockRepo.On("ReplaceMatchPerformances", 1, mock.Anything).Return(nil).Once()
+			mockRepo.On("GetPlayerGameweeks", 2).Return(performances, nil).Once()
+			mockManagerRepo.On("GetAll").Return([]models.Manager{{ID: 1}}, nil).Once()
+			mockRepo.On("GetPicks", 1, 2).Return([]models.FantasyPick{}, nil).Once()
+			mockSquadRepo.On("GetByManager", 1).Return(fantasySquad(1, players), nil).Once()
+			mockLineupRepo.On("GetLatest", 1, 2).Return(lineup, nil).Once()
+			if tt.chip == "" {
+				mockChipRepo.On("GetByManagerWeek", 1, 2).Return(nil, gorm.ErrRecordNotFound).Once()
+			} else {
+				mockChipRepo.On("GetByManagerWeek", 1, 2).Return(&models.ChipActivation{ManagerID: 1, Week: 2, Chip: tt.chip}, nil).Once()
+			}
+			if tt.chip != models.ChipWildcard {
+				mockTransferRepo.On("GetCost", 1, 2).Return(tt.transferCost, nil).Once()
+			}
+			mockRepo.On("SavePicks", mock.Anything).Return(nil).Once()
+			mockRepo.On("SaveManagerGameweek", &models.ManagerGameweek{ManagerID: 1, Week: 2, Points: tt.expected, TransferCost: tt.transferCost, Chip: tt.chip}).Return(nil).Once()
+			mockRepo.On("GetManagerGameweeks", 1).Return([]models.ManagerGameweek{{Week: 2, Points: tt.expected}}, nil).Once()
+			mockManagerRepo.On("UpdateTotalPoints", uint(1), tt.expected).Return(nil).Once()
+
+			// Call the function under test
+			err := service.ScoreMatches([]models.Match{match})
+
+			// Assertions
+			assert.NoError(t, err, "ScoreMatches should not return an error")
+
+			// Verify that all expected calls were made
+			mockRepo.AssertExpectations(t)
+			mockChipRepo.AssertExpectations(t)
+			mockTransferRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package tests
 
 import (
+	"errors"
+	"insider-league/eventbus"
+	"insider-league/helpers"
 	servicemocks "insider-league/mocks/services"
 	"insider-league/models"
 	"insider-league/services"
@@ -14,9 +17,20 @@ func TestLeagueService_EditMatchResult(t *testing.T) {
 	// Create mock services
 	mockTeamService := new(servicemocks.MockTeamService)
 	mockMatchService := new(servicemocks.MockMatchService)
+	mockRatingService := new(servicemocks.MockRatingService)
+	mockEventService := new(servicemocks.MockMatchEventService)
 
-	// Create league service with mocks
-	service := services.NewLeagueService(mockTeamService, mockMatchService)
+	// Create league service with mocks, in events mode
+	bus := eventbus.NewBus()
+	config := services.DefaultSimulationConfig()
+	config.Mode = services.SimulationModeEvents
+	service := services.NewLeagueService(mockTeamService, mockMatchService, mockRatingService, mockEventService, bus, services.NewWeekStatus(), services.NewLeagueLock(), config)
+
+	// Record published events
+	var published []eventbus.Event
+	bus.Subscribe(func(event eventbus.Event) {
+		published = append(published, event)
+	})
 
 	// Test data
 	matchID := 1
@@ -80,6 +94,14 @@ func TestLeagueService_EditMatchResult(t *testing.T) {
 	// Second call: Apply the new match result (3-1) with revert=false
 	mockTeamService.On("UpdateTeamStats", homeTeam, awayTeam, newHomeGoals, newAwayGoals, false).Return(nil).Once()
 
+	// Only the goals are redrawn, in events mode as in score mode, so the rest of the timeline is kept
+	mockEventService.On("GenerateGoalsForMatch", mock.MatchedBy(func(match *models.Match) bool {
+		return int(match.ID) == matchID
+	})).Return([]models.MatchEvent{}, nil).Once()
+
+	// Ratings are replayed after the edit
+	mockRatingService.On("Rebuild").Return(nil).Once()
+
 	// Get league table expectation
 	mockTeamService.On("GetTeamRankings").Return(expectedLeagueTable, nil).Once()
 
@@ -93,10 +115,16 @@ func TestLeagueService_EditMatchResult(t *testing.T) {
 	assert.Equal(t, newAwayGoals, updatedMatch.AwayTeamScore, "Away team score should be updated")
 	assert.True(t, updatedMatch.IsPlayed, "Match should be marked as played")
 	assert.Equal(t, expectedLeagueTable, leagueTable, "League table should match expected")
+	assert.Len(t, published, 1, "A single event should be published")
+	assert.Equal(t, eventbus.MatchResultEdited, published[0].Type, "A result edited event should be published")
+	assert.Equal(t, expectedLeagueTable, published[0].LeagueTable, "The event should carry the table snapshot")
 
 	// Verify that all expected calls were made in the correct order
 	mockMatchService.AssertExpectations(t)
 	mockTeamService.AssertExpectations(t)
+	mockRatingService.AssertExpectations(t)
+	mockEventService.AssertExpectations(t)
+	mockEventService.AssertNotCalled(t, "GenerateForMatch", mock.Anything)
 }
 
 func TestLeagueService_PlayWeeks_NextWeek(t *testing.T) {
@@ -135,9 +163,11 @@ func TestLeagueService_PlayWeeks_NextWeek(t *testing.T) {
 			// Create mock services
 			mockTeamService := new(servicemocks.MockTeamService)
 			mockMatchService := new(servicemocks.MockMatchService)
+			mockRatingService := new(servicemocks.MockRatingService)
+			mockEventService := new(servicemocks.MockMatchEventService)
 
 			// Create league service with mocks
-			service := services.NewLeagueService(mockTeamService, mockMatchService)
+			service := services.NewLeagueService(mockTeamService, mockMatchService, mockRatingService, mockEventService, eventbus.NewBus(), services.NewWeekStatus(), services.NewLeagueLock(), services.DefaultSimulationConfig())
 
 			// Create sample teams
 			homeTeam := models.Team{
@@ -225,9 +255,28 @@ func TestLeagueService_PlayWeeks_NextWeek(t *testing.T) {
 				).Return(nil).Once()
 			}
 
+			// For each match, expect the goals to be credited to scorers
+			for range matches {
+				mockEventService.On("GenerateGoalsForMatch", mock.AnythingOfType("*models.Match")).Return([]models.MatchEvent{}, nil).Once()
+			}
+
+			// For each match, expect the Elo ratings to be updated
+			for range matches {
+				mockRatingService.On("ApplyMatchResult",
+					mock.AnythingOfType("*models.Match"),
+					mock.AnythingOfType("*models.Team"),
+					mock.AnythingOfType("*models.Team"),
+				).Return(nil).Once()
+			}
+
 			// Expect GetTeamRankings to be called
 			mockTeamService.On("GetTeamRankings").Return(expectedLeagueTable, nil).Once()
 
+			// From week 4 the rest of the season is simulated for the predictions
+			if tt.expectPredictions {
+				mockMatchService.On("GetAll").Return(matches, nil).Once()
+			}
+
 			// Call the function under test - play only next week
 			leagueTable, returnedMatches, predictions, err := service.PlayWeeks(false)
 
@@ -239,8 +288,10 @@ func TestLeagueService_PlayWeeks_NextWeek(t *testing.T) {
 
 			if tt.expectPredictions {
 				assert.NotEmpty(t, predictions, "Predictions should not be empty for week >= 4")
-				// Note: The actual predictions are generated by helpers.CalculatePredictions
-				// which we're not mocking, so we just verify they exist
+				// With the week played nothing is left, so the leader is certain to finish first
+				assert.Equal(t, "100.0%", predictions[0].Chance)
+				assert.Equal(t, []float64{1, 0}, predictions[0].Positions)
+				assert.Equal(t, 1.0, predictions[1].Zones["relegation"])
 			} else {
 				assert.Empty(t, predictions, "Predictions should be empty for week < 4")
 			}
@@ -248,17 +299,86 @@ func TestLeagueService_PlayWeeks_NextWeek(t *testing.T) {
 			// Verify that all expected calls were made
 			mockMatchService.AssertExpectations(t)
 			mockTeamService.AssertExpectations(t)
+			mockRatingService.AssertExpectations(t)
+			mockEventService.AssertExpectations(t)
 		})
 	}
 }
 
+func TestLeagueService_PlayWeeks_StrengthDynamics(t *testing.T) {
+	// Create mock services
+	mockTeamService := new(servicemocks.MockTeamService)
+	mockMatchService := new(servicemocks.MockMatchService)
+	mockRatingService := new(servicemocks.MockRatingService)
+	mockEventService := new(servicemocks.MockMatchEventService)
+
+	// Enable dynamics with only regression so the drift is deterministic
+	config := services.DefaultSimulationConfig()
+	config.Dynamics = services.DynamicsConfig{
+		Enabled:        true,
+		FormMatches:    5,
+		RegressionRate: 0.5,
+	}
+
+	// Create league service with mocks
+	service := services.NewLeagueService(mockTeamService, mockMatchService, mockRatingService, mockEventService, eventbus.NewBus(), services.NewWeekStatus(), services.NewLeagueLock(), config)
+
+	// Home team is well above its baseline, away team well below
+	homeTeam := models.Team{ID: 1, Name: "Team A", Strength: 90, BaseStrength: 80}
+	awayTeam := models.Team{ID: 2, Name: "Team B", Strength: 60, BaseStrength: 70}
+
+	matches := []models.Match{
+		{ID: 1, Week: 1, HomeTeamID: 1, AwayTeamID: 2, HomeTeam: homeTeam, AwayTeam: awayTeam},
+	}
+
+	// Set up mock expectations
+	mockMatchService.On("GetUnplayedWeeks").Return([]int{1}, nil).Once()
+	mockMatchService.On("GetByWeek", 1).Return(matches, nil).Once()
+
+	// The match records the strengths used before any drift
+	mockMatchService.On("Update", mock.MatchedBy(func(match *models.Match) bool {
+		return match.ID == 1 && match.HomeStrength == 90 && match.AwayStrength == 60
+	})).Return(nil).Once()
+	mockTeamService.On("UpdateTeamStats", mock.AnythingOfType("*models.Team"), mock.AnythingOfType("*models.Team"),
+		mock.AnythingOfType("int"), mock.AnythingOfType("int"), false).Return(nil).Once()
+	mockRatingService.On("ApplyMatchResult", mock.AnythingOfType("*models.Match"),
+		mock.AnythingOfType("*models.Team"), mock.AnythingOfType("*models.Team")).Return(nil).Once()
+	mockEventService.On("GenerateGoalsForMatch", mock.AnythingOfType("*models.Match")).Return([]models.MatchEvent{}, nil).Once()
+
+	// Form is read from all matches, then both teams regress halfway to their baseline
+	mockMatchService.On("GetAll").Return(matches, nil).Once()
+	mockTeamService.On("Save", mock.MatchedBy(func(team *models.Team) bool {
+		return team.ID == 1 && team.Strength == 85
+	})).Return(nil).Once()
+	mockTeamService.On("Save", mock.MatchedBy(func(team *models.Team) bool {
+		return team.ID == 2 && team.Strength == 65
+	})).Return(nil).Once()
+
+	mockTeamService.On("GetTeamRankings").Return([]models.Team{homeTeam, awayTeam}, nil).Once()
+
+	// Call the function under test
+	_, returnedMatches, _, err := service.PlayWeeks(false)
+
+	// Assertions
+	assert.NoError(t, err, "PlayWeeks should not return an error")
+	assert.Len(t, returnedMatches, 1, "Should return the played match")
+
+	// Verify that all expected calls were made
+	mockMatchService.AssertExpectations(t)
+	mockTeamService.AssertExpectations(t)
+	mockRatingService.AssertExpectations(t)
+	mockEventService.AssertExpectations(t)
+}
+
 func TestLeagueService_PlayWeeks_NoUnplayedWeeks(t *testing.T) {
 	// Create mock services
 	mockTeamService := new(servicemocks.MockTeamService)
 	mockMatchService := new(servicemocks.MockMatchService)
+	mockRatingService := new(servicemocks.MockRatingService)
+	mockEventService := new(servicemocks.MockMatchEventService)
 
 	// Create league service with mocks
-	service := services.NewLeagueService(mockTeamService, mockMatchService)
+	service := services.NewLeagueService(mockTeamService, mockMatchService, mockRatingService, mockEventService, eventbus.NewBus(), services.NewWeekStatus(), services.NewLeagueLock(), services.DefaultSimulationConfig())
 
 	// Expected league table when no unplayed weeks remain
 	expectedLeagueTable := []models.Team{
@@ -306,15 +426,59 @@ func TestLeagueService_PlayWeeks_NoUnplayedWeeks(t *testing.T) {
 	// Verify that the expected calls were made
 	mockMatchService.AssertExpectations(t)
 	mockTeamService.AssertExpectations(t)
+	mockRatingService.AssertExpectations(t)
+	mockEventService.AssertExpectations(t)
+}
+
+func TestLeagueService_PlayWeeks_ClosesWeeksWhilePlaying(t *testing.T) {
+	// Create mock services
+	mockTeamService := new(servicemocks.MockTeamService)
+	mockMatchService := new(servicemocks.MockMatchService)
+	mockRatingService := new(servicemocks.MockRatingService)
+	mockEventService := new(servicemocks.MockMatchEventService)
+	bus := eventbus.NewBus()
+	weeks := services.NewWeekStatus()
+
+	// Create league service with mocks
+	service := services.NewLeagueService(mockTeamService, mockMatchService, mockRatingService, mockEventService, bus, weeks, services.NewLeagueLock(), services.DefaultSimulationConfig())
+
+	// Record whether the last week is still closed while the first one is being played
+	lastWeekClosed := false
+	bus.Subscribe(func(event eventbus.Event) {
+		if event.Type == eventbus.WeekPlayed && event.Week == 1 {
+			lastWeekClosed = weeks.Playing(2)
+		}
+	})
+
+	// Set up mock expectations
+	mockMatchService.On("GetUnplayedWeeks").Return([]int{1, 2}, nil).Once()
+	mockMatchService.On("GetByWeek", 1).Return([]models.Match{}, nil).Once()
+	mockMatchService.On("GetByWeek", 2).Return([]models.Match{}, nil).Once()
+	mockTeamService.On("GetTeamRankings").Return([]models.Team{}, nil).Twice()
+
+	// Call the function under test
+	_, _, _, err := service.PlayWeeks(true)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.True(t, lastWeekClosed, "Every week being played should stay closed until the run finishes")
+	assert.False(t, weeks.Playing(1), "Weeks should reopen once the run has finished")
+	assert.False(t, weeks.Playing(2), "Weeks should reopen once the run has finished")
+
+	// Verify that all expected calls were made
+	mockMatchService.AssertExpectations(t)
+	mockTeamService.AssertExpectations(t)
 }
 
 func TestLeagueService_GetLeagueTable(t *testing.T) {
 	// Create mock services
 	mockTeamService := new(servicemocks.MockTeamService)
 	mockMatchService := new(servicemocks.MockMatchService)
+	mockRatingService := new(servicemocks.MockRatingService)
+	mockEventService := new(servicemocks.MockMatchEventService)
 
 	// Create league service with mocks
-	service := services.NewLeagueService(mockTeamService, mockMatchService)
+	service := services.NewLeagueService(mockTeamService, mockMatchService, mockRatingService, mockEventService, eventbus.NewBus(), services.NewWeekStatus(), services.NewLeagueLock(), services.DefaultSimulationConfig())
 
 	// Expected league table
 	expectedLeagueTable := []models.Team{
@@ -356,6 +520,8 @@ func TestLeagueService_GetLeagueTable(t *testing.T) {
 
 	// Verify that the expected calls were made
 	mockTeamService.AssertExpectations(t)
+	mockRatingService.AssertExpectations(t)
+	mockEventService.AssertExpectations(t)
 	mockMatchService.AssertExpectations(t)
 }
 
@@ -363,9 +529,11 @@ func TestLeagueService_GetWeekResults(t *testing.T) {
 	// Create mock services
 	mockTeamService := new(servicemocks.MockTeamService)
 	mockMatchService := new(servicemocks.MockMatchService)
+	mockRatingService := new(servicemocks.MockRatingService)
+	mockEventService := new(servicemocks.MockMatchEventService)
 
 	// Create league service with mocks
-	service := services.NewLeagueService(mockTeamService, mockMatchService)
+	service := services.NewLeagueService(mockTeamService, mockMatchService, mockRatingService, mockEventService, eventbus.NewBus(), services.NewWeekStatus(), services.NewLeagueLock(), services.DefaultSimulationConfig())
 
 	// Test data
 	week := 3
@@ -403,15 +571,26 @@ func TestLeagueService_GetWeekResults(t *testing.T) {
 	// Verify that the expected calls were made
 	mockMatchService.AssertExpectations(t)
 	mockTeamService.AssertExpectations(t)
+	mockRatingService.AssertExpectations(t)
+	mockEventService.AssertExpectations(t)
 }
 
 func TestLeagueService_ResetLeague(t *testing.T) {
 	// Create mock services
 	mockTeamService := new(servicemocks.MockTeamService)
 	mockMatchService := new(servicemocks.MockMatchService)
+	mockRatingService := new(servicemocks.MockRatingService)
+	mockEventService := new(servicemocks.MockMatchEventService)
 
 	// Create league service with mocks
-	service := services.NewLeagueService(mockTeamService, mockMatchService)
+	bus := eventbus.NewBus()
+	service := services.NewLeagueService(mockTeamService, mockMatchService, mockRatingService, mockEventService, bus, services.NewWeekStatus(), services.NewLeagueLock(), services.DefaultSimulationConfig())
+
+	// Record published events
+	var published []eventbus.Event
+	bus.Subscribe(func(event eventbus.Event) {
+		published = append(published, event)
+	})
 
 	// Mock data - existing matches with played results
 	existingMatches := []models.Match{
@@ -476,7 +655,7 @@ func TestLeagueService_ResetLeague(t *testing.T) {
 
 	// Expect Update to be called for each team (reset stats)
 	for _, team := range existingTeams {
-		mockTeamService.On("Update", mock.MatchedBy(func(t *models.Team) bool {
+		mockTeamService.On("Save", mock.MatchedBy(func(t *models.Team) bool {
 			return t.ID == team.ID &&
 				t.Stats.Points == 0 && t.Stats.Wins == 0 && t.Stats.Draws == 0 &&
 				t.Stats.Losses == 0 && t.Stats.GoalsFor == 0 && t.Stats.GoalsAgainst == 0 &&
@@ -484,13 +663,264 @@ func TestLeagueService_ResetLeague(t *testing.T) {
 		})).Return(nil).Once()
 	}
 
+	// Expect all timelines to be cleared
+	mockEventService.On("DeleteAll").Return(nil).Once()
+
+	// Expect ratings to be reset
+	mockRatingService.On("Rebuild").Return(nil).Once()
+
+	// Expect the fresh table to be loaded for subscribers
+	mockTeamService.On("GetTeamRankings").Return(existingTeams, nil).Once()
+
 	// Call the function under test
 	err := service.ResetLeague()
 
 	// Assertions
 	assert.NoError(t, err, "ResetLeague should not return an error")
+	assert.Len(t, published, 1, "A single event should be published")
+	assert.Equal(t, eventbus.LeagueReset, published[0].Type, "A league reset event should be published")
+	assert.Equal(t, existingTeams, published[0].LeagueTable, "The event should carry the table snapshot")
 
 	// Verify that all expected calls were made
 	mockMatchService.AssertExpectations(t)
 	mockTeamService.AssertExpectations(t)
+	mockRatingService.AssertExpectations(t)
+	mockEventService.AssertExpectations(t)
+}
+
+func TestLeagueService_PlayNextWeekLive(t *testing.T) {
+	// Create mock services
+	mockTeamService := new(servicemocks.MockTeamService)
+	mockMatchService := new(servicemocks.MockMatchService)
+	mockRatingService := new(servicemocks.MockRatingService)
+	mockEventService := new(servicemocks.MockMatchEventService)
+
+	// Create league service with mocks
+	bus := eventbus.NewBus()
+	weeks := services.NewWeekStatus()
+	service := services.NewLeagueService(mockTeamService, mockMatchService, mockRatingService, mockEventService, bus, weeks, services.NewLeagueLock(), services.DefaultSimulationConfig())
+
+	// Record the events published for the week
+	var published []eventbus.Event
+	bus.Subscribe(func(event eventbus.Event) {
+		published = append(published, event)
+	})
+
+	homeTeam := models.Team{ID: 1, Name: "Team A", Strength: 80}
+	awayTeam := models.Team{ID: 2, Name: "Team B", Strength: 75}
+	matches := []models.Match{
+		{ID: 1, Week: 1, HomeTeamID: 1, AwayTeamID: 2, HomeTeam: homeTeam, AwayTeam: awayTeam},
+	}
+	table := []models.Team{homeTeam, awayTeam}
+
+	// Set up mock expectations
+	mockMatchService.On("GetUnplayedWeeks").Return([]int{1}, nil).Once()
+	mockMatchService.On("GetByWeek", 1).Return(matches, nil).Once()
+	mockTeamService.On("GetTeamRankings").Return(table, nil).Twice()
+
+	// Timelines are simulated for the drawn score; the return value is filled in once the score is known
+	simulateCall := mockEventService.On("SimulateForMatch", mock.AnythingOfType("*models.Match")).Once()
+	simulateCall.Run(func(args mock.Arguments) {
+		simulateCall.ReturnArguments = mock.Arguments{helpers.SimulateMatchEvents(args.Get(0).(*models.Match)), nil}
+	})
+
+	// Results are stored once the replay ends, with the timeline that was streamed
+	mockMatchService.On("Update", mock.MatchedBy(func(match *models.Match) bool {
+		return match.ID == 1 && match.IsPlayed
+	})).Return(nil).Once()
+	mockEventService.On("SaveForMatch", 1, mock.AnythingOfType("[]models.MatchEvent")).Return(nil).Once()
+	mockTeamService.On("UpdateTeamStats", mock.AnythingOfType("*models.Team"), mock.AnythingOfType("*models.Team"),
+		mock.AnythingOfType("int"), mock.AnythingOfType("int"), false).Return(nil).Once()
+	mockRatingService.On("ApplyMatchResult", mock.AnythingOfType("*models.Match"),
+		mock.AnythingOfType("*models.Team"), mock.AnythingOfType("*models.Team")).Return(nil).Once()
+
+	// Collect every streamed update, try another league operation while the replay runs, and note anything
+	// stored or published before full time
+	var updates []models.LiveUpdate
+	var playErr error
+	kickoffGoals := -1
+	weekClosed := false
+	storedEarly := false
+	emit := func(update models.LiveUpdate) error {
+		if update.Type == models.LiveKickoff {
+			_, _, _, playErr = service.PlayWeeks(false)
+			kickoffGoals = update.Matches[0].HomeTeamScore + update.Matches[0].AwayTeamScore
+			weekClosed = weeks.Playing(1)
+		}
+		if update.Type != models.LiveFullTime {
+			for _, call := range mockMatchService.Calls {
+				if call.Method == "Update" {
+					storedEarly = true
+				}
+			}
+			if len(published) > 0 {
+				storedEarly = true
+			}
+		}
+		updates = append(updates, update)
+		return nil
+	}
+
+	// Call the function under test at a speed that makes the 90 minutes near-instant
+	err := service.PlayNextWeekLive(1e9, emit)
+
+	// Assertions
+	assert.NoError(t, err, "PlayNextWeekLive should not return an error")
+	assert.ErrorIs(t, playErr, services.ErrLeagueBusy, "The league should stay locked while the replay runs")
+	assert.True(t, weekClosed, "The week should close at kick-off")
+	assert.False(t, weeks.Playing(1), "The week should reopen once it is stored")
+	assert.False(t, storedEarly, "Nothing should be stored or published before full time")
+	assert.Len(t, published, 2, "The week and the end of the season should be announced after the replay")
+	assert.Equal(t, eventbus.WeekPlayed, published[0].Type, "The week should be announced as played")
+	assert.Equal(t, models.LiveKickoff, updates[0].Type, "Playback should start with kick-off")
+	assert.Equal(t, table, updates[0].LeagueTable, "Kick-off should show the table before the week")
+	assert.Zero(t, kickoffGoals, "Kick-off should be goalless")
+
+	final := updates[len(updates)-1]
+	assert.Equal(t, models.LiveFullTime, final.Type, "Playback should end at full time")
+	assert.True(t, final.Matches[0].IsPlayed, "Final matches should be marked as played")
+
+	// Goal events must add up to the committed score
+	homeGoals, awayGoals, halfTimes := 0, 0, 0
+	for _, update := range updates {
+		if update.Type == models.LiveHalfTime {
+			halfTimes++
+		}
+		if update.Event != nil && update.Event.Type == models.EventGoal {
+			assert.NotEmpty(t, update.LeagueTable, "Goals should carry a provisional table")
+			if update.Event.TeamID == 1 {
+				homeGoals++
+			} else {
+				awayGoals++
+			}
+		}
+	}
+	assert.Equal(t, 1, halfTimes, "Half-time should be announced once")
+	assert.Equal(t, final.Matches[0].HomeTeamScore, homeGoals, "Home goals streamed should match the result")
+	assert.Equal(t, final.Matches[0].AwayTeamScore, awayGoals, "Away goals streamed should match the result")
+
+	// Verify that all expected calls were made
+	mockMatchService.AssertExpectations(t)
+	mockTeamService.AssertExpectations(t)
+	mockRatingService.AssertExpectations(t)
+	mockEventService.AssertExpectations(t)
+}
+
+func TestLeagueService_PlayNextWeekLive_ClientDisconnects(t *testing.T) {
+	// Create mock services
+	mockTeamService := new(servicemocks.MockTeamService)
+	mockMatchService := new(servicemocks.MockMatchService)
+	mockRatingService := new(servicemocks.MockRatingService)
+	mockEventService := new(servicemocks.MockMatchEventService)
+
+	// Create league service with mocks
+	service := services.NewLeagueService(mockTeamService, mockMatchService, mockRatingService, mockEventService, eventbus.NewBus(), services.NewWeekStatus(), services.NewLeagueLock(), services.DefaultSimulationConfig())
+
+	matches := []models.Match{
+		{ID: 1, Week: 1, HomeTeamID: 1, AwayTeamID: 2, HomeTeam: models.Team{ID: 1, Strength: 80}, AwayTeam: models.Team{ID: 2, Strength: 80}},
+	}
+
+	// Set up mock expectations; the week is still stored when the replay is cut short
+	mockMatchService.On("GetUnplayedWeeks").Return([]int{1}, nil).Once()
+	mockMatchService.On("GetByWeek", 1).Return(matches, nil).Once()
+	mockTeamService.On("GetTeamRankings").Return([]models.Team{}, nil).Twice()
+	mockEventService.On("SimulateForMatch", mock.AnythingOfType("*models.Match")).Return([]models.MatchEvent{}, nil).Once()
+	mockMatchService.On("Update", mock.AnythingOfType("*models.Match")).Return(nil).Once()
+	mockEventService.On("SaveForMatch", 1, []models.MatchEvent{}).Return(nil).Once()
+	mockTeamService.On("UpdateTeamStats", mock.AnythingOfType("*models.Team"), mock.AnythingOfType("*models.Team"),
+		mock.AnythingOfType("int"), mock.AnythingOfType("int"), false).Return(nil).Once()
+	mockRatingService.On("ApplyMatchResult", mock.AnythingOfType("*models.Match"),
+		mock.AnythingOfType("*models.Team"), mock.AnythingOfType("*models.Team")).Return(nil).Once()
+
+	// The client goes away right after kick-off
+	disconnected := errors.New("client disconnected")
+	emit := func(update models.LiveUpdate) error {
+		return disconnected
+	}
+
+	// Call the function under test
+	err := service.PlayNextWeekLive(1e9, emit)
+
+	// Assertions: the replay stops, but the results are stored
+	assert.ErrorIs(t, err, disconnected, "Playback should stop when the client disconnects")
+
+	// Verify that all expected calls were made
+	mockMatchService.AssertExpectations(t)
+	mockTeamService.AssertExpectations(t)
+	mockRatingService.AssertExpectations(t)
+	mockEventService.AssertExpectations(t)
+}
+
+func TestLeagueService_GetProjections(t *testing.T) {
+	// Create mock services
+	mockTeamService := new(servicemocks.MockTeamService)
+	mockMatchService := new(servicemocks.MockMatchService)
+
+	config := services.DefaultSimulationConfig()
+	config.Projection.Iterations = 2000
+	config.Projection.Zones = []models.Zone{
+		{Name: "title", From: 1, To: 1},
+		{Name: "top_two", From: 1, To: 2},
+		{Name: "relegation", From: -1, To: -1},
+	}
+	service := services.NewLeagueService(mockTeamService, mockMatchService, new(servicemocks.MockRatingService), new(servicemocks.MockMatchEventService), eventbus.NewBus(), services.NewWeekStatus(), services.NewLeagueLock(), config)
+
+	// Team A cannot be caught with one match left; B and C play each other for second place
+	leagueTable := []models.Team{
+		{ID: 1, Name: "Team A", Strength: 80, Stats: models.Stats{Points: 15, GoalsFor: 12, GoalsAgainst: 3}},
+		{ID: 2, Name: "Team B", Strength: 80, Stats: models.Stats{Points: 6, GoalsFor: 6, GoalsAgainst: 6}},
+		{ID: 3, Name: "Team C", Strength: 80, Stats: models.Stats{Points: 6, GoalsFor: 5, GoalsAgainst: 6}},
+	}
+	matches := []models.Match{
+		{ID: 1, HomeTeamID: 1, AwayTeamID: 2, IsPlayed: true},
+		{ID: 2, HomeTeamID: 2, AwayTeamID: 3},
+	}
+
+	// Set up mock expectations
+	mockTeamService.On("GetTeamRankings").Return(leagueTable, nil).Once()
+	mockMatchService.On("GetAll").Return(matches, nil).Once()
+
+	// Call the function under test
+	predictions, err := service.GetProjections()
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Len(t, predictions, 3)
+	assert.Equal(t, uint(1), predictions[0].TeamID)
+	assert.Equal(t, "100.0%", predictions[0].Chance)
+	assert.Equal(t, 1.0, predictions[0].TitleProbability)
+	assert.Equal(t, []float64{1, 0, 0}, predictions[0].Positions)
+	assert.Equal(t, 0.0, predictions[1].TitleProbability)
+	assert.Equal(t, "0.0%", predictions[1].Chance)
+
+	// Each team's positions and each position's teams add up to certainty
+	for position := 0; position < 3; position++ {
+		teamTotal, positionTotal := 0.0, 0.0
+		for i := range predictions {
+			teamTotal += predictions[position].Positions[i]
+			positionTotal += predictions[i].Positions[position]
+		}
+		assert.InDelta(t, 1, teamTotal, 0.001)
+		assert.InDelta(t, 1, positionTotal, 0.001)
+	}
+
+	// The home side is favoured to take second place, and the zones follow the positions
+	assert.Greater(t, predictions[1].Positions[1], predictions[2].Positions[1])
+	assert.Equal(t, predictions[1].Positions[2], predictions[1].Zones["relegation"])
+	assert.InDelta(t, predictions[1].Positions[0]+predictions[1].Positions[1], predictions[1].Zones["top_two"], 0.0001)
+	assert.Equal(t, 1.0, predictions[0].Zones["title"])
+
+	// Verify that all expected calls were made
+	mockTeamService.AssertExpectations(t)
+	mockMatchService.AssertExpectations(t)
+}
+
+func TestZoneProbability(t *testing.T) {
+	positions := []float64{0.5, 0.3, 0.2}
+
+	// Zones are clipped to the league, and negative positions count from the bottom
+	assert.InDelta(t, 1.0, helpers.ZoneProbability(positions, models.Zone{Name: "top_four", From: 1, To: 4}), 1e-9)
+	assert.InDelta(t, 0.5, helpers.ZoneProbability(positions, models.Zone{Name: "bottom_two", From: -2, To: -1}), 1e-9)
+	assert.InDelta(t, 0.2, helpers.ZoneProbability(positions, models.Zone{Name: "last", From: 3, To: 3}), 1e-9)
+	assert.InDelta(t, 0.0, helpers.ZoneProbability(positions, models.Zone{Name: "empty", From: 5, To: 7}), 1e-9)
 }
@@ -0,0 +1,138 @@
+package tests
+
+import (
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestLineupService_SetLineup(t *testing.T) {
+	// Squad: goalkeepers 1-2, defenders 3-7, midfielders 8-12, forwards 13-15
+	_, players := fantasySquadPlayers(6.0)
+
+	tests := []struct {
+		name      string
+		lineup    models.Lineup
+		playing   int
+		expectErr error
+	}{
+		{
+			name:   "Valid 3-4-3",
+			lineup: models.Lineup{Starters: []uint{1, 3, 4, 5, 8, 9, 10, 11, 13, 14, 15}, Bench: []uint{2, 12, 6, 7}, CaptainID: 13, ViceCaptainID: 8},
+		},
+		{
+			name:      "Two goalkeepers",
+			lineup:    models.Lineup{Starters: []uint{1, 2, 3, 4, 5, 8, 9, 10, 11, 13, 14}, Bench: []uint{15, 12, 6, 7}, CaptainID: 13, ViceCaptainID: 8},
+			expectErr: services.ErrInvalidLineup,
+		},
+		{
+			name:      "Captain on the bench",
+			lineup:    models.Lineup{Starters: []uint{1, 3, 4, 5, 8, 9, 10, 11, 13, 14, 15}, Bench: []uint{2, 12, 6, 7}, CaptainID: 12, ViceCaptainID: 8},
+			expectErr: services.ErrInvalidLineup,
+		},
+		{
+			name:      "Player left out",
+			lineup:    models.Lineup{Starters: []uint{1, 3, 4, 5, 8, 9, 10, 11, 13, 14, 15}, Bench: []uint{2, 12, 6}, CaptainID: 13, ViceCaptainID: 8},
+			expectErr: services.ErrInvalidLineup,
+		},
+		{
+			name:      "Week already simulated",
+			lineup:    models.Lineup{Week: 2},
+			expectErr: services.ErrDeadlinePassed,
+		},
+		{
+			name:      "Week being played",
+			lineup:    models.Lineup{Starters: []uint{1, 3, 4, 5, 8, 9, 10, 11, 13, 14, 15}, Bench: []uint{2, 12, 6, 7}, CaptainID: 13, ViceCaptainID: 8},
+			playing:   3,
+			expectErr: services.ErrWeekInProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repositories
+			mockRepo := new(repomocks.MockLineupRepository)
+			mockManagerRepo := new(repomocks.MockManagerRepository)
+			mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+			mockMatchRepo := new(repomocks.MockMatchRepository)
+
+			// Create lineup service with mocks, with the open gameweek being played when the case asks for it
+			weeks := services.NewWeekStatus()
+			if tt.playing > 0 {
+				weeks.Start(tt.playing, tt.playing)
+			}
+			service := services.NewLineupService(mockRepo, mockManagerRepo, mockSquadRepo, mockMatchRepo, weeks, services.DefaultFantasyConfig())
+
+			// Set up mock expectations
+			mockManagerRepo.On("GetByID", 1).Return(&models.Manager{ID: 1}, nil).Once()
+			mockMatchRepo.On("GetUnplayedWeeks").Return([]int{3, 4}, nil).Once()
+			mockSquadRepo.On("GetByManager", 1).Return(fantasySquad(1, players), nil).Maybe()
+			if tt.expectErr == nil {
+				mockRepo.On("Save", mock.MatchedBy(func(lineup *models.Lineup) bool {
+					return lineup.ManagerID == 1 && lineup.Week == 3
+				})).Return(nil).Once()
+			}
+
+			// Call the function under test
+			lineup := tt.lineup
+			saved, err := service.SetLineup(1, &lineup)
+
+			// Assertions
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr, "The lineup should be rejected")
+				assert.Nil(t, saved, "No lineup should be returned")
+				mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+			} else {
+				assert.NoError(t, err, "SetLineup should not return an error")
+				assert.Equal(t, 3, saved.Week, "The lineup should be set for the open gameweek")
+			}
+
+			// Verify that all expected calls were made
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLineupService_GetLineup_Default(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockLineupRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+
+	// Create lineup service with mocks
+	service := services.NewLineupService(mockRepo, mockManagerRepo, mockSquadRepo, mockMatchRepo, services.NewWeekStatus(), services.DefaultFantasyConfig())
+
+	// Rate players by ID so the best forwards and midfielders are the highest IDs
+	_, players := fantasySquadPlayers(6.0)
+	for i := range players {
+		players[i].Rating = 60 + int(players[i].ID)
+	}
+
+	// Set up mock expectations
+	mockManagerRepo.On("GetByID", 1).Return(&models.Manager{ID: 1}, nil).Once()
+	mockMatchRepo.On("GetUnplayedWeeks").Return([]int{5}, nil).Once()
+	mockSquadRepo.On("GetByManager", 1).Return(fantasySquad(1, players), nil).Once()
+	mockRepo.On("GetLatest", 1, 5).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	// Call the function under test
+	lineup, err := service.GetLineup(1, 0)
+
+	// Assertions
+	assert.NoError(t, err, "GetLineup should not return an error")
+	assert.Equal(t, 5, lineup.Week, "The open gameweek should be used")
+	assert.Equal(t, []uint{2, 7, 6, 5, 12, 11, 10, 9, 15, 14, 13}, lineup.Starters, "The best-rated legal team should start by position")
+	assert.Equal(t, []uint{1, 8, 4, 3}, lineup.Bench, "The bench should list the goalkeeper first, then by rating")
+	assert.Equal(t, uint(15), lineup.CaptainID, "The best-rated starter should captain")
+	assert.Equal(t, uint(14), lineup.ViceCaptainID, "The next best starter should be vice-captain")
+
+	// Verify that all expected calls were made
+	mockManagerRepo.AssertExpectations(t)
+	mockSquadRepo.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,267 @@
+package tests
+
+import (
+	"errors"
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// fantasySquadPlayers builds a legal 15-player squad spread over four clubs, each costing the given price
+func fantasySquadPlayers(price float64) ([]uint, []models.Player) {
+	quotas := []struct {
+		position string
+		count    int
+	}{
+		{models.PositionGoalkeeper, 2},
+		{models.PositionDefender, 5},
+		{models.PositionMidfielder, 5},
+		{models.PositionForward, 3},
+	}
+
+	var ids []uint
+	var players []models.Player
+	for _, quota := range quotas {
+		for range quota.count {
+			id := uint(len(players) + 1)
+			ids = append(ids, id)
+			players = append(players, models.Player{ID: id, Position: quota.position, TeamID: id%4 + 1, Price: price})
+		}
+	}
+	return ids, players
+}
+
+// managerOwner is the account that registered the managers in the fantasy tests
+var managerOwner = &models.User{ID: 7, Role: models.RoleManager}
+
+func TestManagerService_Authorize(t *testing.T) {
+	tests := []struct {
+		name      string
+		user      *models.User
+		manager   *models.Manager
+		expectErr error
+	}{
+		{"Own manager", managerOwner, &models.Manager{ID: 1, UserID: &managerOwner.ID}, nil},
+		{"Another account's manager", &models.User{ID: 8, Role: models.RoleManager}, &models.Manager{ID: 1, UserID: &managerOwner.ID}, services.ErrNotManagerOwner},
+		{"Commissioner on another account's manager", &models.User{ID: 8, Role: models.RoleCommissioner}, &models.Manager{ID: 1, UserID: &managerOwner.ID}, services.ErrNotManagerOwner},
+		{"Manager without an account", managerOwner, &models.Manager{ID: 1}, services.ErrNotManagerOwner},
+		{"Admin on another account's manager", &models.User{ID: 1, Role: models.RoleAdmin}, &models.Manager{ID: 1, UserID: &managerOwner.ID}, nil},
+		{"Anonymous", nil, &models.Manager{ID: 1, UserID: &managerOwner.ID}, services.ErrNotManagerOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repositories
+			mockRepo := new(repomocks.MockManagerRepository)
+			mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+			mockPlayerRepo := new(repomocks.MockPlayerRepository)
+
+			// Create manager service with mocks
+			service := services.NewManagerService(mockRepo, mockSquadRepo, mockPlayerRepo, services.DefaultFantasyConfig())
+
+			// Set up mock expectations
+			mockRepo.On("GetByID", 1).Return(tt.manager, nil).Once()
+
+			// Call the function under test
+			err := service.Authorize(tt.user, 1)
+
+			// Assertions
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			// Verify that all expected calls were made
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestManagerService_Authorize_NotFound(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+
+	// Create manager service with mocks
+	service := services.NewManagerService(mockRepo, mockSquadRepo, mockPlayerRepo, services.DefaultFantasyConfig())
+
+	// Set up mock expectations
+	mockRepo.On("GetByID", 9).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	// Call the function under test
+	err := service.Authorize(managerOwner, 9)
+
+	// Assertions
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestManagerService_CreateSquad(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+
+	// Create manager service with mocks
+	service := services.NewManagerService(mockRepo, mockSquadRepo, mockPlayerRepo, services.DefaultFantasyConfig())
+
+	ids, players := fantasySquadPlayers(6.0)
+	manager := &models.Manager{ID: 1, Name: "Alex", TeamName: "Alex's XI", Bank: 100}
+
+	// Set up mock expectations
+	mockRepo.On("GetByID", 1).Return(manager, nil).Once()
+	mockSquadRepo.On("GetByManager", 1).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockPlayerRepo.On("GetByIDs", ids).Return(players, nil).Once()
+	mockSquadRepo.On("Create", mock.MatchedBy(func(squad *models.FantasySquad) bool {
+		return squad.ManagerID == 1 && len(squad.Players) == 15 && squad.Players[0].PurchasePrice == 6.0
+	}), mock.MatchedBy(func(manager *models.Manager) bool {
+		return manager.Bank == 10.0
+	})).Return(nil).Once()
+
+	// Call the function under test
+	squad, err := service.CreateSquad(1, ids)
+
+	// Assertions
+	assert.NoError(t, err, "CreateSquad should not return an error")
+	assert.Len(t, squad.Players, 15, "Squad should hold every picked player")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockSquadRepo.AssertExpectations(t)
+	mockPlayerRepo.AssertExpectations(t)
+}
+
+func TestManagerService_CreateSquad_SaveFails(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+
+	// Create manager service with mocks
+	service := services.NewManagerService(mockRepo, mockSquadRepo, mockPlayerRepo, services.DefaultFantasyConfig())
+
+	ids, players := fantasySquadPlayers(6.0)
+	manager := &models.Manager{ID: 1, Name: "Alex", TeamName: "Alex's XI", Bank: 100}
+
+	// Set up mock expectations: the squad and the bank are written together, so a failure leaves neither
+	mockRepo.On("GetByID", 1).Return(manager, nil).Once()
+	mockSquadRepo.On("GetByManager", 1).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockPlayerRepo.On("GetByIDs", ids).Return(players, nil).Once()
+	mockSquadRepo.On("Create", mock.AnythingOfType("*models.FantasySquad"), manager).Return(errors.New("connection lost")).Once()
+
+	// Call the function under test
+	squad, err := service.CreateSquad(1, ids)
+
+	// Assertions
+	assert.EqualError(t, err, "connection lost")
+	assert.Nil(t, squad)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockSquadRepo.AssertExpectations(t)
+	mockPlayerRepo.AssertExpectations(t)
+}
+
+func TestManagerService_CreateSquad_BreaksRules(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+
+	// Create manager service with mocks
+	service := services.NewManagerService(mockRepo, mockSquadRepo, mockPlayerRepo, services.DefaultFantasyConfig())
+
+	// Too expensive, and every player from the same club
+	ids, players := fantasySquadPlayers(7.0)
+	for i := range players {
+		players[i].TeamID = 1
+	}
+
+	// Set up mock expectations
+	mockRepo.On("GetByID", 1).Return(&models.Manager{ID: 1}, nil).Once()
+	mockSquadRepo.On("GetByManager", 1).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockPlayerRepo.On("GetByIDs", ids).Return(players, nil).Once()
+
+	// Call the function under test
+	_, err := service.CreateSquad(1, ids)
+
+	// Assertions
+	var validationErr *services.SquadValidationError
+	if assert.ErrorAs(t, err, &validationErr, "CreateSquad should report the broken rules") {
+		assert.False(t, validationErr.Validation.Valid, "Squad should be invalid")
+		assert.Equal(t, 105.0, validationErr.Validation.Cost, "Cost should use current prices")
+		assert.Len(t, validationErr.Validation.Errors, 2, "Club cap and budget should both be reported")
+	}
+	mockSquadRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockSquadRepo.AssertExpectations(t)
+	mockPlayerRepo.AssertExpectations(t)
+}
+
+func TestManagerService_CreateSquad_AlreadyPicked(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+
+	// Create manager service with mocks
+	service := services.NewManagerService(mockRepo, mockSquadRepo, mockPlayerRepo, services.DefaultFantasyConfig())
+
+	// Set up mock expectations
+	mockRepo.On("GetByID", 1).Return(&models.Manager{ID: 1}, nil).Once()
+	mockSquadRepo.On("GetByManager", 1).Return(&models.FantasySquad{ID: 3, ManagerID: 1}, nil).Once()
+
+	// Call the function under test
+	_, err := service.CreateSquad(1, []uint{1, 2, 3})
+
+	// Assertions
+	assert.ErrorIs(t, err, services.ErrSquadExists, "A second squad should be rejected")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockSquadRepo.AssertExpectations(t)
+}
+
+func TestManagerService_ValidatePlayers(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+
+	// Create manager service with mocks
+	service := services.NewManagerService(mockRepo, mockSquadRepo, mockPlayerRepo, services.DefaultFantasyConfig())
+
+	// A goalkeeper picked twice plus a player that does not exist
+	ids := []uint{1, 1, 99}
+	players := []models.Player{{ID: 1, Position: models.PositionGoalkeeper, TeamID: 1, Price: 4.5}}
+
+	// Set up mock expectations
+	mockPlayerRepo.On("GetByIDs", ids).Return(players, nil).Once()
+
+	// Call the function under test
+	validation, err := service.ValidatePlayers(ids)
+
+	// Assertions
+	assert.NoError(t, err, "ValidatePlayers should not return an error")
+	assert.False(t, validation.Valid, "Squad should be invalid")
+	assert.Contains(t, validation.Errors, "squad must have 15 players, got 3", "Squad size should be checked")
+	assert.Contains(t, validation.Errors, "player 1 is picked more than once", "Duplicates should be reported")
+	assert.Contains(t, validation.Errors, "player 99 does not exist", "Unknown players should be reported")
+	assert.Contains(t, validation.Errors, "squad must have 5 DEF, got 0", "Position quotas should be checked")
+	assert.Equal(t, 95.5, validation.Remaining, "Remaining budget should use current prices")
+
+	// Verify that all expected calls were made
+	mockPlayerRepo.AssertExpectations(t)
+}
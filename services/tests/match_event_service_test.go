@@ -0,0 +1,230 @@
+package tests
+
+import (
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMatchEventService_GenerateForMatch(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockMatchEventRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+
+	// Create match event service with mocks
+	service := services.NewMatchEventService(mockRepo, mockMatchRepo, mockPlayerRepo)
+
+	// A played match with a known score
+	match := &models.Match{
+		ID:                5,
+		Week:              2,
+		HomeTeamID:        1,
+		AwayTeamID:        2,
+		HomeTeamScore:     3,
+		AwayTeamScore:     1,
+		HomeExpectedGoals: 2.1,
+		AwayExpectedGoals: 0.9,
+		IsPlayed:          true,
+	}
+
+	// Set up mock expectations; the teams have no squads yet
+	mockPlayerRepo.On("GetByTeam", 1).Return([]models.Player{}, nil).Once()
+	mockPlayerRepo.On("GetByTeam", 2).Return([]models.Player{}, nil).Once()
+	mockRepo.On("ReplaceByMatch", 5, mock.AnythingOfType("[]models.MatchEvent")).Return(nil).Once()
+
+	// Call the function under test
+	events, err := service.GenerateForMatch(match)
+
+	// Assertions
+	assert.NoError(t, err, "GenerateForMatch should not return an error")
+
+	homeGoals, awayGoals := 0, 0
+	lastMinute := 0
+	for _, event := range events {
+		assert.Equal(t, uint(5), event.MatchID, "Every event should belong to the match")
+		assert.GreaterOrEqual(t, event.Minute, lastMinute, "Events should be in chronological order")
+		lastMinute = event.Minute
+
+		switch {
+		case event.Type == models.EventGoal && event.TeamID == 1:
+			homeGoals++
+		case event.Type == models.EventGoal && event.TeamID == 2:
+			awayGoals++
+		}
+
+		if event.Type == models.EventHalfTime {
+			assert.Equal(t, 45, event.Minute, "Half-time should be at minute 45")
+		}
+	}
+
+	assert.Equal(t, 3, homeGoals, "Home goal events should match the home score")
+	assert.Equal(t, 1, awayGoals, "Away goal events should match the away score")
+
+	final := events[len(events)-1]
+	assert.Equal(t, models.EventFullTime, final.Type, "Timeline should end at full time")
+	assert.Equal(t, 3, final.HomeScore, "Final running home score should match the result")
+	assert.Equal(t, 1, final.AwayScore, "Final running away score should match the result")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockPlayerRepo.AssertExpectations(t)
+}
+
+func TestMatchEventService_GenerateGoalsForMatch(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockMatchEventRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+
+	// Create match event service with mocks
+	service := services.NewMatchEventService(mockRepo, mockMatchRepo, mockPlayerRepo)
+
+	match := &models.Match{ID: 8, HomeTeamID: 1, AwayTeamID: 2, HomeTeamScore: 4, AwayTeamScore: 2, IsPlayed: true}
+	homeSquad := []models.Player{
+		{ID: 10, TeamID: 1, Position: models.PositionForward, Rating: 90},
+		{ID: 11, TeamID: 1, Position: models.PositionMidfielder, Rating: 85},
+	}
+	awaySquad := []models.Player{
+		{ID: 20, TeamID: 2, Position: models.PositionForward, Rating: 80},
+	}
+
+	// Set up mock expectations
+	mockPlayerRepo.On("GetByTeam", 1).Return(homeSquad, nil).Once()
+	mockPlayerRepo.On("GetByTeam", 2).Return(awaySquad, nil).Once()
+	mockRepo.On("GetByMatch", 8).Return([]models.MatchEvent{}, nil).Once()
+	mockRepo.On("ReplaceByMatch", 8, mock.AnythingOfType("[]models.MatchEvent")).Return(nil).Once()
+
+	// Call the function under test
+	events, err := service.GenerateGoalsForMatch(match)
+
+	// Assertions
+	assert.NoError(t, err, "GenerateGoalsForMatch should not return an error")
+	assert.True(t, slices.ContainsFunc(events, func(event models.MatchEvent) bool { return event.Type == models.EventFullTime }),
+		"A match without a stored timeline should have the rest of it simulated around the goals")
+	goals := slices.DeleteFunc(slices.Clone(events), func(event models.MatchEvent) bool { return event.Type != models.EventGoal })
+	assert.Len(t, goals, 6, "There should be one event per goal")
+	for _, event := range goals {
+		if assert.NotNil(t, event.PlayerID, "Every goal should have a scorer") {
+			if event.TeamID == 1 {
+				assert.Contains(t, []uint{10, 11}, *event.PlayerID, "Home goals should be scored by the home squad")
+			} else {
+				assert.Equal(t, uint(20), *event.PlayerID, "Away goals should be scored by the away squad")
+			}
+		}
+		if event.AssistPlayerID != nil {
+			assert.NotEqual(t, *event.PlayerID, *event.AssistPlayerID, "Scorers should not assist themselves")
+		}
+	}
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockPlayerRepo.AssertExpectations(t)
+}
+
+func TestMatchEventService_GenerateGoalsForMatch_KeepsSupportingEvents(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockMatchEventRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+
+	// Create match event service with mocks
+	service := services.NewMatchEventService(mockRepo, mockMatchRepo, mockPlayerRepo)
+
+	// The result was 1-0 and is edited to 0-1; the stored card and full-time whistle must survive
+	match := &models.Match{ID: 8, HomeTeamID: 1, AwayTeamID: 2, HomeTeamScore: 0, AwayTeamScore: 1, IsPlayed: true}
+	booked := uint(10)
+	stored := []models.MatchEvent{
+		{ID: 1, MatchID: 8, Minute: 20, Type: models.EventGoal, TeamID: 1, HomeScore: 1},
+		{ID: 2, MatchID: 8, Minute: 40, Type: models.EventYellowCard, TeamID: 1, PlayerID: &booked, HomeScore: 1},
+		{ID: 3, MatchID: 8, Minute: 90, Type: models.EventFullTime, HomeScore: 1},
+	}
+
+	// Set up mock expectations
+	mockRepo.On("GetByMatch", 8).Return(stored, nil).Once()
+	mockPlayerRepo.On("GetByTeam", 1).Return([]models.Player{}, nil).Once()
+	mockPlayerRepo.On("GetByTeam", 2).Return([]models.Player{{ID: 20, TeamID: 2, Position: models.PositionForward, Rating: 80}}, nil).Once()
+	mockRepo.On("ReplaceByMatch", 8, mock.AnythingOfType("[]models.MatchEvent")).Return(nil).Once()
+
+	// Call the function under test
+	events, err := service.GenerateGoalsForMatch(match)
+
+	// Assertions
+	assert.NoError(t, err, "GenerateGoalsForMatch should not return an error")
+	assert.Len(t, events, 3, "The old goal should be replaced and the rest of the timeline kept")
+	card := slices.IndexFunc(events, func(event models.MatchEvent) bool { return event.Type == models.EventYellowCard })
+	if assert.GreaterOrEqual(t, card, 0, "The stored card should be kept") {
+		assert.Equal(t, &booked, events[card].PlayerID, "The card should still belong to the same player")
+	}
+	last := events[len(events)-1]
+	assert.Equal(t, models.EventFullTime, last.Type, "The timeline should still end at full time")
+	assert.Equal(t, 0, last.HomeScore, "Running scores should follow the edited result")
+	assert.Equal(t, 1, last.AwayScore, "Running scores should follow the edited result")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockPlayerRepo.AssertExpectations(t)
+}
+
+func TestMatchEventService_GetTopScorers(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockMatchEventRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+
+	// Create match event service with mocks
+	service := services.NewMatchEventService(mockRepo, mockMatchRepo, mockPlayerRepo)
+
+	expectedLeaders := []models.PlayerLeader{
+		{PlayerID: 9, Name: "Erling Haaland", Position: models.PositionForward, TeamID: 3, Total: 7},
+		{PlayerID: 11, Name: "Mohamed Salah", Position: models.PositionMidfielder, TeamID: 4, Total: 5},
+	}
+
+	// Set up mock expectations
+	mockRepo.On("GetTopScorers", 10).Return(expectedLeaders, nil).Once()
+
+	// Call the function under test
+	leaders, err := service.GetTopScorers(10)
+
+	// Assertions
+	assert.NoError(t, err, "GetTopScorers should not return an error")
+	assert.Equal(t, expectedLeaders, leaders, "Leaders should match expected")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMatchEventService_GetByMatch(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockMatchEventRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+
+	// Create match event service with mocks
+	service := services.NewMatchEventService(mockRepo, mockMatchRepo, mockPlayerRepo)
+
+	expectedEvents := []models.MatchEvent{
+		{ID: 1, MatchID: 3, Minute: 12, Type: models.EventGoal, TeamID: 1, HomeScore: 1},
+		{ID: 2, MatchID: 3, Minute: 45, Type: models.EventHalfTime, HomeScore: 1},
+	}
+
+	// Set up mock expectations
+	mockMatchRepo.On("GetByID", 3).Return(&models.Match{ID: 3}, nil).Once()
+	mockRepo.On("GetByMatch", 3).Return(expectedEvents, nil).Once()
+
+	// Call the function under test
+	events, err := service.GetByMatch(3)
+
+	// Assertions
+	assert.NoError(t, err, "GetByMatch should not return an error")
+	assert.Equal(t, expectedEvents, events, "Events should match expected")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockMatchRepo.AssertExpectations(t)
+}
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func TestMatchService_Create(t *testing.T) {
@@ -14,7 +16,7 @@ func TestMatchService_Create(t *testing.T) {
 	mockRepo := new(repomocks.MockMatchRepository)
 
 	// Create match service with mock
-	service := services.NewMatchService(mockRepo)
+	service := services.NewMatchService(mockRepo, new(repomocks.MockTeamRepository))
 
 	// Test data
 	newMatch := &models.Match{
@@ -44,7 +46,7 @@ func TestMatchService_GetAll(t *testing.T) {
 	mockRepo := new(repomocks.MockMatchRepository)
 
 	// Create match service with mock
-	service := services.NewMatchService(mockRepo)
+	service := services.NewMatchService(mockRepo, new(repomocks.MockTeamRepository))
 
 	// Expected matches
 	expectedMatches := []models.Match{
@@ -87,7 +89,7 @@ func TestMatchService_GetByID(t *testing.T) {
 	mockRepo := new(repomocks.MockMatchRepository)
 
 	// Create match service with mock
-	service := services.NewMatchService(mockRepo)
+	service := services.NewMatchService(mockRepo, new(repomocks.MockTeamRepository))
 
 	// Test data
 	matchID := 1
@@ -120,7 +122,7 @@ func TestMatchService_GetByWeek(t *testing.T) {
 	mockRepo := new(repomocks.MockMatchRepository)
 
 	// Create match service with mock
-	service := services.NewMatchService(mockRepo)
+	service := services.NewMatchService(mockRepo, new(repomocks.MockTeamRepository))
 
 	// Test data
 	week := 2
@@ -164,7 +166,7 @@ func TestMatchService_GetUnplayedWeeks(t *testing.T) {
 	mockRepo := new(repomocks.MockMatchRepository)
 
 	// Create match service with mock
-	service := services.NewMatchService(mockRepo)
+	service := services.NewMatchService(mockRepo, new(repomocks.MockTeamRepository))
 
 	// Expected unplayed weeks
 	expectedWeeks := []int{3, 4, 5}
@@ -183,12 +185,77 @@ func TestMatchService_GetUnplayedWeeks(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestMatchService_GetHeadToHead(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockMatchRepository)
+	mockTeamRepo := new(repomocks.MockTeamRepository)
+
+	// Create match service with mocks
+	service := services.NewMatchService(mockRepo, mockTeamRepo)
+
+	// Played meetings between team 1 and team 2, at both venues
+	meetings := []models.Match{
+		{ID: 1, Week: 1, HomeTeamID: 1, AwayTeamID: 2, HomeTeamScore: 3, AwayTeamScore: 0, IsPlayed: true},
+		{ID: 2, Week: 4, HomeTeamID: 2, AwayTeamID: 1, HomeTeamScore: 2, AwayTeamScore: 2, IsPlayed: true},
+		{ID: 3, Week: 7, HomeTeamID: 2, AwayTeamID: 1, HomeTeamScore: 4, AwayTeamScore: 1, IsPlayed: true},
+	}
+
+	// Set up mock expectations
+	mockTeamRepo.On("GetByID", 1).Return(&models.Team{ID: 1}, nil).Once()
+	mockTeamRepo.On("GetByID", 2).Return(&models.Team{ID: 2}, nil).Once()
+	mockRepo.On("GetPlayedBetween", 1, 2).Return(meetings, nil).Once()
+
+	// Call the function under test
+	record, err := service.GetHeadToHead(1, 2)
+
+	// Assertions
+	assert.NoError(t, err, "GetHeadToHead should not return an error")
+	assert.Equal(t, 3, record.Played, "All meetings should be counted")
+	assert.Equal(t, 1, record.Wins, "Team 1 won once")
+	assert.Equal(t, 1, record.Draws, "Team 1 drew once")
+	assert.Equal(t, 1, record.Losses, "Team 1 lost once")
+	assert.Equal(t, 6, record.GoalsFor, "Goals for should be oriented to team 1")
+	assert.Equal(t, 6, record.GoalsAgainst, "Goals against should be oriented to team 1")
+	assert.Equal(t, uint(1), record.BiggestWin.ID, "Biggest win should be the 3-0")
+	assert.Equal(t, uint(3), record.BiggestLoss.ID, "Biggest loss should be the 4-1 away defeat")
+	assert.Equal(t, uint(3), record.HighestScore.ID, "Highest scoring meeting should be the 4-1")
+	assert.Len(t, record.Meetings, 3, "All meetings should be returned")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestMatchService_GetHeadToHead_UnknownTeam(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockMatchRepository)
+	mockTeamRepo := new(repomocks.MockTeamRepository)
+
+	// Create match service with mocks
+	service := services.NewMatchService(mockRepo, mockTeamRepo)
+
+	// Set up mock expectations
+	mockTeamRepo.On("GetByID", 1).Return(&models.Team{ID: 1}, nil).Once()
+	mockTeamRepo.On("GetByID", 99).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	// Call the function under test
+	record, err := service.GetHeadToHead(1, 99)
+
+	// Assertions
+	assert.Nil(t, record, "No record should be returned for an unknown team")
+	assert.Equal(t, gorm.ErrRecordNotFound, err, "An unknown team should surface as not found")
+	mockRepo.AssertNotCalled(t, "GetPlayedBetween", mock.Anything, mock.Anything)
+
+	// Verify that all expected calls were made
+	mockTeamRepo.AssertExpectations(t)
+}
+
 func TestMatchService_Update(t *testing.T) {
 	// Create mock repository
 	mockRepo := new(repomocks.MockMatchRepository)
 
 	// Create match service with mock
-	service := services.NewMatchService(mockRepo)
+	service := services.NewMatchService(mockRepo, new(repomocks.MockTeamRepository))
 
 	// Test data
 	updatedMatch := &models.Match{
@@ -219,7 +286,7 @@ func TestMatchService_Delete(t *testing.T) {
 	mockRepo := new(repomocks.MockMatchRepository)
 
 	// Create match service with mock
-	service := services.NewMatchService(mockRepo)
+	service := services.NewMatchService(mockRepo, new(repomocks.MockTeamRepository))
 
 	// Test data
 	matchID := 1
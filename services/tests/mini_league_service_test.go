@@ -0,0 +1,349 @@
+package tests
+
+import (
+	"insider-league/eventbus"
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// miniLeagueMembers returns members for the given manager IDs with their managers filled in
+func miniLeagueMembers(managerIDs ...uint) []models.MiniLeagueMember {
+	var members []models.MiniLeagueMember
+	for _, id := range managerIDs {
+		members = append(members, models.MiniLeagueMember{
+			ManagerID: id,
+			Manager:   models.Manager{ID: id, Name: "Manager", TeamName: "Team"},
+		})
+	}
+	return members
+}
+
+func TestMiniLeagueService_Create(t *testing.T) {
+	tests := []struct {
+		name          string
+		league        models.MiniLeague
+		expectedStart int
+		expectErr     error
+	}{
+		{"Classic from week 1", models.MiniLeague{Name: "Office"}, 1, nil},
+		{"Head-to-head from the open gameweek", models.MiniLeague{Name: "Rivals", Type: models.MiniLeagueHeadToHead}, 3, nil},
+		{"Head-to-head from a later week", models.MiniLeague{Name: "Rivals", Type: models.MiniLeagueHeadToHead, StartWeek: 5}, 5, nil},
+		{"Head-to-head from a played week", models.MiniLeague{Name: "Rivals", Type: models.MiniLeagueHeadToHead, StartWeek: 2}, 0, services.ErrInvalidMiniLeague},
+		{"Unknown type", models.MiniLeague{Name: "Office", Type: "draft"}, 0, services.ErrInvalidMiniLeague},
+		{"Missing name", models.MiniLeague{Name: " "}, 0, services.ErrInvalidMiniLeague},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repositories
+			mockRepo := new(repomocks.MockMiniLeagueRepository)
+			mockManagerRepo := new(repomocks.MockManagerRepository)
+			mockPointsRepo := new(repomocks.MockFantasyPointsRepository)
+			mockMatchRepo := new(repomocks.MockMatchRepository)
+
+			// Create mini-league service with mocks
+			service := services.NewMiniLeagueService(mockRepo, mockManagerRepo, mockPointsRepo, mockMatchRepo, services.NewWeekStatus())
+			league := tt.league
+			league.CreatorManagerID = 1
+
+			// Set up mock expectations
+			mockManagerRepo.On("GetByID", 1).Return(&models.Manager{ID: 1, UserID: &managerOwner.ID}, nil).Maybe()
+			mockMatchRepo.On("GetUnplayedWeeks").Return([]int{3, 4, 5, 6}, nil).Maybe()
+			if tt.expectErr == nil {
+				mockRepo.On("GetByCode", mock.AnythingOfType("string")).Return(nil, gorm.ErrRecordNotFound).Once()
+				mockRepo.On("Create", mock.MatchedBy(func(created *models.MiniLeague) bool {
+					return len(created.Code) == 8 && len(created.Members) == 1 && created.Members[0].ManagerID == 1
+				})).Return(nil).Once()
+			}
+
+			// Call the function under test
+			err := service.Create(managerOwner, &league)
+
+			// Assertions
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStart, league.StartWeek)
+			assert.NotEmpty(t, league.Type)
+
+			// Verify that all expected calls were made
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMiniLeagueService_Join(t *testing.T) {
+	tests := []struct {
+		name      string
+		league    models.MiniLeague
+		openWeek  int
+		expectErr error
+	}{
+		{"Classic league mid-season", models.MiniLeague{ID: 7, Type: models.MiniLeagueClassic, StartWeek: 1}, 4, nil},
+		{"Head-to-head before its start", models.MiniLeague{ID: 7, Type: models.MiniLeagueHeadToHead, StartWeek: 4}, 4, nil},
+		{"Head-to-head after its start", models.MiniLeague{ID: 7, Type: models.MiniLeagueHeadToHead, StartWeek: 3}, 4, services.ErrMiniLeagueClosed},
+		{"Already a member", models.MiniLeague{ID: 7, Type: models.MiniLeagueClassic, StartWeek: 1, Members: miniLeagueMembers(2)}, 4, services.ErrAlreadyMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repositories
+			mockRepo := new(repomocks.MockMiniLeagueRepository)
+			mockManagerRepo := new(repomocks.MockManagerRepository)
+			mockPointsRepo := new(repomocks.MockFantasyPointsRepository)
+			mockMatchRepo := new(repomocks.MockMatchRepository)
+
+			// Create mini-league service with mocks
+			service := services.NewMiniLeagueService(mockRepo, mockManagerRepo, mockPointsRepo, mockMatchRepo, services.NewWeekStatus())
+			league := tt.league
+			if league.Members == nil {
+				league.Members = miniLeagueMembers(1)
+			}
+
+			// Set up mock expectations
+			mockRepo.On("GetByCode", "AB12CD34").Return(&league, nil).Once()
+			mockManagerRepo.On("GetByID", 2).Return(&models.Manager{ID: 2, UserID: &managerOwner.ID}, nil).Once()
+			mockMatchRepo.On("GetUnplayedWeeks").Return([]int{tt.openWeek, 6}, nil).Maybe()
+			if tt.expectErr == nil {
+				mockRepo.On("AddMember", &models.MiniLeagueMember{LeagueID: 7, ManagerID: 2}).Return(nil).Once()
+				mockRepo.On("GetByID", 7).Return(&league, nil).Once()
+			}
+
+			// Call the function under test
+			_, err := service.Join(managerOwner, " ab12cd34 ", 2)
+
+			// Assertions
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				mockRepo.AssertNotCalled(t, "AddMember", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+
+			// Verify that all expected calls were made
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMiniLeagueService_OtherAccountsManager(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockMiniLeagueRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockPointsRepo := new(repomocks.MockFantasyPointsRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+
+	// Create mini-league service with mocks
+	service := services.NewMiniLeagueService(mockRepo, mockManagerRepo, mockPointsRepo, mockMatchRepo, services.NewWeekStatus())
+	intruder := &models.User{ID: 8, Role: models.RoleManager}
+	league := &models.MiniLeague{ID: 7, Type: models.MiniLeagueClassic, StartWeek: 1, Members: miniLeagueMembers(1)}
+
+	// Set up mock expectations
+	mockManagerRepo.On("GetByID", 2).Return(&models.Manager{ID: 2, UserID: &managerOwner.ID}, nil).Twice()
+	mockRepo.On("GetByCode", "AB12CD34").Return(league, nil).Once()
+
+	// Call the functions under test
+	createErr := service.Create(intruder, &models.MiniLeague{Name: "Office", CreatorManagerID: 2})
+	_, joinErr := service.Join(intruder, "AB12CD34", 2)
+
+	// Assertions
+	assert.ErrorIs(t, createErr, services.ErrNotManagerOwner)
+	assert.ErrorIs(t, joinErr, services.ErrNotManagerOwner)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	mockRepo.AssertNotCalled(t, "AddMember", mock.Anything)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockManagerRepo.AssertExpectations(t)
+}
+
+func TestMiniLeagueService_HandleEvent_DrawsAndSettlesFixtures(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockMiniLeagueRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockPointsRepo := new(repomocks.MockFantasyPointsRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+
+	// Create mini-league service with mocks
+	service := services.NewMiniLeagueService(mockRepo, mockManagerRepo, mockPointsRepo, mockMatchRepo, services.NewWeekStatus())
+	league := models.MiniLeague{ID: 7, Type: models.MiniLeagueHeadToHead, StartWeek: 2, Members: miniLeagueMembers(1, 2, 3, 4)}
+
+	// Set up mock expectations
+	mockRepo.On("GetByType", models.MiniLeagueHeadToHead).Return([]models.MiniLeague{league}, nil).Once()
+	mockRepo.On("GetFixtures", 7).Return([]models.HeadToHeadFixture{}, nil).Once()
+	mockMatchRepo.On("GetAll").Return(sixWeekSeason(), nil).Once()
+
+	var drawn []models.HeadToHeadFixture
+	mockRepo.On("CreateFixtures", mock.Anything).Run(func(args mock.Arguments) {
+		drawn = args.Get(0).([]models.HeadToHeadFixture)
+	}).Return(nil).Once()
+
+	mockPointsRepo.On("GetManagerGameweek", 1, 2).Return(&models.ManagerGameweek{Points: 60}, nil)
+	mockPointsRepo.On("GetManagerGameweek", 2, 2).Return(&models.ManagerGameweek{Points: 45}, nil)
+	mockPointsRepo.On("GetManagerGameweek", 3, 2).Return(&models.ManagerGameweek{Points: 50}, nil)
+	mockPointsRepo.On("GetManagerGameweek", 4, 2).Return(nil, gorm.ErrRecordNotFound)
+
+	var settled []models.HeadToHeadFixture
+	mockRepo.On("SaveFixtures", mock.Anything).Run(func(args mock.Arguments) {
+		settled = args.Get(0).([]models.HeadToHeadFixture)
+	}).Return(nil).Once()
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{Type: eventbus.WeekPlayed, Week: 2})
+
+	// Assertions: weeks 2 to 6 with two matchups each, everyone meeting once in the first three weeks
+	assert.Len(t, drawn, 10)
+	met := make(map[[2]uint]int)
+	for _, fixture := range drawn {
+		assert.NotEqual(t, fixture.HomeManagerID, fixture.AwayManagerID)
+		if fixture.Week <= 4 {
+			pair := [2]uint{min(fixture.HomeManagerID, fixture.AwayManagerID), max(fixture.HomeManagerID, fixture.AwayManagerID)}
+			met[pair]++
+		}
+	}
+	assert.Len(t, met, 6)
+
+	// The second cycle swaps home and away
+	assert.Equal(t, drawn[0].HomeManagerID, drawn[6].AwayManagerID)
+	assert.Equal(t, drawn[0].AwayManagerID, drawn[6].HomeManagerID)
+
+	assert.Len(t, settled, 2)
+	scores := map[uint]int{1: 60, 2: 45, 3: 50, 4: 0}
+	for _, fixture := range settled {
+		assert.Equal(t, 2, fixture.Week)
+		assert.True(t, fixture.IsPlayed)
+		assert.Equal(t, scores[fixture.HomeManagerID], fixture.HomePoints)
+		assert.Equal(t, scores[fixture.AwayManagerID], fixture.AwayPoints)
+	}
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockMatchRepo.AssertExpectations(t)
+}
+
+func TestMiniLeagueService_HandleEvent_BeforeStartWeek(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockMiniLeagueRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockPointsRepo := new(repomocks.MockFantasyPointsRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+
+	// Create mini-league service with mocks
+	service := services.NewMiniLeagueService(mockRepo, mockManagerRepo, mockPointsRepo, mockMatchRepo, services.NewWeekStatus())
+	league := models.MiniLeague{ID: 7, Type: models.MiniLeagueHeadToHead, StartWeek: 4, Members: miniLeagueMembers(1, 2)}
+
+	// Set up mock expectations
+	mockRepo.On("GetByType", models.MiniLeagueHeadToHead).Return([]models.MiniLeague{league}, nil).Once()
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{Type: eventbus.WeekPlayed, Week: 3})
+
+	// Assertions
+	mockRepo.AssertNotCalled(t, "CreateFixtures", mock.Anything)
+	mockRepo.AssertNotCalled(t, "SaveFixtures", mock.Anything)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMiniLeagueService_GetStandings(t *testing.T) {
+	t.Run("Classic ranks by points since the start week", func(t *testing.T) {
+		// Create mock repositories
+		mockRepo := new(repomocks.MockMiniLeagueRepository)
+		mockManagerRepo := new(repomocks.MockManagerRepository)
+		mockPointsRepo := new(repomocks.MockFantasyPointsRepository)
+		mockMatchRepo := new(repomocks.MockMatchRepository)
+
+		// Create mini-league service with mocks
+		service := services.NewMiniLeagueService(mockRepo, mockManagerRepo, mockPointsRepo, mockMatchRepo, services.NewWeekStatus())
+		league := &models.MiniLeague{ID: 7, Type: models.MiniLeagueClassic, StartWeek: 2, Members: miniLeagueMembers(1, 2, 3)}
+
+		// Set up mock expectations
+		mockRepo.On("GetByID", 7).Return(league, nil).Once()
+		mockPointsRepo.On("GetManagerGameweeks", 1).Return([]models.ManagerGameweek{{Week: 1, Points: 90}, {Week: 2, Points: 40}}, nil).Once()
+		mockPointsRepo.On("GetManagerGameweeks", 2).Return([]models.ManagerGameweek{{Week: 1, Points: 20}, {Week: 2, Points: 70}}, nil).Once()
+		mockPointsRepo.On("GetManagerGameweeks", 3).Return([]models.ManagerGameweek{{Week: 2, Points: 40}}, nil).Once()
+
+		// Call the function under test
+		standings, err := service.GetStandings(7)
+
+		// Assertions
+		assert.NoError(t, err)
+		assert.Len(t, standings, 3)
+		assert.Equal(t, uint(2), standings[0].ManagerID)
+		assert.Equal(t, 70, standings[0].Total)
+		assert.Equal(t, 1, standings[0].Rank)
+		assert.Equal(t, 2, standings[1].Rank)
+		assert.Equal(t, 2, standings[2].Rank)
+		assert.Equal(t, 0, standings[0].Played)
+
+		// Verify that all expected calls were made
+		mockRepo.AssertExpectations(t)
+		mockPointsRepo.AssertExpectations(t)
+	})
+
+	t.Run("Head-to-head ranks by league points", func(t *testing.T) {
+		// Create mock repositories
+		mockRepo := new(repomocks.MockMiniLeagueRepository)
+		mockManagerRepo := new(repomocks.MockManagerRepository)
+		mockPointsRepo := new(repomocks.MockFantasyPointsRepository)
+		mockMatchRepo := new(repomocks.MockMatchRepository)
+
+		// Create mini-league service with mocks
+		service := services.NewMiniLeagueService(mockRepo, mockManagerRepo, mockPointsRepo, mockMatchRepo, services.NewWeekStatus())
+		league := &models.MiniLeague{ID: 7, Type: models.MiniLeagueHeadToHead, StartWeek: 1, Members: miniLeagueMembers(1, 2)}
+
+		// Set up mock expectations
+		mockRepo.On("GetByID", 7).Return(league, nil).Once()
+		mockPointsRepo.On("GetManagerGameweeks", 1).Return([]models.ManagerGameweek{{Week: 1, Points: 90}, {Week: 2, Points: 30}, {Week: 3, Points: 50}}, nil).Once()
+		mockPointsRepo.On("GetManagerGameweeks", 2).Return([]models.ManagerGameweek{{Week: 1, Points: 40}, {Week: 2, Points: 35}, {Week: 3, Points: 50}}, nil).Once()
+		mockRepo.On("GetFixtures", 7).Return([]models.HeadToHeadFixture{
+			{Week: 1, HomeManagerID: 1, AwayManagerID: 2, HomePoints: 90, AwayPoints: 40, IsPlayed: true},
+			{Week: 2, HomeManagerID: 2, AwayManagerID: 1, HomePoints: 35, AwayPoints: 30, IsPlayed: true},
+			{Week: 3, HomeManagerID: 1, AwayManagerID: 2, HomePoints: 50, AwayPoints: 50, IsPlayed: true},
+			{Week: 4, HomeManagerID: 2, AwayManagerID: 1},
+		}, nil).Once()
+
+		// Call the function under test
+		standings, err := service.GetStandings(7)
+
+		// Assertions: level on 4 points, manager 1 ahead on points scored
+		assert.NoError(t, err)
+		assert.Len(t, standings, 2)
+		assert.Equal(t, models.MiniLeagueStanding{Rank: 1, ManagerID: 1, Name: "Manager", TeamName: "Team", Total: 170, Played: 3, Won: 1, Drawn: 1, Lost: 1, Points: 4}, standings[0])
+		assert.Equal(t, models.MiniLeagueStanding{Rank: 2, ManagerID: 2, Name: "Manager", TeamName: "Team", Total: 125, Played: 3, Won: 1, Drawn: 1, Lost: 1, Points: 4}, standings[1])
+
+		// Verify that all expected calls were made
+		mockRepo.AssertExpectations(t)
+		mockPointsRepo.AssertExpectations(t)
+	})
+}
+
+func TestMiniLeagueService_HandleEvent_Reset(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockMiniLeagueRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockPointsRepo := new(repomocks.MockFantasyPointsRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+
+	// Create mini-league service with mocks
+	service := services.NewMiniLeagueService(mockRepo, mockManagerRepo, mockPointsRepo, mockMatchRepo, services.NewWeekStatus())
+
+	// Set up mock expectations
+	mockRepo.On("ResetSeason").Return(nil).Once()
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{Type: eventbus.LeagueReset})
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
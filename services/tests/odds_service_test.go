@@ -0,0 +1,149 @@
+package tests
+
+import (
+	"insider-league/helpers"
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// evenMatch returns an unplayed fixture between two equally rated teams
+func evenMatch() *models.Match {
+	return &models.Match{
+		ID:       5,
+		Week:     3,
+		HomeTeam: models.Team{Name: "Arsenal", Attack: 80, Defence: 80},
+		AwayTeam: models.Team{Name: "Chelsea", Attack: 80, Defence: 80},
+	}
+}
+
+// marketByName returns the market with the given name
+func marketByName(t *testing.T, odds *models.MatchOdds, name string) models.OddsMarket {
+	for _, market := range odds.Markets {
+		if market.Name == name {
+			return market
+		}
+	}
+	t.Fatalf("market %q not found", name)
+	return models.OddsMarket{}
+}
+
+func TestOddsService_GetMatchOdds(t *testing.T) {
+	repo := new(repomocks.MockMatchRepository)
+	service := services.NewOddsService(repo, services.NewWeekStatus(), services.DefaultOddsConfig())
+
+	// Set up mock expectations
+	repo.On("GetByID", 5).Return(evenMatch(), nil).Once()
+
+	// Call the function under test
+	odds, err := service.GetMatchOdds(5, nil)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "Arsenal", odds.HomeTeam)
+	assert.Equal(t, 0.05, odds.Margin)
+	assert.Equal(t, 1.5, odds.HomeExpectedGoals)
+	assert.Equal(t, 1.3, odds.AwayExpectedGoals)
+	assert.Len(t, odds.Markets, 3)
+
+	// Every market's probabilities cover every outcome, and its prices carry the margin
+	for _, market := range odds.Markets {
+		total, implied := 0.0, 0.0
+		for _, selection := range market.Selections {
+			total += selection.Probability
+			implied += 1 / selection.Decimal
+		}
+		assert.InDelta(t, 1, total, 0.001, market.Name)
+		assert.InDelta(t, 1.05, implied, 0.02, market.Name)
+		assert.Equal(t, 1.05, market.Overround)
+	}
+
+	// Home advantage makes the home side favourites
+	result := marketByName(t, odds, services.MarketMatchResult)
+	assert.Equal(t, []string{"Home", "Draw", "Away"}, []string{result.Selections[0].Selection, result.Selections[1].Selection, result.Selections[2].Selection})
+	assert.Greater(t, result.Selections[0].Probability, result.Selections[2].Probability)
+
+	// Over 2.5 goals is the complement of at most two goals from a Poisson mean of 2.8
+	overUnder := marketByName(t, odds, services.MarketOverUnder)
+	assert.InDelta(t, 1-helpers.TotalGoalsAtMost(2, 1.495, 1.3), overUnder.Selections[0].Probability, 0.001)
+
+	// Correct scores are listed likeliest first, with the rest grouped last
+	correctScore := marketByName(t, odds, services.MarketCorrectScore)
+	assert.Equal(t, "1-1", correctScore.Selections[0].Selection)
+	assert.Len(t, correctScore.Selections, 37)
+	assert.Equal(t, "Any other score", correctScore.Selections[36].Selection)
+
+	// Verify that all expected calls were made
+	repo.AssertExpectations(t)
+}
+
+func TestOddsService_GetMatchOdds_Rejected(t *testing.T) {
+	played := evenMatch()
+	played.IsPlayed = true
+	negative, tooLarge := -0.1, 1.0
+
+	tests := []struct {
+		name      string
+		match     *models.Match
+		margin    *float64
+		expectErr error
+	}{
+		{"Played match", played, nil, services.ErrMatchPlayed},
+		{"Unknown match", nil, nil, gorm.ErrRecordNotFound},
+		{"Negative margin", evenMatch(), &negative, services.ErrInvalidMargin},
+		{"Margin too large", evenMatch(), &tooLarge, services.ErrInvalidMargin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(repomocks.MockMatchRepository)
+			service := services.NewOddsService(repo, services.NewWeekStatus(), services.DefaultOddsConfig())
+
+			// Set up mock expectations
+			if tt.margin == nil {
+				if tt.match != nil {
+					repo.On("GetByID", 5).Return(tt.match, nil).Once()
+				} else {
+					repo.On("GetByID", 5).Return(nil, gorm.ErrRecordNotFound).Once()
+				}
+			}
+
+			// Call the function under test
+			odds, err := service.GetMatchOdds(5, tt.margin)
+
+			// Assertions
+			assert.Nil(t, odds)
+			assert.ErrorIs(t, err, tt.expectErr)
+
+			// Verify that all expected calls were made
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOddsFormats(t *testing.T) {
+	tests := []struct {
+		decimal    float64
+		fractional string
+		american   string
+	}{
+		{2.5, "3/2", "+150"},
+		{3.0, "2/1", "+200"},
+		{2.0, "1/1", "+100"},
+		{1.5, "1/2", "-200"},
+		{1.91, "10/11", "-110"},
+	}
+
+	for _, tt := range tests {
+		// Call the functions under test and check the formats
+		assert.Equal(t, tt.fractional, helpers.FractionalOdds(tt.decimal), tt.decimal)
+		assert.Equal(t, tt.american, helpers.AmericanOdds(tt.decimal), tt.decimal)
+	}
+
+	// A 50% chance with a 5% margin is priced at 1/0.525
+	assert.InDelta(t, 1.9048, helpers.DecimalOdds(0.5, 0.05), 0.0001)
+}
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestPlayerService_Create(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockPlayerRepository)
+	mockTeamRepo := new(repomocks.MockTeamRepository)
+
+	// Create player service with mocks
+	service := services.NewPlayerService(mockRepo, mockTeamRepo)
+
+	// Test data
+	newPlayer := &models.Player{Name: "Cole Palmer", Position: models.PositionMidfielder, TeamID: 1, Rating: 88, ShirtNumber: 20, Price: 10.5}
+
+	// Set up mock expectations
+	mockTeamRepo.On("GetByID", 1).Return(&models.Team{ID: 1, Name: "Chelsea"}, nil).Once()
+	mockRepo.On("GetByTeam", 1).Return([]models.Player{{ID: 2, TeamID: 1, ShirtNumber: 8}}, nil).Once()
+	mockRepo.On("Create", newPlayer).Return(nil).Once()
+
+	// Call the function under test
+	err := service.Create(newPlayer)
+
+	// Assertions
+	assert.NoError(t, err, "Create should not return an error")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPlayerService_Create_Invalid(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockPlayerRepository)
+	mockTeamRepo := new(repomocks.MockTeamRepository)
+
+	// Create player service with mocks
+	service := services.NewPlayerService(mockRepo, mockTeamRepo)
+
+	// Set up mock expectations
+	mockTeamRepo.On("GetByID", 1).Return(&models.Team{ID: 1}, nil).Once()
+	mockTeamRepo.On("GetByID", 9).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockRepo.On("GetByTeam", 1).Return([]models.Player{{ID: 2, TeamID: 1, ShirtNumber: 20}}, nil).Once()
+
+	// Call the function under test
+	errUnknownPosition := service.Create(&models.Player{Name: "Cole Palmer", Position: "WING", TeamID: 1, Rating: 88, ShirtNumber: 20})
+	errUnknownTeam := service.Create(&models.Player{Name: "Cole Palmer", Position: models.PositionMidfielder, TeamID: 9, Rating: 88, ShirtNumber: 20})
+	errShirtTaken := service.Create(&models.Player{Name: "Cole Palmer", Position: models.PositionMidfielder, TeamID: 1, Rating: 88, ShirtNumber: 20})
+
+	// Assertions
+	assert.ErrorIs(t, errUnknownPosition, services.ErrInvalidPlayer, "Unknown positions should be rejected")
+	assert.ErrorIs(t, errUnknownTeam, services.ErrPlayerTeamNotFound, "Players must belong to an existing team")
+	assert.ErrorIs(t, errShirtTaken, services.ErrShirtNumberTaken, "Shirt numbers must be unique within a squad")
+	mockRepo.AssertNotCalled(t, "Create")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPlayerService_GetByTeam(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockPlayerRepository)
+	mockTeamRepo := new(repomocks.MockTeamRepository)
+
+	// Create player service with mocks
+	service := services.NewPlayerService(mockRepo, mockTeamRepo)
+
+	// Expected squad
+	expectedPlayers := []models.Player{
+		{ID: 1, Name: "Alisson", Position: models.PositionGoalkeeper, TeamID: 4, ShirtNumber: 1},
+		{ID: 2, Name: "Mohamed Salah", Position: models.PositionMidfielder, TeamID: 4, ShirtNumber: 11},
+	}
+
+	// Set up mock expectations
+	mockTeamRepo.On("GetByID", 4).Return(&models.Team{ID: 4, Name: "Liverpool"}, nil).Once()
+	mockTeamRepo.On("GetByID", 9).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockRepo.On("GetByTeam", 4).Return(expectedPlayers, nil).Once()
+
+	// Call the function under test
+	players, err := service.GetByTeam(4)
+	_, errMissing := service.GetByTeam(9)
+
+	// Assertions
+	assert.NoError(t, err, "GetByTeam should not return an error")
+	assert.Equal(t, expectedPlayers, players, "Squad should match expected")
+	assert.Equal(t, gorm.ErrRecordNotFound, errMissing, "Unknown teams should surface as not found")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPlayerService_Update(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockPlayerRepository)
+	mockTeamRepo := new(repomocks.MockTeamRepository)
+
+	// Create player service with mocks
+	service := services.NewPlayerService(mockRepo, mockTeamRepo)
+
+	// Test data: keeping his own shirt number is not a clash
+	updatedPlayer := &models.Player{ID: 5, Name: "Erling Haaland", Position: models.PositionForward, TeamID: 3, Rating: 92, ShirtNumber: 9, Price: 15.0}
+
+	// Set up mock expectations
+	mockRepo.On("GetByID", 5).Return(&models.Player{ID: 5, TeamID: 3, ShirtNumber: 9}, nil).Once()
+	mockTeamRepo.On("GetByID", 3).Return(&models.Team{ID: 3}, nil).Once()
+	mockRepo.On("GetByTeam", 3).Return([]models.Player{{ID: 5, TeamID: 3, ShirtNumber: 9}}, nil).Once()
+	mockRepo.On("Update", updatedPlayer).Return(nil).Once()
+
+	// Call the function under test
+	err := service.Update(updatedPlayer)
+
+	// Assertions
+	assert.NoError(t, err, "Update should not return an error")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"insider-league/eventbus"
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPriceService_UpdatePrices(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockPriceRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockTransferRepo := new(repomocks.MockTransferRepository)
+	mockPointsRepo := new(repomocks.MockFantasyPointsRepository)
+
+	// Create price service with mocks
+	service := services.NewPriceService(mockRepo, mockPlayerRepo, mockManagerRepo, mockTransferRepo, mockPointsRepo, services.DefaultFantasyConfig())
+
+	players := []models.Player{
+		{ID: 1, Price: 6.0},  // Transferred in and in form: two steps up
+		{ID: 2, Price: 6.0},  // Transferred out and out of form: two steps down
+		{ID: 3, Price: 6.0},  // Transferred in but out of form: unchanged
+		{ID: 4, Price: 15.9}, // In form near the ceiling: capped
+		{ID: 5, Price: 3.5},  // Out of form at the floor: unchanged
+		{ID: 6, Price: 5.0},  // Average form and no transfers: unchanged
+	}
+	managers := make([]models.Manager, 10)
+	transfers := []models.Transfer{
+		{PlayerOutID: 2, PlayerInID: 1},
+		{PlayerOutID: 6, PlayerInID: 3},
+		{PlayerOutID: 3, PlayerInID: 6},
+		{PlayerOutID: 2, PlayerInID: 3},
+	}
+
+	// Set up mock expectations
+	mockPlayerRepo.On("GetAll").Return(players, nil).Once()
+	mockManagerRepo.On("GetAll").Return(managers, nil).Once()
+	mockTransferRepo.On("GetByWeek", 4).Return(transfers, nil).Once()
+	mockPointsRepo.On("GetPlayerGameweeks", 2).Return([]models.PlayerGameweek{
+		{PlayerID: 1, Points: 8}, {PlayerID: 2, Points: 1}, {PlayerID: 3, Points: 0}, {PlayerID: 4, Points: 9}, {PlayerID: 5, Points: 0}, {PlayerID: 6, Points: 3},
+	}, nil).Once()
+	mockPointsRepo.On("GetPlayerGameweeks", 3).Return([]models.PlayerGameweek{
+		{PlayerID: 1, Points: 6}, {PlayerID: 2, Points: 0}, {PlayerID: 3, Points: 1}, {PlayerID: 4, Points: 7}, {PlayerID: 5, Points: 1}, {PlayerID: 6, Points: 2},
+	}, nil).Once()
+	mockPointsRepo.On("GetPlayerGameweeks", 4).Return([]models.PlayerGameweek{
+		{PlayerID: 1, Points: 7}, {PlayerID: 2, Points: 2}, {PlayerID: 3, Points: 2}, {PlayerID: 4, Points: 8}, {PlayerID: 5, Points: 2}, {PlayerID: 6, Points: 4},
+	}, nil).Once()
+	mockPlayerRepo.On("Update", mock.AnythingOfType("*models.Player")).Return(nil).Times(3)
+	mockRepo.On("Create", mock.Anything).Return(nil).Once()
+
+	// Call the function under test
+	changes, err := service.UpdatePrices(4)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Len(t, changes, 3)
+	assert.Equal(t, models.PriceChange{PlayerID: 1, Week: 4, OldPrice: 6.0, NewPrice: 6.2, NetTransfers: 1, Form: 7}, changes[0])
+	assert.Equal(t, models.PriceChange{PlayerID: 2, Week: 4, OldPrice: 6.0, NewPrice: 5.8, NetTransfers: -2, Form: 1}, changes[1])
+	assert.Equal(t, models.PriceChange{PlayerID: 4, Week: 4, OldPrice: 15.9, NewPrice: 16.0, Form: 8}, changes[2])
+
+	// Verify that all expected calls were made
+	mockPlayerRepo.AssertExpectations(t)
+	mockPointsRepo.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPriceService_UpdatePrices_NoManagers(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockPriceRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockTransferRepo := new(repomocks.MockTransferRepository)
+	mockPointsRepo := new(repomocks.MockFantasyPointsRepository)
+
+	// Create price service with mocks
+	service := services.NewPriceService(mockRepo, mockPlayerRepo, mockManagerRepo, mockTransferRepo, mockPointsRepo, services.DefaultFantasyConfig())
+
+	// Set up mock expectations: early in the season only one week counts towards form
+	mockPlayerRepo.On("GetAll").Return([]models.Player{{ID: 1, Price: 6.0}}, nil).Once()
+	mockManagerRepo.On("GetAll").Return([]models.Manager{}, nil).Once()
+	mockTransferRepo.On("GetByWeek", 1).Return([]models.Transfer{}, nil).Once()
+	mockPointsRepo.On("GetPlayerGameweeks", 1).Return([]models.PlayerGameweek{{PlayerID: 1, Points: 12}}, nil).Once()
+	mockPlayerRepo.On("Update", &models.Player{ID: 1, Price: 6.1}).Return(nil).Once()
+	mockRepo.On("Create", mock.Anything).Return(nil).Once()
+
+	// Call the function under test
+	changes, err := service.UpdatePrices(1)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, 6.1, changes[0].NewPrice)
+
+	// Verify that all expected calls were made
+	mockPlayerRepo.AssertExpectations(t)
+	mockPointsRepo.AssertExpectations(t)
+}
+
+func TestPriceService_HandleEvent_Reset(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockPriceRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockTransferRepo := new(repomocks.MockTransferRepository)
+	mockPointsRepo := new(repomocks.MockFantasyPointsRepository)
+
+	// Create price service with mocks
+	service := services.NewPriceService(mockRepo, mockPlayerRepo, mockManagerRepo, mockTransferRepo, mockPointsRepo, services.DefaultFantasyConfig())
+
+	// Set up mock expectations
+	mockRepo.On("GetAll").Return([]models.PriceChange{
+		{PlayerID: 1, Week: 1, OldPrice: 6.0, NewPrice: 6.1},
+		{PlayerID: 2, Week: 1, OldPrice: 5.0, NewPrice: 4.9},
+		{PlayerID: 1, Week: 2, OldPrice: 6.1, NewPrice: 6.3},
+	}, nil).Once()
+	mockPlayerRepo.On("GetByID", 1).Return(&models.Player{ID: 1, Price: 6.3}, nil).Once()
+	mockPlayerRepo.On("GetByID", 2).Return(&models.Player{ID: 2, Price: 4.9}, nil).Once()
+	mockPlayerRepo.On("Update", &models.Player{ID: 1, Price: 6.0}).Return(nil).Once()
+	mockPlayerRepo.On("Update", &models.Player{ID: 2, Price: 5.0}).Return(nil).Once()
+	mockRepo.On("DeleteAll").Return(nil).Once()
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{Type: eventbus.LeagueReset})
+
+	// Verify that all expected calls were made
+	mockPlayerRepo.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRatingService_ApplyMatchResult(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockRatingRepository)
+	mockTeamRepo := new(repomocks.MockTeamRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+
+	// Create rating service with mocks and no home advantage to keep the maths simple
+	config := services.EloConfig{KFactor: 20, HomeAdvantage: 0, InitialRating: 1500}
+	service := services.NewRatingService(mockRepo, mockTeamRepo, mockMatchRepo, config)
+
+	// Equal teams, home side wins by one goal
+	homeTeam := &models.Team{ID: 1, Name: "Home Team", Rating: 1500}
+	awayTeam := &models.Team{ID: 2, Name: "Away Team", Rating: 1500}
+	match := &models.Match{ID: 7, Week: 2, HomeTeamID: 1, AwayTeamID: 2, HomeTeamScore: 1, AwayTeamScore: 0, IsPlayed: true}
+
+	// Set up mock expectations
+	mockTeamRepo.On("Update", homeTeam).Return(nil).Once()
+	mockTeamRepo.On("Update", awayTeam).Return(nil).Once()
+	mockRepo.On("Create", mock.MatchedBy(func(ratings []models.TeamRating) bool {
+		return len(ratings) == 2 &&
+			ratings[0].TeamID == 1 && ratings[0].MatchID == 7 && ratings[0].Change == 10 &&
+			ratings[1].TeamID == 2 && ratings[1].MatchID == 7 && ratings[1].Change == -10
+	})).Return(nil).Once()
+
+	// Call the function under test
+	err := service.ApplyMatchResult(match, homeTeam, awayTeam)
+
+	// Assertions
+	assert.NoError(t, err, "ApplyMatchResult should not return an error")
+	assert.Equal(t, 1510.0, homeTeam.Rating, "Winner should gain K/2 points against an equal opponent")
+	assert.Equal(t, 1490.0, awayTeam.Rating, "Loser should lose the same amount")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestRatingService_Rebuild(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockRatingRepository)
+	mockTeamRepo := new(repomocks.MockTeamRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+
+	// Create rating service with mocks
+	config := services.EloConfig{KFactor: 20, HomeAdvantage: 0, InitialRating: 1500}
+	service := services.NewRatingService(mockRepo, mockTeamRepo, mockMatchRepo, config)
+
+	// Teams carry stale ratings that must be discarded
+	teams := []models.Team{
+		{ID: 1, Name: "Team A", Rating: 1620},
+		{ID: 2, Name: "Team B", Rating: 1380},
+	}
+
+	// One played match and one unplayed fixture
+	matches := []models.Match{
+		{ID: 2, Week: 2, HomeTeamID: 2, AwayTeamID: 1, IsPlayed: false},
+		{ID: 1, Week: 1, HomeTeamID: 1, AwayTeamID: 2, HomeTeamScore: 0, AwayTeamScore: 0, IsPlayed: true},
+	}
+
+	// Set up mock expectations
+	mockTeamRepo.On("GetAll").Return(teams, nil).Once()
+	mockMatchRepo.On("GetAll").Return(matches, nil).Once()
+	mockRepo.On("DeleteAll").Return(nil).Once()
+	mockTeamRepo.On("Update", mock.MatchedBy(func(team *models.Team) bool {
+		return team.Rating == 1500
+	})).Return(nil).Twice()
+	mockRepo.On("Create", mock.MatchedBy(func(ratings []models.TeamRating) bool {
+		return len(ratings) == 2 && ratings[0].MatchID == 1 && ratings[1].MatchID == 1
+	})).Return(nil).Once()
+
+	// Call the function under test
+	err := service.Rebuild()
+
+	// Assertions
+	assert.NoError(t, err, "Rebuild should not return an error")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+	mockMatchRepo.AssertExpectations(t)
+}
+
+func TestRatingService_GetPowerRankings(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockRatingRepository)
+	mockTeamRepo := new(repomocks.MockTeamRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+
+	// Create rating service with mocks
+	service := services.NewRatingService(mockRepo, mockTeamRepo, mockMatchRepo, services.DefaultEloConfig())
+
+	// Teams in arbitrary order
+	teams := []models.Team{
+		{ID: 1, Name: "Team A", Rating: 1480},
+		{ID: 2, Name: "Team B", Rating: 1560},
+		{ID: 3, Name: "Team C", Rating: 1510},
+	}
+
+	// Set up mock expectations
+	mockTeamRepo.On("GetAll").Return(teams, nil).Once()
+
+	// Call the function under test
+	rankings, err := service.GetPowerRankings()
+
+	// Assertions
+	assert.NoError(t, err, "GetPowerRankings should not return an error")
+	assert.Len(t, rankings, 3, "All teams should be ranked")
+	assert.Equal(t, "Team B", rankings[0].TeamName, "Highest rated team should be first")
+	assert.Equal(t, "Team C", rankings[1].TeamName, "Second highest rated team should be second")
+	assert.Equal(t, "Team A", rankings[2].TeamName, "Lowest rated team should be last")
+	assert.Equal(t, 1, rankings[0].Position, "Positions should start at 1")
+
+	// Verify that all expected calls were made
+	mockTeamRepo.AssertExpectations(t)
+}
@@ -0,0 +1,278 @@
+package tests
+
+import (
+	"insider-league/eventbus"
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// points returns a pointer to a prediction score
+func points(value int) *int {
+	return &value
+}
+
+func TestScorePredictionService_Predict(t *testing.T) {
+	user := &models.User{ID: 7}
+
+	t.Run("New prediction", func(t *testing.T) {
+		// Create mock repositories
+		mockRepo := new(repomocks.MockScorePredictionRepository)
+		mockMatchRepo := new(repomocks.MockMatchRepository)
+		mockUserRepo := new(repomocks.MockUserRepository)
+
+		// Create score prediction service with mocks
+		service := services.NewScorePredictionService(mockRepo, mockMatchRepo, mockUserRepo, services.NewWeekStatus())
+
+		// Set up mock expectations
+		mockMatchRepo.On("GetByID", 5).Return(&models.Match{ID: 5, Week: 3}, nil).Once()
+		mockRepo.On("GetByUserAndMatch", 7, 5).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("Create", &models.ScorePrediction{UserID: 7, MatchID: 5, Week: 3, HomeGoals: 2, AwayGoals: 1}).Return(nil).Once()
+
+		// Call the function under test
+		prediction, err := service.Predict(user, models.ScorePredictionRequest{MatchID: 5, HomeGoals: 2, AwayGoals: 1})
+
+		// Assertions
+		assert.NoError(t, err)
+		assert.Equal(t, 3, prediction.Week)
+		assert.Nil(t, prediction.Points)
+
+		// Verify that all expected calls were made
+		mockMatchRepo.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Changed prediction", func(t *testing.T) {
+		// Create mock repositories
+		mockRepo := new(repomocks.MockScorePredictionRepository)
+		mockMatchRepo := new(repomocks.MockMatchRepository)
+		mockUserRepo := new(repomocks.MockUserRepository)
+
+		// Create score prediction service with mocks
+		service := services.NewScorePredictionService(mockRepo, mockMatchRepo, mockUserRepo, services.NewWeekStatus())
+
+		// Set up mock expectations
+		mockMatchRepo.On("GetByID", 5).Return(&models.Match{ID: 5, Week: 3}, nil).Once()
+		mockRepo.On("GetByUserAndMatch", 7, 5).Return(&models.ScorePrediction{ID: 9, UserID: 7, MatchID: 5, Week: 3, HomeGoals: 0, AwayGoals: 0}, nil).Once()
+		mockRepo.On("Update", &models.ScorePrediction{ID: 9, UserID: 7, MatchID: 5, Week: 3, HomeGoals: 1, AwayGoals: 3}).Return(nil).Once()
+
+		// Call the function under test
+		prediction, err := service.Predict(user, models.ScorePredictionRequest{MatchID: 5, HomeGoals: 1, AwayGoals: 3})
+
+		// Assertions
+		assert.NoError(t, err)
+		assert.Equal(t, uint(9), prediction.ID)
+
+		// Verify that all expected calls were made
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Locked once played", func(t *testing.T) {
+		// Create mock repositories
+		mockRepo := new(repomocks.MockScorePredictionRepository)
+		mockMatchRepo := new(repomocks.MockMatchRepository)
+		mockUserRepo := new(repomocks.MockUserRepository)
+
+		// Create score prediction service with mocks
+		service := services.NewScorePredictionService(mockRepo, mockMatchRepo, mockUserRepo, services.NewWeekStatus())
+
+		// Set up mock expectations
+		mockMatchRepo.On("GetByID", 5).Return(&models.Match{ID: 5, Week: 3, IsPlayed: true}, nil).Once()
+
+		// Call the function under test
+		prediction, err := service.Predict(user, models.ScorePredictionRequest{MatchID: 5, HomeGoals: 2, AwayGoals: 1})
+
+		// Assertions
+		assert.Nil(t, prediction)
+		assert.ErrorIs(t, err, services.ErrPredictionLocked)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("Locked while its week is played", func(t *testing.T) {
+		// Create mock repositories
+		mockRepo := new(repomocks.MockScorePredictionRepository)
+		mockMatchRepo := new(repomocks.MockMatchRepository)
+		mockUserRepo := new(repomocks.MockUserRepository)
+
+		// Create score prediction service with mocks while week 3 is being played
+		weeks := services.NewWeekStatus()
+		weeks.Start(3, 3)
+		service := services.NewScorePredictionService(mockRepo, mockMatchRepo, mockUserRepo, weeks)
+
+		// Set up mock expectations
+		mockMatchRepo.On("GetByID", 5).Return(&models.Match{ID: 5, Week: 3}, nil).Once()
+
+		// Call the function under test
+		prediction, err := service.Predict(user, models.ScorePredictionRequest{MatchID: 5, HomeGoals: 2, AwayGoals: 1})
+
+		// Assertions
+		assert.Nil(t, prediction)
+		assert.ErrorIs(t, err, services.ErrWeekInProgress)
+		mockRepo.AssertNotCalled(t, "GetByUserAndMatch", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Negative goals", func(t *testing.T) {
+		// Create mock repositories
+		mockRepo := new(repomocks.MockScorePredictionRepository)
+		mockMatchRepo := new(repomocks.MockMatchRepository)
+		mockUserRepo := new(repomocks.MockUserRepository)
+
+		// Create score prediction service with mocks
+		service := services.NewScorePredictionService(mockRepo, mockMatchRepo, mockUserRepo, services.NewWeekStatus())
+
+		// Call the function under test
+		prediction, err := service.Predict(user, models.ScorePredictionRequest{MatchID: 5, HomeGoals: -1})
+
+		// Assertions
+		assert.Nil(t, prediction)
+		assert.ErrorIs(t, err, services.ErrInvalidPrediction)
+		mockMatchRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+	})
+}
+
+func TestScorePredictionService_HandleEvent_Scoring(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockScorePredictionRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockUserRepo := new(repomocks.MockUserRepository)
+
+	// Create score prediction service with mocks
+	service := services.NewScorePredictionService(mockRepo, mockMatchRepo, mockUserRepo, services.NewWeekStatus())
+
+	match := models.Match{ID: 5, Week: 3, HomeTeamScore: 2, AwayTeamScore: 1, IsPlayed: true}
+
+	// Set up mock expectations
+	mockRepo.On("GetByMatch", 5).Return([]models.ScorePrediction{
+		{ID: 1, UserID: 1, MatchID: 5, HomeGoals: 2, AwayGoals: 1}, // Exact score
+		{ID: 2, UserID: 2, MatchID: 5, HomeGoals: 3, AwayGoals: 0}, // Right outcome
+		{ID: 3, UserID: 3, MatchID: 5, HomeGoals: 1, AwayGoals: 1}, // Wrong outcome
+	}, nil).Once()
+	mockRepo.On("Update", mock.MatchedBy(func(p *models.ScorePrediction) bool { return p.ID == 1 && *p.Points == 3 })).Return(nil).Once()
+	mockRepo.On("Update", mock.MatchedBy(func(p *models.ScorePrediction) bool { return p.ID == 2 && *p.Points == 1 })).Return(nil).Once()
+	mockRepo.On("Update", mock.MatchedBy(func(p *models.ScorePrediction) bool { return p.ID == 3 && *p.Points == 0 })).Return(nil).Once()
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{Type: eventbus.WeekPlayed, Week: 3, Matches: []models.Match{match}})
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestScorePredictionService_HandleEvent_Edited(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockScorePredictionRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockUserRepo := new(repomocks.MockUserRepository)
+
+	// Create score prediction service with mocks
+	service := services.NewScorePredictionService(mockRepo, mockMatchRepo, mockUserRepo, services.NewWeekStatus())
+
+	// An edited result rescores predictions that were already scored
+	edited := models.Match{ID: 5, Week: 3, HomeTeamScore: 1, AwayTeamScore: 1, IsPlayed: true}
+
+	// Set up mock expectations
+	mockRepo.On("GetByMatch", 5).Return([]models.ScorePrediction{
+		{ID: 1, UserID: 1, MatchID: 5, HomeGoals: 2, AwayGoals: 1, Points: points(3)},
+		{ID: 3, UserID: 3, MatchID: 5, HomeGoals: 1, AwayGoals: 1, Points: points(0)},
+	}, nil).Once()
+	mockRepo.On("Update", mock.MatchedBy(func(p *models.ScorePrediction) bool { return p.ID == 1 && *p.Points == 0 })).Return(nil).Once()
+	mockRepo.On("Update", mock.MatchedBy(func(p *models.ScorePrediction) bool { return p.ID == 3 && *p.Points == 3 })).Return(nil).Once()
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{Type: eventbus.MatchResultEdited, Week: 3, Matches: []models.Match{edited}})
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestScorePredictionService_HandleEvent_Reset(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockScorePredictionRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockUserRepo := new(repomocks.MockUserRepository)
+
+	// Create score prediction service with mocks
+	service := services.NewScorePredictionService(mockRepo, mockMatchRepo, mockUserRepo, services.NewWeekStatus())
+
+	// Set up mock expectations
+	mockRepo.On("DeleteAll").Return(nil).Once()
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{Type: eventbus.LeagueReset})
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestScorePredictionService_GetLeaderboard(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockScorePredictionRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockUserRepo := new(repomocks.MockUserRepository)
+
+	// Create score prediction service with mocks
+	service := services.NewScorePredictionService(mockRepo, mockMatchRepo, mockUserRepo, services.NewWeekStatus())
+
+	// Set up mock expectations
+	mockRepo.On("GetAll").Return([]models.ScorePrediction{
+		{UserID: 1, Points: points(3)},
+		{UserID: 1, Points: points(0)},
+		{UserID: 2, Points: points(1)},
+		{UserID: 2, Points: points(1)},
+		{UserID: 2, Points: points(1)},
+		{UserID: 3, Points: points(3)},
+		{UserID: 3, Points: nil}, // Not played yet
+		{UserID: 4, Points: points(0)},
+	}, nil).Once()
+	mockUserRepo.On("GetAll").Return([]models.User{{ID: 1, Name: "Ada"}, {ID: 2, Name: "Grace"}, {ID: 3, Name: "Alan"}, {ID: 4, Name: "Edsger"}}, nil).Once()
+
+	// Call the function under test
+	leaderboard, err := service.GetLeaderboard()
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, []models.PredictionLeaderboardEntry{
+		{Rank: 1, UserID: 1, Name: "Ada", Points: 3, ExactScores: 1, Predictions: 2},
+		{Rank: 1, UserID: 3, Name: "Alan", Points: 3, ExactScores: 1, Predictions: 2},
+		{Rank: 3, UserID: 2, Name: "Grace", Points: 3, CorrectOutcomes: 3, Predictions: 3},
+		{Rank: 4, UserID: 4, Name: "Edsger", Predictions: 1},
+	}, leaderboard)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestScorePredictionService_GetByWeek(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockScorePredictionRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockUserRepo := new(repomocks.MockUserRepository)
+
+	// Create score prediction service with mocks
+	service := services.NewScorePredictionService(mockRepo, mockMatchRepo, mockUserRepo, services.NewWeekStatus())
+
+	// Set up mock expectations
+	mockRepo.On("GetByWeek", 3).Return([]models.ScorePrediction{
+		{ID: 1, Match: &models.Match{ID: 5, IsPlayed: true}},
+		{ID: 2, Match: &models.Match{ID: 6}},
+	}, nil).Once()
+
+	// Call the function under test
+	predictions, err := service.GetByWeek(3)
+
+	// Assertions: predictions for matches still to be played stay hidden
+	assert.NoError(t, err)
+	assert.Len(t, predictions, 1)
+	assert.Equal(t, uint(1), predictions[0].ID)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"insider-league/eventbus"
 	repomocks "insider-league/mocks/repository"
 	"insider-league/models"
 	"insider-league/services"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func TestTeamService_UpdateTeamStats(t *testing.T) {
@@ -153,7 +155,7 @@ func TestTeamService_UpdateTeamStats(t *testing.T) {
 			mockRepo := new(repomocks.MockTeamRepository)
 
 			// Create team service with mock
-			service := services.NewTeamService(mockRepo)
+			service := services.NewTeamService(mockRepo, eventbus.NewBus())
 
 			// Create initial teams with some stats
 			homeTeam := &models.Team{
@@ -210,7 +212,7 @@ func TestTeamService_GetTeamRankings(t *testing.T) {
 	mockRepo := new(repomocks.MockTeamRepository)
 
 	// Create team service with mock
-	service := services.NewTeamService(mockRepo)
+	service := services.NewTeamService(mockRepo, eventbus.NewBus())
 
 	// Create unsorted teams with different stats
 	unsortedTeams := []models.Team{
@@ -297,7 +299,7 @@ func TestTeamService_Create(t *testing.T) {
 	mockRepo := new(repomocks.MockTeamRepository)
 
 	// Create team service with mock
-	service := services.NewTeamService(mockRepo)
+	service := services.NewTeamService(mockRepo, eventbus.NewBus())
 
 	// Test data
 	newTeam := &models.Team{
@@ -332,7 +334,7 @@ func TestTeamService_GetAll(t *testing.T) {
 	mockRepo := new(repomocks.MockTeamRepository)
 
 	// Create team service with mock
-	service := services.NewTeamService(mockRepo)
+	service := services.NewTeamService(mockRepo, eventbus.NewBus())
 
 	// Expected teams
 	expectedTeams := []models.Team{
@@ -383,7 +385,7 @@ func TestTeamService_GetByID(t *testing.T) {
 	mockRepo := new(repomocks.MockTeamRepository)
 
 	// Create team service with mock
-	service := services.NewTeamService(mockRepo)
+	service := services.NewTeamService(mockRepo, eventbus.NewBus())
 
 	// Test data
 	teamID := 1
@@ -420,7 +422,7 @@ func TestTeamService_Update(t *testing.T) {
 	mockRepo := new(repomocks.MockTeamRepository)
 
 	// Create team service with mock
-	service := services.NewTeamService(mockRepo)
+	service := services.NewTeamService(mockRepo, eventbus.NewBus())
 
 	// Test data
 	updatedTeam := &models.Team{
@@ -438,6 +440,7 @@ func TestTeamService_Update(t *testing.T) {
 	}
 
 	// Set up mock expectations
+	mockRepo.On("GetByID", 1).Return(&models.Team{ID: 1, Name: "Team", Strength: 80, Rating: 1500}, nil).Once()
 	mockRepo.On("Update", updatedTeam).Return(nil).Once()
 
 	// Call the function under test
@@ -450,12 +453,124 @@ func TestTeamService_Update(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestTeamService_Update_Partial(t *testing.T) {
+	// Create mock repository
+	mockRepo := new(repomocks.MockTeamRepository)
+
+	// Create team service with mock
+	service := services.NewTeamService(mockRepo, eventbus.NewBus())
+
+	// A rename sent without a rating or baseline strength, as a partial PUT body decodes
+	renamed := &models.Team{ID: 1, Name: "Renamed", Attack: 82, Defence: 78}
+
+	// Set up mock expectations
+	mockRepo.On("GetByID", 1).Return(&models.Team{ID: 1, Name: "Team", Attack: 82, Defence: 78, Strength: 80, BaseStrength: 76, Rating: 1612.5}, nil).Once()
+	mockRepo.On("Update", mock.MatchedBy(func(team *models.Team) bool {
+		return team.Name == "Renamed" && team.Rating == 1612.5 && team.BaseStrength == 76
+	})).Return(nil).Once()
+
+	// Call the function under test
+	err := service.Update(renamed)
+
+	// Assertions
+	assert.NoError(t, err, "Update should not return an error")
+	assert.Equal(t, 1612.5, renamed.Rating, "The stored Elo rating should be kept")
+	assert.Equal(t, 76, renamed.BaseStrength, "The stored baseline strength should be kept")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTeamService_Update_NotFound(t *testing.T) {
+	// Create mock repository
+	mockRepo := new(repomocks.MockTeamRepository)
+
+	// Create team service with mock
+	service := services.NewTeamService(mockRepo, eventbus.NewBus())
+
+	// Set up mock expectations
+	mockRepo.On("GetByID", 9).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	// Call the function under test
+	err := service.Update(&models.Team{ID: 9, Name: "Nobody"})
+
+	// Assertions
+	assert.Equal(t, gorm.ErrRecordNotFound, err, "An unknown team should surface as not found")
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTeamService_Save(t *testing.T) {
+	// Create mock repository
+	mockRepo := new(repomocks.MockTeamRepository)
+	bus := eventbus.NewBus()
+
+	// Create team service with mock
+	service := services.NewTeamService(mockRepo, bus)
+
+	// Record any events published
+	var published []eventbus.Event
+	bus.Subscribe(func(event eventbus.Event) {
+		published = append(published, event)
+	})
+
+	team := &models.Team{ID: 1, Name: "Team A", Attack: 80, Defence: 70}
+
+	// Set up mock expectations
+	mockRepo.On("Update", team).Return(nil).Once()
+
+	// Call the function under test
+	err := service.Save(team)
+
+	// Assertions
+	assert.NoError(t, err, "Save should not return an error")
+	assert.Equal(t, 75, team.Strength, "Strength should be derived from attack and defence")
+	assert.Empty(t, published, "Saving a team changed by the league should not publish an event")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTeamService_SaveRatings(t *testing.T) {
+	// Create mock repository
+	mockRepo := new(repomocks.MockTeamRepository)
+	bus := eventbus.NewBus()
+
+	// Create team service with mock
+	service := services.NewTeamService(mockRepo, bus)
+
+	// Record any events published
+	var published []eventbus.Event
+	bus.Subscribe(func(event eventbus.Event) {
+		published = append(published, event)
+	})
+
+	team := &models.Team{ID: 1, Name: "Team A", Attack: 90, Defence: 70}
+
+	// Set up mock expectations; only the ratings are written, never the whole row
+	mockRepo.On("UpdateRatings", team).Return(nil).Once()
+
+	// Call the function under test
+	err := service.SaveRatings(team)
+
+	// Assertions
+	assert.NoError(t, err, "SaveRatings should not return an error")
+	assert.Equal(t, 80, team.Strength, "Strength should be derived from attack and defence")
+	assert.Empty(t, published, "Saving fitted ratings should not publish an event")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
 func TestTeamService_Delete(t *testing.T) {
 	// Create mock repository
 	mockRepo := new(repomocks.MockTeamRepository)
 
 	// Create team service with mock
-	service := services.NewTeamService(mockRepo)
+	service := services.NewTeamService(mockRepo, eventbus.NewBus())
 
 	// Test data
 	teamID := 1
@@ -472,3 +587,76 @@ func TestTeamService_Delete(t *testing.T) {
 	// Verify that all expected calls were made
 	mockRepo.AssertExpectations(t)
 }
+
+func TestTeamService_Create_DerivesStrength(t *testing.T) {
+	tests := []struct {
+		name            string
+		team            models.Team
+		expectedAttack  int
+		expectedDefence int
+		expectedOverall int
+		description     string
+	}{
+		{
+			name:            "Attack and defence given",
+			team:            models.Team{Name: "Leaky Attackers", Attack: 90, Defence: 71, Strength: 50},
+			expectedAttack:  90,
+			expectedDefence: 71,
+			expectedOverall: 81,
+			description:     "Strength should be the rounded average of attack and defence",
+		},
+		{
+			name:            "Only strength given",
+			team:            models.Team{Name: "Legacy Client FC", Strength: 75},
+			expectedAttack:  75,
+			expectedDefence: 75,
+			expectedOverall: 75,
+			description:     "Strength alone should be copied to both ratings",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repository
+			mockRepo := new(repomocks.MockTeamRepository)
+
+			// Create team service with mock
+			service := services.NewTeamService(mockRepo, eventbus.NewBus())
+
+			team := tt.team
+
+			// Set up mock expectations
+			mockRepo.On("Create", &team).Return(nil).Once()
+
+			// Call the function under test
+			err := service.Create(&team)
+
+			// Assertions
+			assert.NoError(t, err, "Create should not return an error")
+			assert.Equal(t, tt.expectedAttack, team.Attack, tt.description)
+			assert.Equal(t, tt.expectedDefence, team.Defence, tt.description)
+			assert.Equal(t, tt.expectedOverall, team.Strength, tt.description)
+			assert.Equal(t, tt.expectedOverall, team.BaseStrength, "Base strength should follow the derived strength")
+
+			// Verify that all expected calls were made
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_Update_InvalidRatings(t *testing.T) {
+	// Create mock repository
+	mockRepo := new(repomocks.MockTeamRepository)
+
+	// Create team service with mock
+	service := services.NewTeamService(mockRepo, eventbus.NewBus())
+
+	// Call the function under test with an out-of-range attack rating
+	err := service.Update(&models.Team{ID: 1, Name: "Broken", Attack: 140, Defence: 80})
+
+	// Assertions
+	assert.ErrorIs(t, err, services.ErrInvalidRatings, "Out-of-range ratings should be rejected")
+
+	// The repository must not be touched
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,328 @@
+package tests
+
+import (
+	"errors"
+	"insider-league/eventbus"
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// fantasySquad wraps players in a saved squad bought at their current prices
+func fantasySquad(managerID uint, players []models.Player) *models.FantasySquad {
+	squad := &models.FantasySquad{ID: 1, ManagerID: managerID}
+	for _, player := range players {
+		squad.Players = append(squad.Players, models.FantasySquadPlayer{PlayerID: player.ID, Player: player, PurchasePrice: player.Price})
+	}
+	return squad
+}
+
+func TestTransferService_MakeTransfers(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockTransferRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockChipRepo := new(repomocks.MockChipRepository)
+
+	// Create transfer service with mocks
+	service := services.NewTransferService(mockRepo, mockManagerRepo, mockSquadRepo, mockPlayerRepo, mockMatchRepo, mockChipRepo, services.NewWeekStatus(), services.DefaultFantasyConfig())
+
+	ids, players := fantasySquadPlayers(6.0)
+	manager := &models.Manager{ID: 1, Bank: 10, FreeTransfers: 1}
+
+	// Swap a defender and a midfielder for players from the same clubs
+	defender := models.Player{ID: 16, Position: models.PositionDefender, TeamID: players[5].TeamID, Price: 9.0}
+	midfielder := models.Player{ID: 17, Position: models.PositionMidfielder, TeamID: players[7].TeamID, Price: 5.0}
+	newIDs := slices.Clone(ids)
+	newIDs[5], newIDs[7] = defender.ID, midfielder.ID
+	newPlayers := slices.Clone(players)
+	newPlayers[5], newPlayers[7] = defender, midfielder
+
+	// Set up mock expectations
+	mockManagerRepo.On("GetByID", 1).Return(manager, nil).Once()
+	mockMatchRepo.On("GetUnplayedWeeks").Return([]int{3, 4, 5, 6}, nil).Once()
+	mockSquadRepo.On("GetByManager", 1).Return(fantasySquad(1, players), nil).Once()
+	mockChipRepo.On("GetByManagerWeek", 1, 3).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockPlayerRepo.On("GetByIDs", newIDs).Return(newPlayers, nil).Once()
+	mockRepo.On("Create", mock.MatchedBy(func(squad *models.FantasySquad) bool {
+		return len(squad.Players) == 15 && squad.Players[5].PlayerID == 16 && squad.Players[5].PurchasePrice == 9.0
+	}), mock.MatchedBy(func(transfers []models.Transfer) bool {
+		return len(transfers) == 2 && transfers[0].Cost == 0 && transfers[1].Cost == 4 && transfers[0].SellingPrice == 6.0 && transfers[1].Week == 3
+	}), 8.0, 1).Return(nil).Once()
+
+	// Call the function under test
+	result, err := service.MakeTransfers(1, 0, []models.TransferRequest{
+		{PlayerOutID: 6, PlayerInID: 16},
+		{PlayerOutID: 8, PlayerInID: 17},
+	})
+
+	// Assertions
+	assert.NoError(t, err, "MakeTransfers should not return an error")
+	assert.Equal(t, 3, result.Week, "Transfers should count towards the next unplayed week")
+	assert.Equal(t, 1, result.FreeTransfersUsed, "The single free transfer should be used")
+	assert.Equal(t, 4, result.PointsCost, "The extra transfer should cost a points hit")
+	assert.Equal(t, 8.0, result.Bank, "The bank should hold what is left after the swaps")
+
+	// Verify that all expected calls were made
+	mockManagerRepo.AssertExpectations(t)
+	mockMatchRepo.AssertExpectations(t)
+	mockSquadRepo.AssertExpectations(t)
+	mockPlayerRepo.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTransferService_MakeTransfers_SellingPrice(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockTransferRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockChipRepo := new(repomocks.MockChipRepository)
+
+	// Create transfer service with mocks
+	service := services.NewTransferService(mockRepo, mockManagerRepo, mockSquadRepo, mockPlayerRepo, mockMatchRepo, mockChipRepo, services.NewWeekStatus(), services.DefaultFantasyConfig())
+
+	ids, players := fantasySquadPlayers(6.0)
+	manager := &models.Manager{ID: 1, Bank: 0, FreeTransfers: 1}
+
+	// The defender was bought at 6.0 and has risen to 6.5, the midfielder has fallen to 5.5
+	squad := fantasySquad(1, players)
+	squad.Players[5].Player.Price = 6.5
+	squad.Players[7].Player.Price = 5.5
+	defender := models.Player{ID: 16, Position: models.PositionDefender, TeamID: players[5].TeamID, Price: 6.2}
+	midfielder := models.Player{ID: 17, Position: models.PositionMidfielder, TeamID: players[7].TeamID, Price: 5.5}
+	newIDs := slices.Clone(ids)
+	newIDs[5], newIDs[7] = defender.ID, midfielder.ID
+	newPlayers := slices.Clone(players)
+	newPlayers[5], newPlayers[7] = defender, midfielder
+
+	// Set up mock expectations
+	mockManagerRepo.On("GetByID", 1).Return(manager, nil).Once()
+	mockMatchRepo.On("GetUnplayedWeeks").Return([]int{3, 4}, nil).Once()
+	mockSquadRepo.On("GetByManager", 1).Return(squad, nil).Once()
+	mockChipRepo.On("GetByManagerWeek", 1, 3).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockPlayerRepo.On("GetByIDs", newIDs).Return(newPlayers, nil).Once()
+	mockRepo.On("Create", squad, mock.MatchedBy(func(transfers []models.Transfer) bool {
+		return transfers[0].SellingPrice == 6.2 && transfers[1].SellingPrice == 5.5
+	}), 0.0, 1).Return(nil).Once()
+
+	// Call the function under test
+	result, err := service.MakeTransfers(1, 0, []models.TransferRequest{
+		{PlayerOutID: 6, PlayerInID: 16},
+		{PlayerOutID: 8, PlayerInID: 17},
+	})
+
+	// Assertions: half of the 0.5m rise is 0.25m, rounded down to 0.2m, so the defender raises exactly his replacement's price
+	assert.NoError(t, err, "MakeTransfers should not return an error")
+	assert.Equal(t, 0.0, result.Bank, "The sales should exactly cover the purchases")
+	assert.Equal(t, 6.2, result.Squad.Players[5].SellingPrice, "A new player should sell for what he cost")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTransferService_MakeTransfers_Rejected(t *testing.T) {
+	ids, players := fantasySquadPlayers(6.0)
+	expensive := models.Player{ID: 16, Position: models.PositionDefender, TeamID: players[5].TeamID, Price: 20.0}
+	newIDs := slices.Clone(ids)
+	newIDs[5] = expensive.ID
+	newPlayers := slices.Clone(players)
+	newPlayers[5] = expensive
+
+	tests := []struct {
+		name        string
+		week        int
+		unplayed    []int
+		requests    []models.TransferRequest
+		expectSquad bool
+		expectErr   error
+	}{
+		{"Week already simulated", 2, []int{3, 4}, []models.TransferRequest{{PlayerOutID: 6, PlayerInID: 16}}, false, services.ErrDeadlinePassed},
+		{"Season over", 0, []int{}, []models.TransferRequest{{PlayerOutID: 6, PlayerInID: 16}}, false, services.ErrSeasonOver},
+		{"Player not in squad", 3, []int{3, 4}, []models.TransferRequest{{PlayerOutID: 99, PlayerInID: 16}}, true, services.ErrInvalidTransfer},
+		{"Over budget", 0, []int{3, 4}, []models.TransferRequest{{PlayerOutID: 6, PlayerInID: 16}}, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repositories
+			mockRepo := new(repomocks.MockTransferRepository)
+			mockManagerRepo := new(repomocks.MockManagerRepository)
+			mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+			mockPlayerRepo := new(repomocks.MockPlayerRepository)
+			mockMatchRepo := new(repomocks.MockMatchRepository)
+			mockChipRepo := new(repomocks.MockChipRepository)
+
+			// Create transfer service with mocks
+			service := services.NewTransferService(mockRepo, mockManagerRepo, mockSquadRepo, mockPlayerRepo, mockMatchRepo, mockChipRepo, services.NewWeekStatus(), services.DefaultFantasyConfig())
+
+			// Set up mock expectations
+			mockManagerRepo.On("GetByID", 1).Return(&models.Manager{ID: 1, Bank: 10, FreeTransfers: 1}, nil).Once()
+			mockMatchRepo.On("GetUnplayedWeeks").Return(tt.unplayed, nil).Once()
+			if tt.expectSquad {
+				mockSquadRepo.On("GetByManager", 1).Return(fantasySquad(1, players), nil).Once()
+				mockChipRepo.On("GetByManagerWeek", 1, 3).Return(nil, gorm.ErrRecordNotFound).Once()
+				mockPlayerRepo.On("GetByIDs", newIDs).Return(newPlayers, nil).Maybe()
+			}
+
+			// Call the function under test
+			result, err := service.MakeTransfers(1, tt.week, tt.requests)
+
+			// Assertions
+			assert.Nil(t, result, "No result should be returned")
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr, "The transfers should be rejected with the expected error")
+			} else {
+				var validationErr *services.SquadValidationError
+				assert.True(t, errors.As(err, &validationErr), "An unaffordable squad should fail validation")
+				assert.Equal(t, -4.0, validationErr.Validation.Remaining, "Validation should show the shortfall")
+			}
+
+			// Verify that nothing was saved
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTransferService_WeekInProgress(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockTransferRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockChipRepo := new(repomocks.MockChipRepository)
+
+	// Create transfer service with mocks while the open gameweek is being played
+	weeks := services.NewWeekStatus()
+	weeks.Start(3, 3)
+	service := services.NewTransferService(mockRepo, mockManagerRepo, mockSquadRepo, mockPlayerRepo, mockMatchRepo, mockChipRepo, weeks, services.DefaultFantasyConfig())
+
+	// Set up mock expectations
+	mockManagerRepo.On("GetByID", 1).Return(&models.Manager{ID: 1, Bank: 10, FreeTransfers: 1}, nil).Once()
+	mockMatchRepo.On("GetUnplayedWeeks").Return([]int{3, 4}, nil).Twice()
+
+	// Call the functions under test
+	deadline, deadlineErr := service.GetDeadline()
+	result, err := service.MakeTransfers(1, 0, []models.TransferRequest{{PlayerOutID: 6, PlayerInID: 16}})
+
+	// Assertions
+	assert.NoError(t, deadlineErr, "GetDeadline should not return an error")
+	assert.Equal(t, &models.TransferDeadline{Week: 3, Open: false}, deadline, "The week being played should be shown as closed")
+	assert.Nil(t, result, "No result should be returned")
+	assert.ErrorIs(t, err, services.ErrWeekInProgress, "Transfers should wait until the week has been played")
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// Verify that all expected calls were made
+	mockManagerRepo.AssertExpectations(t)
+	mockMatchRepo.AssertExpectations(t)
+}
+
+func TestTransferService_HandleEvent_WeekPlayed(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockTransferRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockChipRepo := new(repomocks.MockChipRepository)
+
+	// Create transfer service with mocks
+	service := services.NewTransferService(mockRepo, mockManagerRepo, mockSquadRepo, mockPlayerRepo, mockMatchRepo, mockChipRepo, services.NewWeekStatus(), services.DefaultFantasyConfig())
+
+	// Set up mock expectations; a free transfer for each of the two weeks, banked up to the cap
+	mockManagerRepo.On("AddFreeTransfers", 2, 5).Return(nil).Once()
+
+	// Call the function under test with two weeks played at once
+	service.HandleEvent(eventbus.Event{Type: eventbus.WeekPlayed, Matches: []models.Match{
+		{Week: 3, IsPlayed: true},
+		{Week: 3, IsPlayed: true},
+		{Week: 4, IsPlayed: true},
+	}})
+
+	// Verify that all expected calls were made
+	mockManagerRepo.AssertExpectations(t)
+}
+
+func TestTransferService_HandleEvent_LeagueReset(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockTransferRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockChipRepo := new(repomocks.MockChipRepository)
+
+	// Create transfer service with mocks
+	service := services.NewTransferService(mockRepo, mockManagerRepo, mockSquadRepo, mockPlayerRepo, mockMatchRepo, mockChipRepo, services.NewWeekStatus(), services.DefaultFantasyConfig())
+
+	// Set up mock expectations; every manager goes back to a single week's free transfers in one statement
+	mockRepo.On("DeleteAll").Return(nil).Once()
+	mockManagerRepo.On("ResetFreeTransfers", 1).Return(nil).Once()
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{Type: eventbus.LeagueReset})
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockManagerRepo.AssertExpectations(t)
+	mockManagerRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestTransferService_MakeTransfers_FreeHit(t *testing.T) {
+	// Create mock repositories
+	mockRepo := new(repomocks.MockTransferRepository)
+	mockManagerRepo := new(repomocks.MockManagerRepository)
+	mockSquadRepo := new(repomocks.MockFantasySquadRepository)
+	mockPlayerRepo := new(repomocks.MockPlayerRepository)
+	mockMatchRepo := new(repomocks.MockMatchRepository)
+	mockChipRepo := new(repomocks.MockChipRepository)
+
+	// Create transfer service with mocks
+	service := services.NewTransferService(mockRepo, mockManagerRepo, mockSquadRepo, mockPlayerRepo, mockMatchRepo, mockChipRepo, services.NewWeekStatus(), services.DefaultFantasyConfig())
+
+	ids, players := fantasySquadPlayers(6.0)
+	manager := &models.Manager{ID: 1, Bank: 0, FreeTransfers: 1}
+	newIDs := slices.Clone(ids)
+	newPlayers := slices.Clone(players)
+	for i, index := range []int{5, 8} {
+		replacement := players[index]
+		replacement.ID = uint(16 + i)
+		newIDs[index], newPlayers[index] = replacement.ID, replacement
+	}
+
+	// Set up mock expectations
+	mockManagerRepo.On("GetByID", 1).Return(manager, nil).Once()
+	mockMatchRepo.On("GetUnplayedWeeks").Return([]int{3}, nil).Once()
+	mockSquadRepo.On("GetByManager", 1).Return(fantasySquad(1, players), nil).Once()
+	mockChipRepo.On("GetByManagerWeek", 1, 3).Return(&models.ChipActivation{ManagerID: 1, Week: 3, Chip: models.ChipFreeHit}, nil).Once()
+	mockPlayerRepo.On("GetByIDs", newIDs).Return(newPlayers, nil).Once()
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(transfers []models.Transfer) bool {
+		return len(transfers) == 2 && transfers[0].Cost == 0 && transfers[1].Cost == 0
+	}), 0.0, 0).Return(nil).Once()
+
+	// Call the function under test
+	result, err := service.MakeTransfers(1, 0, []models.TransferRequest{
+		{PlayerOutID: ids[5], PlayerInID: 16},
+		{PlayerOutID: ids[8], PlayerInID: 17},
+	})
+
+	// Assertions
+	assert.NoError(t, err, "MakeTransfers should not return an error")
+	assert.Equal(t, models.ChipFreeHit, result.Chip, "The active chip should be reported")
+	assert.Equal(t, 0, result.PointsCost, "A free hit should make every transfer free")
+	assert.Equal(t, 0, result.FreeTransfersUsed, "A free hit should leave the free transfer banked")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+	mockManagerRepo.AssertExpectations(t)
+}
@@ -0,0 +1,324 @@
+package tests
+
+import (
+	"encoding/json"
+	"insider-league/eventbus"
+	repomocks "insider-league/mocks/repository"
+	"insider-league/models"
+	"insider-league/services"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testWebhookConfig retries quickly so tests don't wait on real backoff, and allows the local test subscribers
+func testWebhookConfig() services.WebhookConfig {
+	return services.WebhookConfig{MaxAttempts: 3, BaseBackoff: time.Millisecond, Timeout: time.Second, Workers: 2, QueueSize: 8, AllowPrivateTargets: true}
+}
+
+// receivedWebhook is a request captured by the test subscriber
+type receivedWebhook struct {
+	event     string
+	signature string
+	body      []byte
+}
+
+func TestWebhookService_Create(t *testing.T) {
+	// Create mock repository
+	mockRepo := new(repomocks.MockWebhookRepository)
+
+	// Create webhook service with mock
+	service := services.NewWebhookService(mockRepo, testWebhookConfig())
+
+	// Set up mock expectations
+	mockRepo.On("Create", mock.AnythingOfType("*models.WebhookSubscription")).Return(nil).Once()
+
+	// Call the function under test
+	subscription := &models.WebhookSubscription{TargetURL: "https://partner.example/hooks", Events: []string{models.WebhookMatchPlayed}}
+	err := service.Create(subscription)
+
+	// Assertions
+	assert.NoError(t, err, "Create should not return an error")
+	assert.Len(t, subscription.Secret, 64, "A signing secret should be generated")
+	assert.True(t, subscription.Active, "New subscriptions should be active")
+
+	// Invalid target URLs and unknown events are rejected before storage
+	err = service.Create(&models.WebhookSubscription{TargetURL: "ftp://partner.example"})
+	assert.ErrorIs(t, err, services.ErrInvalidWebhook, "Non-HTTP targets should be rejected")
+	err = service.Create(&models.WebhookSubscription{TargetURL: "https://partner.example", Events: []string{"goal"}})
+	assert.ErrorIs(t, err, services.ErrInvalidWebhook, "Unknown events should be rejected")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWebhookService_Create_RejectsInternalTargets(t *testing.T) {
+	// Create mock repository
+	mockRepo := new(repomocks.MockWebhookRepository)
+
+	// Create webhook service with mock and the default protection against internal targets
+	config := testWebhookConfig()
+	config.AllowPrivateTargets = false
+	service := services.NewWebhookService(mockRepo, config)
+
+	targets := []string{
+		"http://localhost:8080/hooks",
+		"http://api.localhost/hooks",
+		"http://127.0.0.1/hooks",
+		"https://10.0.0.5/hooks",
+		"http://192.168.1.20:9000",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]:8080/hooks",
+		"http://0.0.0.0/",
+	}
+
+	for _, target := range targets {
+		// Call the function under test
+		err := service.Create(&models.WebhookSubscription{TargetURL: target})
+
+		// Assertions
+		assert.ErrorIs(t, err, services.ErrInvalidWebhook, "%s should be rejected", target)
+	}
+
+	// Verify that nothing was stored
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestWebhookService_HandleEvent_RefusesInternalDeliveries(t *testing.T) {
+	// Local subscriber that must never be reached
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Create mock repository
+	mockRepo := new(repomocks.MockWebhookRepository)
+
+	// Create webhook service with mock and the default protection against internal targets
+	config := testWebhookConfig()
+	config.MaxAttempts = 1
+	config.AllowPrivateTargets = false
+	service := services.NewWebhookService(mockRepo, config)
+
+	// Set up mock expectations: a subscription stored before its host started resolving to a loopback address
+	deliveries := make(chan *models.WebhookDelivery, 1)
+	mockRepo.On("GetAll").Return([]models.WebhookSubscription{{ID: 7, TargetURL: server.URL, Secret: "s3cret", Active: true}}, nil).Once()
+	mockRepo.On("CreateDelivery", mock.AnythingOfType("*models.WebhookDelivery")).Run(func(args mock.Arguments) {
+		deliveries <- args.Get(0).(*models.WebhookDelivery)
+	}).Return(nil).Once()
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{Type: eventbus.LeagueReset})
+
+	// Assertions
+	select {
+	case delivery := <-deliveries:
+		assert.False(t, delivery.Success, "Delivery to a loopback address should fail")
+		assert.Contains(t, delivery.Error, "loopback", "The failure should say why")
+	case <-time.After(2 * time.Second):
+		t.Fatal("delivery attempt was not logged")
+	}
+	assert.Equal(t, int32(0), calls.Load(), "The internal subscriber should not be reached")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWebhookService_HandleEvent_DeliversSignedPayloads(t *testing.T) {
+	// Local subscriber that records every request
+	received := make(chan receivedWebhook, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- receivedWebhook{
+			event:     r.Header.Get(services.WebhookEventHeader),
+			signature: r.Header.Get(services.WebhookSignatureHeader),
+			body:      body,
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// Create mock repository
+	mockRepo := new(repomocks.MockWebhookRepository)
+
+	// Create webhook service with mock
+	service := services.NewWebhookService(mockRepo, testWebhookConfig())
+
+	// One subscriber wants match results only, another only league resets
+	subscriptions := []models.WebhookSubscription{
+		{ID: 1, TargetURL: server.URL, Events: []string{models.WebhookMatchPlayed}, Secret: "s3cret", Active: true},
+		{ID: 2, TargetURL: server.URL, Events: []string{models.WebhookLeagueReset}, Secret: "other", Active: true},
+	}
+
+	// Set up mock expectations
+	deliveries := make(chan *models.WebhookDelivery, 4)
+	mockRepo.On("GetAll").Return(subscriptions, nil).Once()
+	mockRepo.On("CreateDelivery", mock.AnythingOfType("*models.WebhookDelivery")).Run(func(args mock.Arguments) {
+		deliveries <- args.Get(0).(*models.WebhookDelivery)
+	}).Return(nil)
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{
+		Type:    eventbus.WeekPlayed,
+		Week:    1,
+		Matches: []models.Match{{ID: 1, Week: 1, HomeTeamID: 1, AwayTeamID: 2, HomeTeamScore: 2, AwayTeamScore: 1, IsPlayed: true}},
+	})
+
+	// Assertions
+	select {
+	case hook := <-received:
+		assert.Equal(t, models.WebhookMatchPlayed, hook.event, "Event header should name the event")
+		assert.Equal(t, services.SignWebhookPayload("s3cret", hook.body), hook.signature, "Body should be signed with the subscription secret")
+
+		var payload models.WebhookPayload
+		assert.NoError(t, json.Unmarshal(hook.body, &payload), "Body should be JSON")
+		assert.Equal(t, uint(1), payload.Match.ID, "Payload should carry the played match")
+	case <-time.After(2 * time.Second):
+		t.Fatal("webhook was not delivered")
+	}
+
+	select {
+	case delivery := <-deliveries:
+		assert.Equal(t, uint(1), delivery.SubscriptionID, "Delivery should be logged against the subscription")
+		assert.True(t, delivery.Success, "Delivery should be logged as successful")
+		assert.Equal(t, 1, delivery.Attempt, "Delivery should succeed on the first attempt")
+	case <-time.After(2 * time.Second):
+		t.Fatal("delivery was not logged")
+	}
+
+	// The league reset subscriber must not be called for a played week
+	select {
+	case hook := <-received:
+		t.Fatalf("unexpected %s webhook delivered", hook.event)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWebhookService_HandleEvent_RetriesFailedDeliveries(t *testing.T) {
+	// Local subscriber that fails twice before accepting
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Create mock repository
+	mockRepo := new(repomocks.MockWebhookRepository)
+
+	// Create webhook service with mock
+	service := services.NewWebhookService(mockRepo, testWebhookConfig())
+
+	// Set up mock expectations
+	deliveries := make(chan *models.WebhookDelivery, 4)
+	mockRepo.On("GetAll").Return([]models.WebhookSubscription{{ID: 7, TargetURL: server.URL, Secret: "s3cret", Active: true}}, nil).Once()
+	mockRepo.On("CreateDelivery", mock.AnythingOfType("*models.WebhookDelivery")).Run(func(args mock.Arguments) {
+		deliveries <- args.Get(0).(*models.WebhookDelivery)
+	}).Return(nil).Times(3)
+
+	// Call the function under test
+	service.HandleEvent(eventbus.Event{Type: eventbus.LeagueReset})
+
+	// Assertions
+	var logged []*models.WebhookDelivery
+	for range 3 {
+		select {
+		case delivery := <-deliveries:
+			logged = append(logged, delivery)
+		case <-time.After(2 * time.Second):
+			t.Fatal("delivery attempts were not logged")
+		}
+	}
+	assert.Equal(t, http.StatusInternalServerError, logged[0].StatusCode, "First attempt should record the failure status")
+	assert.False(t, logged[1].Success, "Second attempt should fail")
+	assert.True(t, logged[2].Success, "Third attempt should succeed")
+	assert.Equal(t, 3, logged[2].Attempt, "Attempts should be numbered")
+	assert.Equal(t, logged[0].DeliveryID, logged[2].DeliveryID, "Retries should share a delivery ID")
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWebhookService_HandleEvent_DropsDeliveriesBeyondTheQueue(t *testing.T) {
+	// Local subscriber that holds every request until released
+	started := make(chan struct{}, 4)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// Create mock repository
+	mockRepo := new(repomocks.MockWebhookRepository)
+
+	// Create webhook service with one worker and room for one waiting delivery
+	config := testWebhookConfig()
+	config.Workers = 1
+	config.QueueSize = 1
+	service := services.NewWebhookService(mockRepo, config)
+
+	// Set up mock expectations
+	deliveries := make(chan *models.WebhookDelivery, 4)
+	mockRepo.On("GetAll").Return([]models.WebhookSubscription{{ID: 3, TargetURL: server.URL, Secret: "s3cret", Active: true}}, nil).Twice()
+	mockRepo.On("CreateDelivery", mock.AnythingOfType("*models.WebhookDelivery")).Run(func(args mock.Arguments) {
+		deliveries <- args.Get(0).(*models.WebhookDelivery)
+	}).Return(nil).Twice()
+
+	// Call the function under test: the first reset keeps the only worker busy
+	service.HandleEvent(eventbus.Event{Type: eventbus.LeagueReset})
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("first webhook was not sent")
+	}
+
+	// Two matches arrive while the worker is busy: one waits in the queue and the other is dropped
+	done := make(chan struct{})
+	go func() {
+		service.HandleEvent(eventbus.Event{Type: eventbus.WeekPlayed, Week: 1, Matches: []models.Match{{ID: 1, Week: 1}, {ID: 2, Week: 1}}})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("HandleEvent waited for a free worker")
+	}
+	close(release)
+
+	// Assertions
+	var logged []*models.WebhookDelivery
+	for range 2 {
+		select {
+		case delivery := <-deliveries:
+			logged = append(logged, delivery)
+		case <-time.After(2 * time.Second):
+			t.Fatal("deliveries were not logged")
+		}
+	}
+	assert.Equal(t, models.WebhookLeagueReset, logged[0].Event, "The busy worker should finish its delivery first")
+	assert.Equal(t, models.WebhookMatchPlayed, logged[1].Event, "The queued delivery should be sent next")
+	select {
+	case delivery := <-deliveries:
+		t.Fatalf("dropped %s delivery was sent", delivery.Event)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Verify that all expected calls were made
+	mockRepo.AssertExpectations(t)
+}
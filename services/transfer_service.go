@@ -0,0 +1,273 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"insider-league/eventbus"
+	"insider-league/models"
+	"insider-league/repository"
+	"log"
+	"slices"
+)
+
+var (
+	// ErrInvalidTransfer is returned when a transfer request cannot be applied to the squad
+	ErrInvalidTransfer = errors.New("invalid transfer")
+
+	// ErrDeadlinePassed is returned when transfers target a gameweek that has already been played
+	ErrDeadlinePassed = errors.New("the transfer deadline for this gameweek has passed")
+
+	// ErrSeasonOver is returned when every gameweek has been played and there is nothing left to change
+	ErrSeasonOver = errors.New("the season is over; no gameweeks are left")
+
+	// ErrGameweekNotOpen is returned when changes target a gameweek after the open one
+	ErrGameweekNotOpen = errors.New("changes can only be made for the open gameweek")
+)
+
+// TransferService defines the interface for fantasy transfer operations
+type TransferService interface {
+	GetDeadline() (*models.TransferDeadline, error)
+	MakeTransfers(managerID, week int, requests []models.TransferRequest) (*models.TransferResult, error)
+	GetHistory(managerID int) ([]models.Transfer, error)
+	HandleEvent(event eventbus.Event)
+}
+
+// transferService implements TransferService interface
+type transferService struct {
+	repo        repository.TransferRepository
+	managerRepo repository.ManagerRepository
+	squadRepo   repository.FantasySquadRepository
+	playerRepo  repository.PlayerRepository
+	matchRepo   repository.MatchRepository
+	chipRepo    repository.ChipRepository
+	weeks       *WeekStatus
+	config      FantasyConfig
+}
+
+// NewTransferService creates a new instance of transferService
+func NewTransferService(repo repository.TransferRepository, managerRepo repository.ManagerRepository, squadRepo repository.FantasySquadRepository, playerRepo repository.PlayerRepository, matchRepo repository.MatchRepository, chipRepo repository.ChipRepository, weeks *WeekStatus, config FantasyConfig) TransferService {
+	return &transferService{
+		repo:        repo,
+		managerRepo: managerRepo,
+		squadRepo:   squadRepo,
+		playerRepo:  playerRepo,
+		matchRepo:   matchRepo,
+		chipRepo:    chipRepo,
+		weeks:       weeks,
+		config:      config,
+	}
+}
+
+// GetDeadline returns the next unplayed week, which transfers count towards until it starts being simulated
+func (s *transferService) GetDeadline() (*models.TransferDeadline, error) {
+	return openGameweek(s.matchRepo, s.weeks)
+}
+
+// openGameweek returns the next unplayed week, which squad changes count towards until it starts being
+// simulated. The week stays closed while it is being played; a week of 0 means the season is over.
+func openGameweek(matchRepo repository.MatchRepository, status *WeekStatus) (*models.TransferDeadline, error) {
+	weeks, err := matchRepo.GetUnplayedWeeks()
+	if err != nil {
+		return nil, err
+	}
+	if len(weeks) == 0 {
+		return &models.TransferDeadline{}, nil
+	}
+	return &models.TransferDeadline{Week: weeks[0], Open: !status.Playing(weeks[0])}, nil
+}
+
+// seasonLength returns the last week of the season
+func seasonLength(matchRepo repository.MatchRepository) (int, error) {
+	matches, err := matchRepo.GetAll()
+	if err != nil {
+		return 0, err
+	}
+	weeks := 0
+	for _, match := range matches {
+		weeks = max(weeks, match.Week)
+	}
+	return weeks, nil
+}
+
+// deadlineWeek resolves the gameweek a squad change is for. 0 means the open gameweek,
+// and any earlier week has already been simulated so its deadline has passed. Nothing can be changed for the
+// open gameweek while it is being played.
+func deadlineWeek(matchRepo repository.MatchRepository, status *WeekStatus, week int) (int, error) {
+	deadline, err := openGameweek(matchRepo, status)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case deadline.Week == 0:
+		return 0, ErrSeasonOver
+	case week != 0 && week < deadline.Week:
+		return 0, ErrDeadlinePassed
+	case week > deadline.Week:
+		return 0, fmt.Errorf("%w: gameweek %d is open", ErrGameweekNotOpen, deadline.Week)
+	case !deadline.Open:
+		return 0, ErrWeekInProgress
+	}
+	return deadline.Week, nil
+}
+
+// MakeTransfers swaps players in a manager's squad for the next gameweek. The new squad must still follow the
+// fantasy rules and fit the bank plus what the outgoing players sell for, which keeps only part of any rise since
+// purchase. Transfers beyond the free ones cost points in that gameweek unless a wildcard or free hit is active.
+// A week of 0 means the open gameweek; any earlier week is rejected as past its deadline.
+func (s *transferService) MakeTransfers(managerID, week int, requests []models.TransferRequest) (*models.TransferResult, error) {
+	manager, err := s.managerRepo.GetByID(managerID)
+	if err != nil {
+		return nil, err
+	}
+
+	week, err = deadlineWeek(s.matchRepo, s.weeks, week)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("%w: no transfers requested", ErrInvalidTransfer)
+	}
+
+	squad, err := s.squadRepo.GetByManager(managerID)
+	if err != nil {
+		return nil, err
+	}
+	chip, err := activeChip(s.chipRepo, managerID, week)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the swaps to the squad's player IDs. The squad is valued at current prices, less what each outgoing
+	// player gives up by selling below his current price.
+	setSellingPrices(squad, s.config)
+	owned := make(map[uint]models.FantasySquadPlayer)
+	sold := make(map[uint]bool)
+	playerIDs := make([]uint, len(squad.Players))
+	budget := manager.Bank
+	for i, squadPlayer := range squad.Players {
+		owned[squadPlayer.PlayerID] = squadPlayer
+		playerIDs[i] = squadPlayer.PlayerID
+		budget += squadPlayer.Player.Price
+	}
+	for _, request := range requests {
+		index := slices.Index(playerIDs, request.PlayerOutID)
+		if index < 0 {
+			return nil, fmt.Errorf("%w: player %d is not in the squad", ErrInvalidTransfer, request.PlayerOutID)
+		}
+		if request.PlayerInID == request.PlayerOutID {
+			return nil, fmt.Errorf("%w: player %d cannot replace himself", ErrInvalidTransfer, request.PlayerOutID)
+		}
+		if squadPlayer, ok := owned[request.PlayerOutID]; ok && !sold[request.PlayerOutID] {
+			budget -= squadPlayer.Player.Price - squadPlayer.SellingPrice
+			sold[request.PlayerOutID] = true
+		}
+		playerIDs[index] = request.PlayerInID
+	}
+
+	players, err := s.playerRepo.GetByIDs(playerIDs)
+	if err != nil {
+		return nil, err
+	}
+	validation := validateSquad(playerIDs, players, s.config, budget)
+	if !validation.Valid {
+		return nil, &SquadValidationError{Validation: validation}
+	}
+
+	// Keep what was paid for the players who stay and record the price of the new ones; a player sold and
+	// bought back counts as bought again at his current price
+	byID := make(map[uint]models.Player)
+	for _, player := range players {
+		byID[player.ID] = player
+	}
+	squad.Players = nil
+	for _, id := range playerIDs {
+		squadPlayer, ok := owned[id]
+		if !ok || sold[id] {
+			squadPlayer = models.FantasySquadPlayer{PlayerID: id, PurchasePrice: byID[id].Price}
+		}
+		squadPlayer.Player = byID[id]
+		squad.Players = append(squad.Players, squadPlayer)
+	}
+	setSellingPrices(squad, s.config)
+
+	result := &models.TransferResult{Week: week, Chip: chip, Squad: squad}
+	for _, request := range requests {
+		transfer := models.Transfer{
+			ManagerID:     manager.ID,
+			Week:          week,
+			PlayerOutID:   request.PlayerOutID,
+			PlayerInID:    request.PlayerInID,
+			SellingPrice:  owned[request.PlayerOutID].SellingPrice,
+			PurchasePrice: byID[request.PlayerInID].Price,
+		}
+		switch {
+		case freeTransfersChip(chip):
+			// A wildcard or free hit week costs nothing and leaves the free transfers banked
+		case manager.FreeTransfers > 0:
+			manager.FreeTransfers--
+			result.FreeTransfersUsed++
+		default:
+			transfer.Cost = s.config.TransferHit
+			result.PointsCost += transfer.Cost
+		}
+		result.Transfers = append(result.Transfers, transfer)
+	}
+
+	manager.Bank = validation.Remaining
+	if err := s.repo.Create(squad, result.Transfers, manager.Bank, result.FreeTransfersUsed); err != nil {
+		return nil, err
+	}
+
+	result.FreeTransfersLeft = manager.FreeTransfers
+	result.Bank = manager.Bank
+	return result, nil
+}
+
+// GetHistory retrieves an existing manager's transfers, oldest first
+func (s *transferService) GetHistory(managerID int) ([]models.Transfer, error) {
+	// Make sure the manager exists so unknown IDs surface as not found
+	if _, err := s.managerRepo.GetByID(managerID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByManager(managerID)
+}
+
+// HandleEvent grants free transfers for every week played and clears transfers on reset.
+// It is meant to be subscribed to the event bus.
+func (s *transferService) HandleEvent(event eventbus.Event) {
+	var err error
+	switch event.Type {
+	case eventbus.WeekPlayed:
+		var weeks []int
+		for _, match := range event.Matches {
+			if match.IsPlayed && !slices.Contains(weeks, match.Week) {
+				weeks = append(weeks, match.Week)
+			}
+		}
+		err = s.grantFreeTransfers(len(weeks))
+	case eventbus.LeagueReset:
+		err = s.resetTransfers()
+	}
+	if err != nil {
+		log.Printf("Failed to update fantasy transfers after %s: %v", event.Type, err)
+	}
+}
+
+// grantFreeTransfers gives every manager their free transfers for each played week, up to the rollover cap
+func (s *transferService) grantFreeTransfers(weeks int) error {
+	if weeks == 0 {
+		return nil
+	}
+
+	return s.managerRepo.AddFreeTransfers(weeks*s.config.FreeTransfers, s.config.MaxFreeTransfers)
+}
+
+// resetTransfers removes the transfer history and puts every manager back on a single week's free transfers
+func (s *transferService) resetTransfers() error {
+	if err := s.repo.DeleteAll(); err != nil {
+		return err
+	}
+
+	return s.managerRepo.ResetFreeTransfers(s.config.FreeTransfers)
+}
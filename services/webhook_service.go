@@ -0,0 +1,384 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"insider-league/eventbus"
+	"insider-league/models"
+	"insider-league/repository"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Webhook request headers sent with every delivery
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+var (
+	// ErrInvalidWebhook is returned when a subscription has a bad target URL or unknown event names
+	ErrInvalidWebhook = errors.New("webhook must have a public http(s) target URL and known event names")
+
+	// errPrivateWebhookTarget is returned when a delivery would connect to a loopback, private or link-local address
+	errPrivateWebhookTarget = errors.New("webhook target resolves to a loopback, private or link-local address")
+)
+
+// webhookEvents lists the event names a subscription may filter on
+var webhookEvents = []string{
+	models.WebhookMatchPlayed,
+	models.WebhookResultEdited,
+	models.WebhookSeasonFinished,
+	models.WebhookLeagueReset,
+}
+
+// WebhookConfig holds the tunable parameters of webhook delivery
+type WebhookConfig struct {
+	// MaxAttempts is how many times a delivery is tried before giving up
+	MaxAttempts int
+
+	// BaseBackoff is the wait before the first retry; it doubles on every further retry
+	BaseBackoff time.Duration
+
+	// Timeout bounds a single HTTP request to a subscriber
+	Timeout time.Duration
+
+	// Workers is how many deliveries run at the same time
+	Workers int
+
+	// QueueSize is how many deliveries may wait for a free worker; deliveries beyond it are dropped and logged
+	QueueSize int
+
+	// AllowPrivateTargets lets subscriptions point at loopback and private network addresses, which are otherwise
+	// refused so the server cannot be used to reach internal services
+	AllowPrivateTargets bool
+}
+
+// DefaultWebhookConfig returns the delivery parameters used when nothing is overridden
+func DefaultWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		MaxAttempts: 5,
+		BaseBackoff: time.Second,
+		Timeout:     10 * time.Second,
+		Workers:     4,
+		QueueSize:   256,
+	}
+}
+
+// WebhookService defines the interface for webhook subscription and delivery operations
+type WebhookService interface {
+	GetAll() ([]models.WebhookSubscription, error)
+	GetByID(id int) (*models.WebhookSubscription, error)
+	Create(subscription *models.WebhookSubscription) error
+	Delete(id int) error
+	GetDeliveries(subscriptionID int) ([]models.WebhookDelivery, error)
+	HandleEvent(event eventbus.Event)
+}
+
+// webhookDelivery is a payload waiting in the queue for a subscriber
+type webhookDelivery struct {
+	subscription models.WebhookSubscription
+	payload      models.WebhookPayload
+}
+
+// webhookService implements WebhookService interface
+type webhookService struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+	config WebhookConfig
+	queue  chan webhookDelivery
+}
+
+// NewWebhookService creates a new instance of webhookService
+func NewWebhookService(repo repository.WebhookRepository, config WebhookConfig) WebhookService {
+	client := &http.Client{Timeout: config.Timeout}
+	if !config.AllowPrivateTargets {
+		// Check the address actually dialled, so hostnames that resolve or redirect to internal addresses are caught too
+		dialer := &net.Dialer{Timeout: config.Timeout, Control: refusePrivateAddress}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DialContext = dialer.DialContext
+		// A proxy from the environment would be dialled instead of the target, so the check above would never see it
+		transport.Proxy = nil
+		client.Transport = transport
+	}
+
+	service := &webhookService{
+		repo:   repo,
+		client: client,
+		config: config,
+		queue:  make(chan webhookDelivery, max(config.QueueSize, 0)),
+	}
+
+	// A fixed pool of workers bounds the connections and goroutines a burst of events can open
+	for range max(config.Workers, 1) {
+		go service.work()
+	}
+	return service
+}
+
+// GetAll retrieves all webhook subscriptions
+func (s *webhookService) GetAll() ([]models.WebhookSubscription, error) {
+	return s.repo.GetAll()
+}
+
+// GetByID retrieves a webhook subscription by its ID
+func (s *webhookService) GetByID(id int) (*models.WebhookSubscription, error) {
+	return s.repo.GetByID(id)
+}
+
+// Create validates and stores a subscription, generating a signing secret when none is given
+func (s *webhookService) Create(subscription *models.WebhookSubscription) error {
+	target, err := url.Parse(subscription.TargetURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Hostname() == "" {
+		return ErrInvalidWebhook
+	}
+	if !s.config.AllowPrivateTargets && privateHost(target.Hostname()) {
+		return ErrInvalidWebhook
+	}
+	for _, event := range subscription.Events {
+		if !slices.Contains(webhookEvents, event) {
+			return ErrInvalidWebhook
+		}
+	}
+
+	if subscription.Secret == "" {
+		secret, err := randomHex(32)
+		if err != nil {
+			return err
+		}
+		subscription.Secret = secret
+	}
+	subscription.Active = true
+
+	return s.repo.Create(subscription)
+}
+
+// Delete removes a webhook subscription
+func (s *webhookService) Delete(id int) error {
+	return s.repo.Delete(id)
+}
+
+// GetDeliveries retrieves the delivery log of an existing subscription
+func (s *webhookService) GetDeliveries(subscriptionID int) ([]models.WebhookDelivery, error) {
+	// Make sure the subscription exists so unknown IDs surface as not found
+	if _, err := s.repo.GetByID(subscriptionID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetDeliveries(subscriptionID)
+}
+
+// HandleEvent turns a league event into webhook payloads and queues them for the delivery workers.
+// It is meant to be subscribed to the event bus, so it never waits for a worker.
+func (s *webhookService) HandleEvent(event eventbus.Event) {
+	payloads := webhookPayloads(event)
+	if len(payloads) == 0 {
+		return
+	}
+
+	subscriptions, err := s.repo.GetAll()
+	if err != nil {
+		log.Printf("Failed to load webhook subscriptions: %v", err)
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		if !subscription.Active {
+			continue
+		}
+		for _, payload := range payloads {
+			if len(subscription.Events) > 0 && !slices.Contains(subscription.Events, payload.Event) {
+				continue
+			}
+			select {
+			case s.queue <- webhookDelivery{subscription: subscription, payload: payload}:
+			default:
+				log.Printf("Webhook delivery queue is full; dropped %s for subscription %d", payload.Event, subscription.ID)
+			}
+		}
+	}
+}
+
+// work delivers queued payloads one at a time for as long as the service lives
+func (s *webhookService) work() {
+	for delivery := range s.queue {
+		s.deliver(delivery.subscription, delivery.payload)
+	}
+}
+
+// webhookPayloads maps a bus event to the webhook payloads it produces
+func webhookPayloads(event eventbus.Event) []models.WebhookPayload {
+	var payloads []models.WebhookPayload
+	switch event.Type {
+	case eventbus.WeekPlayed:
+		// Every match of the week is announced on its own
+		for i := range event.Matches {
+			payloads = append(payloads, models.WebhookPayload{
+				Event: models.WebhookMatchPlayed,
+				Week:  event.Week,
+				Match: &event.Matches[i],
+			})
+		}
+	case eventbus.MatchResultEdited:
+		for i := range event.Matches {
+			payloads = append(payloads, models.WebhookPayload{
+				Event:       models.WebhookResultEdited,
+				Week:        event.Matches[i].Week,
+				Match:       &event.Matches[i],
+				LeagueTable: event.LeagueTable,
+			})
+		}
+	case eventbus.SeasonFinished:
+		payloads = append(payloads, models.WebhookPayload{
+			Event:       models.WebhookSeasonFinished,
+			Week:        event.Week,
+			LeagueTable: event.LeagueTable,
+		})
+	case eventbus.LeagueReset:
+		payloads = append(payloads, models.WebhookPayload{
+			Event:       models.WebhookLeagueReset,
+			LeagueTable: event.LeagueTable,
+		})
+	}
+
+	for i := range payloads {
+		payloads[i].OccurredAt = event.OccurredAt
+	}
+	return payloads
+}
+
+// deliver posts a payload to a subscriber, retrying with exponential backoff and logging every attempt
+func (s *webhookService) deliver(subscription models.WebhookSubscription, payload models.WebhookPayload) {
+	deliveryID, err := randomHex(16)
+	if err != nil {
+		log.Printf("Failed to create webhook delivery ID: %v", err)
+		return
+	}
+	payload.DeliveryID = deliveryID
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("Failed to encode webhook payload: %v", err)
+		return
+	}
+	signature := SignWebhookPayload(subscription.Secret, body)
+
+	backoff := s.config.BaseBackoff
+	for attempt := 1; attempt <= s.config.MaxAttempts; attempt++ {
+		statusCode, err := s.post(subscription.TargetURL, payload.Event, deliveryID, signature, body)
+
+		delivery := &models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			DeliveryID:     deliveryID,
+			Event:          payload.Event,
+			Attempt:        attempt,
+			StatusCode:     statusCode,
+			Success:        err == nil,
+			Payload:        string(body),
+		}
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		if logErr := s.repo.CreateDelivery(delivery); logErr != nil {
+			log.Printf("Failed to record webhook delivery: %v", logErr)
+		}
+
+		if err == nil {
+			return
+		}
+		if attempt < s.config.MaxAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+}
+
+// post sends one signed request and treats any non-2xx response as a failure
+func (s *webhookService) post(target, event, deliveryID, signature string, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookDeliveryHeader, deliveryID)
+	req.Header.Set(WebhookSignatureHeader, signature)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// privateHost reports whether a target host is, or resolves to, a loopback, private or link-local address. Hosts
+// that do not resolve yet are let through; deliveries check the dialled address again.
+func privateHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return privateIP(ip)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(addrs, func(addr net.IPAddr) bool { return privateIP(addr.IP) })
+}
+
+// privateIP reports whether an address is not reachable on the public internet
+func privateIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast()
+}
+
+// refusePrivateAddress is a dialer control that stops deliveries connecting to internal addresses
+func refusePrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || privateIP(ip) {
+		return errPrivateWebhookTarget
+	}
+	return nil
+}
+
+// SignWebhookPayload returns the signature header value for a body, in the form "sha256=<hex HMAC>"
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// randomHex returns n random bytes encoded as hex
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
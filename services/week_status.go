@@ -0,0 +1,43 @@
+package services
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrWeekInProgress is returned when something that closes at kick-off is tried while its week is being played
+var ErrWeekInProgress = errors.New("the gameweek is being played; try again once it has finished")
+
+// WeekStatus shares which gameweeks the league is playing, so whatever closes at kick-off closes as soon as
+// simulation starts rather than once the results are saved
+type WeekStatus struct {
+	mu      sync.RWMutex
+	from    int
+	through int
+}
+
+// NewWeekStatus creates a WeekStatus with no week being played
+func NewWeekStatus() *WeekStatus {
+	return &WeekStatus{}
+}
+
+// Start marks the weeks from one week through another as being played
+func (w *WeekStatus) Start(from, through int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.from, w.through = from, through
+}
+
+// Finish marks that no week is being played any more
+func (w *WeekStatus) Finish() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.from, w.through = 0, 0
+}
+
+// Playing reports whether a week is being played
+func (w *WeekStatus) Playing(week int) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.from > 0 && week >= w.from && week <= w.through
+}